@@ -80,6 +80,7 @@ func (c *Client) Run(urlOpener URLOpener, dns *DNSList, dnsReadyListener DnsRead
 	}
 	c.recorder.UpdateManagementAddress(cfg.ManagementURL.String())
 	c.recorder.UpdateRosenpass(cfg.RosenpassEnabled, cfg.RosenpassPermissive)
+	c.recorder.UpdateCustomDNSTTL(cfg.CustomDNSTTL)
 
 	var ctx context.Context
 	//nolint
@@ -111,6 +112,7 @@ func (c *Client) RunWithoutLogin(dns *DNSList, dnsReadyListener DnsReadyListener
 	}
 	c.recorder.UpdateManagementAddress(cfg.ManagementURL.String())
 	c.recorder.UpdateRosenpass(cfg.RosenpassEnabled, cfg.RosenpassPermissive)
+	c.recorder.UpdateCustomDNSTTL(cfg.CustomDNSTTL)
 
 	var ctx context.Context
 	//nolint
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pion/stun/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/netbirdio/netbird/client/internal"
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/util"
+)
+
+// hop statuses of the network path report
+const (
+	hopOK      = "ok"
+	hopFailed  = "failed"
+	hopSkipped = "skipped"
+)
+
+var diagJSONFlag bool
+
+type networkPathHop struct {
+	Name    string   `json:"name"`
+	Status  string   `json:"status"`
+	Details []string `json:"details"`
+}
+
+type networkPathReport struct {
+	Peer string           `json:"peer"`
+	Path string           `json:"path"`
+	Hops []networkPathHop `json:"hops"`
+}
+
+var diagCmd = &cobra.Command{
+	Use:   "diag",
+	Short: "diagnose the connectivity of the Netbird Service",
+}
+
+var diagNetworkPathCmd = &cobra.Command{
+	Use:   "network-path <fqdn>",
+	Short: "explain the network path to a peer: address discovery, relay, ICE candidate pair and WireGuard tunnel",
+	Args:  cobra.ExactArgs(1),
+	RunE:  diagNetworkPathFunc,
+}
+
+func init() {
+	diagCmd.AddCommand(diagNetworkPathCmd)
+	diagNetworkPathCmd.Flags().BoolVar(&diagJSONFlag, "json", false, "display the network path report in json format")
+}
+
+func diagNetworkPathFunc(cmd *cobra.Command, args []string) error {
+	SetFlagsFromEnvVars(rootCmd)
+
+	cmd.SetOut(cmd.OutOrStdout())
+
+	err := util.InitLog(logLevel, "console")
+	if err != nil {
+		return fmt.Errorf("failed initializing log %v", err)
+	}
+
+	ctx := internal.CtxInitState(context.Background())
+
+	resp, err := getStatus(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	overview := convertToStatusOutputOverview(resp)
+	selected, err := selectPeersByName(overview.Peers.Details, args)
+	if err != nil {
+		return err
+	}
+
+	report := buildNetworkPathReport(overview, selected[0])
+
+	if diagJSONFlag {
+		jsonBytes, err := json.Marshal(report)
+		if err != nil {
+			return fmt.Errorf("json marshal failed")
+		}
+		cmd.Println(string(jsonBytes))
+		return nil
+	}
+
+	cmd.Print(parseNetworkPathReport(report))
+	return nil
+}
+
+// buildNetworkPathReport describes every stage of the connection establishment from the local peer to the remote peer
+func buildNetworkPathReport(overview statusOutputOverview, peerState peerStateDetailOutput) networkPathReport {
+	connected := peerState.Status == peer.StatusConnected.String()
+	localCandidate := peerState.IceCandidateType.Local
+
+	report := networkPathReport{
+		Peer: peerState.FQDN,
+		Path: "none",
+	}
+	if connected {
+		report.Path = strings.ToLower(peerState.ConnType)
+	}
+
+	report.Hops = append(report.Hops, networkPathHop{
+		Name:   "Local peer",
+		Status: hopOK,
+		Details: []string{
+			fmt.Sprintf("FQDN: %s", overview.FQDN),
+			fmt.Sprintf("NetBird IP: %s", overview.IP),
+			fmt.Sprintf("Signal: %s", connectedString(overview.SignalState.Connected)),
+		},
+	})
+
+	discovery := networkPathHop{Name: "Address discovery (STUN)", Status: hopSkipped}
+	stunServers := relaysByScheme(overview.Relays, stun.SchemeTypeSTUN)
+	discovery.Details = append(discovery.Details, stunServers...)
+	if localCandidate == "srflx" || localCandidate == "prflx" {
+		discovery.Status = hopOK
+		discovery.Details = append(discovery.Details, fmt.Sprintf("Public endpoint: %s", peerState.IceCandidateEndpoint.Local))
+	} else if connected {
+		discovery.Details = append(discovery.Details, fmt.Sprintf("Not used, the local candidate is %s", localCandidate))
+	}
+	report.Hops = append(report.Hops, discovery)
+
+	relayHop := networkPathHop{Name: "Relay (TURN)", Status: hopSkipped}
+	relayHop.Details = append(relayHop.Details, relaysByScheme(overview.Relays, stun.SchemeTypeTURN, stun.SchemeTypeTURNS)...)
+	if localCandidate == "relay" {
+		relayHop.Status = hopOK
+		relayHop.Details = append(relayHop.Details, fmt.Sprintf("Relayed endpoint: %s", peerState.IceCandidateEndpoint.Local))
+	} else if connected {
+		relayHop.Details = append(relayHop.Details, "Not used, the peers are connected directly")
+	}
+	report.Hops = append(report.Hops, relayHop)
+
+	iceHop := networkPathHop{Name: "ICE candidate pair", Status: hopFailed}
+	if connected {
+		iceHop.Status = hopOK
+		iceHop.Details = []string{
+			fmt.Sprintf("Local: %s %s", peerState.IceCandidateType.Local, peerState.IceCandidateEndpoint.Local),
+			fmt.Sprintf("Remote: %s %s", peerState.IceCandidateType.Remote, peerState.IceCandidateEndpoint.Remote),
+			fmt.Sprintf("Direct: %t", peerState.Direct),
+		}
+	} else {
+		iceHop.Details = []string{fmt.Sprintf("No candidate pair selected, the peer is %s", peerState.Status)}
+	}
+	report.Hops = append(report.Hops, iceHop)
+
+	wgHop := networkPathHop{Name: "WireGuard tunnel", Status: hopFailed}
+	if connected && !peerState.LastWireguardHandshake.IsZero() {
+		wgHop.Status = hopOK
+	}
+	wgHop.Details = []string{
+		fmt.Sprintf("Last handshake: %s", formatOptionalTime(peerState.LastWireguardHandshake)),
+		fmt.Sprintf("Transfer (received/sent): %s/%s", toIEC(peerState.TransferReceived), toIEC(peerState.TransferSent)),
+	}
+	report.Hops = append(report.Hops, wgHop)
+
+	remoteHop := networkPathHop{Name: "Remote peer", Status: hopFailed}
+	if connected {
+		remoteHop.Status = hopOK
+	}
+	latency := "-"
+	if peerState.Latency > 0 {
+		latency = peerState.Latency.String()
+	}
+	remoteHop.Details = []string{
+		fmt.Sprintf("FQDN: %s", peerState.FQDN),
+		fmt.Sprintf("NetBird IP: %s", peerState.IP),
+		fmt.Sprintf("Status: %s", peerState.Status),
+		fmt.Sprintf("Latency: %s", latency),
+	}
+	report.Hops = append(report.Hops, remoteHop)
+
+	return report
+}
+
+func parseNetworkPathReport(report networkPathReport) string {
+	output := fmt.Sprintf("Network path to %s (%s)\n", report.Peer, report.Path)
+	for i, hop := range report.Hops {
+		output += fmt.Sprintf("\n %d. %s [%s]\n", i+1, hop.Name, hop.Status)
+		for _, detail := range hop.Details {
+			output += fmt.Sprintf("    %s\n", detail)
+		}
+	}
+	return output
+}
+
+func relaysByScheme(relays relayStateOutput, schemes ...stun.SchemeType) []string {
+	var servers []string
+	for _, relayState := range relays.Details {
+		uri, err := stun.ParseURI(relayState.URI)
+		if err != nil {
+			continue
+		}
+		for _, scheme := range schemes {
+			if uri.Scheme != scheme {
+				continue
+			}
+			available := "Available"
+			if !relayState.Available {
+				available = "Unavailable"
+			}
+			servers = append(servers, fmt.Sprintf("Server: %s is %s", relayState.URI, available))
+		}
+	}
+	return servers
+}
+
+func connectedString(connected bool) string {
+	if connected {
+		return "Connected"
+	}
+	return "Disconnected"
+}
+
+func formatOptionalTime(t time.Time) string {
+	if t.IsZero() || t == time.Unix(0, 0) {
+		return "-"
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
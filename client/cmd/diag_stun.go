@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pion/stun/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/netbirdio/netbird/client/internal"
+	"github.com/netbirdio/netbird/client/internal/relay"
+	"github.com/netbirdio/netbird/util"
+)
+
+const (
+	natTestTimeout = 2 * time.Second
+	stunURIPrefix  = "stun:"
+)
+
+var stunTestServers []string
+
+type stunTestOutput struct {
+	Server           string        `json:"server"`
+	MappedAddress    string        `json:"mappedAddress"`
+	ResponseTime     time.Duration `json:"responseTime"`
+	OtherAddress     string        `json:"otherAddress,omitempty"`
+	SecondMappedAddr string        `json:"secondMappedAddress,omitempty"`
+	Hairpin          bool          `json:"hairpin"`
+	NATType          string        `json:"natType"`
+	Description      string        `json:"description"`
+	RelayLikely      string        `json:"relayLikely"`
+}
+
+var diagSTUNTestCmd = &cobra.Command{
+	Use:   "stun-test",
+	Short: "detect the NAT type of this host with STUN binding requests and explain what it means for peer-to-peer connections, e.g., netbird diag stun-test --server stun.cloudflare.com:3478",
+	RunE:  diagSTUNTestFunc,
+}
+
+func init() {
+	diagCmd.AddCommand(diagSTUNTestCmd)
+	diagSTUNTestCmd.Flags().StringSliceVar(&stunTestServers, "server", []string{}, "STUN server to test against as host[:port], a second server is used to detect a symmetric NAT when the first one doesn't support RFC 5780. "+
+		"Defaults to the STUN servers of the daemon, e.g., --server stun.cloudflare.com:3478")
+	diagSTUNTestCmd.Flags().BoolVar(&diagJSONFlag, "json", false, "display the STUN test result in json format")
+}
+
+func diagSTUNTestFunc(cmd *cobra.Command, _ []string) error {
+	SetFlagsFromEnvVars(rootCmd)
+
+	cmd.SetOut(cmd.OutOrStdout())
+
+	err := util.InitLog(logLevel, "console")
+	if err != nil {
+		return fmt.Errorf("failed initializing log %v", err)
+	}
+
+	ctx := internal.CtxInitState(context.Background())
+
+	servers := stunTestServers
+	if len(servers) == 0 {
+		resp, err := getStatus(ctx, cmd)
+		if err != nil {
+			return err
+		}
+		for _, relayState := range convertToStatusOutputOverview(resp).Relays.Details {
+			if strings.HasPrefix(relayState.URI, stunURIPrefix) {
+				servers = append(servers, relayState.URI)
+			}
+		}
+		if len(servers) == 0 {
+			return fmt.Errorf("the daemon has no STUN servers, use --server")
+		}
+	}
+
+	uris, err := parseSTUNServers(servers)
+	if err != nil {
+		return err
+	}
+
+	result, err := relay.DetectNATType(cmd.Context(), uris, natTestTimeout)
+	if err != nil {
+		return fmt.Errorf("STUN test failed: %v", err)
+	}
+
+	output := mapSTUNTest(result)
+
+	if diagJSONFlag {
+		jsonBytes, err := json.Marshal(output)
+		if err != nil {
+			return fmt.Errorf("json marshal failed")
+		}
+		cmd.Println(string(jsonBytes))
+		return nil
+	}
+
+	cmd.Print(parseSTUNTest(output))
+	return nil
+}
+
+// parseSTUNServers accepts stun: URIs and host[:port] addresses, the port defaults to 3478
+func parseSTUNServers(servers []string) ([]*stun.URI, error) {
+	uris := make([]*stun.URI, 0, len(servers))
+	for _, server := range servers {
+		raw := server
+		if !strings.HasPrefix(raw, stunURIPrefix) {
+			raw = stunURIPrefix + raw
+		}
+		uri, err := stun.ParseURI(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid STUN server %s: %v", server, err)
+		}
+		uris = append(uris, uri)
+	}
+	return uris, nil
+}
+
+func mapSTUNTest(result *relay.NATTypeResult) stunTestOutput {
+	description, relayLikely := describeNATType(result.NATType)
+	return stunTestOutput{
+		Server:           result.URI.String(),
+		MappedAddress:    result.MappedAddr,
+		ResponseTime:     result.ResponseTime,
+		OtherAddress:     result.OtherAddr,
+		SecondMappedAddr: result.SecondMappedAddr,
+		Hairpin:          result.Hairpin,
+		NATType:          string(result.NATType),
+		Description:      description,
+		RelayLikely:      relayLikely,
+	}
+}
+
+// describeNATType explains the NAT type for NetBird peer-to-peer connections and whether the relay is likely needed
+func describeNATType(natType relay.NATType) (string, string) {
+	switch natType {
+	case relay.NATTypeFullCone:
+		return "Any host can reach the mapped address once it is allocated. Peer-to-peer connections work with every peer.",
+			"no"
+	case relay.NATTypeRestrictedCone:
+		return "Only hosts this peer sent packets to can reach the mapped address, from any port. Hole punching works with every peer.",
+			"no"
+	case relay.NATTypePortRestricted:
+		return "Only the exact address and port this peer sent packets to can reach the mapped address. Hole punching works unless the remote peer is behind a symmetric NAT.",
+			"only with peers behind a symmetric NAT"
+	case relay.NATTypeSymmetric:
+		return "Every destination gets another mapped address, so the address learned from STUN is useless to the other peers. Peer-to-peer connections work only with peers that are reachable without hole punching.",
+			"yes"
+	case relay.NATTypeCone:
+		return "The mapped address is the same for every destination, which allows hole punching. The filtering couldn't be tested because the server doesn't support RFC 5780.",
+			"unlikely"
+	default:
+		return "The mapping couldn't be compared because the server doesn't support RFC 5780, pass a second --server to detect a symmetric NAT.",
+			"unknown"
+	}
+}
+
+func parseSTUNTest(output stunTestOutput) string {
+	otherAddress := "not advertised, the server doesn't support RFC 5780"
+	if output.OtherAddress != "" {
+		otherAddress = output.OtherAddress
+	}
+
+	secondMapped := "-"
+	if output.SecondMappedAddr != "" {
+		secondMapped = output.SecondMappedAddr
+	}
+
+	hairpin := "not supported"
+	if output.Hairpin {
+		hairpin = "supported"
+	}
+
+	return fmt.Sprintf(
+		"STUN server: %s\n"+
+			"Mapped address: %s (responded in %s)\n"+
+			"Alternate address: %s\n"+
+			"Second mapped address: %s\n"+
+			"Hairpin: %s\n"+
+			"NAT type: %s\n"+
+			"  %s\n"+
+			"Relay needed: %s\n",
+		output.Server,
+		output.MappedAddress,
+		output.ResponseTime.Round(time.Millisecond),
+		otherAddress,
+		secondMapped,
+		hairpin,
+		output.NATType,
+		output.Description,
+		output.RelayLikely,
+	)
+}
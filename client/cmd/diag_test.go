@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pion/stun/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/client/internal/relay"
+)
+
+func TestParseNetworkPathReport(t *testing.T) {
+	report := buildNetworkPathReport(overview, overview.Peers.Details[1])
+
+	expected := "Network path to peer-2.awesome-domain.com (relayed)\n" +
+		"\n 1. Local peer [ok]\n" +
+		"    FQDN: some-localhost.awesome-domain.com\n" +
+		"    NetBird IP: 192.168.178.100/16\n" +
+		"    Signal: Connected\n" +
+		"\n 2. Address discovery (STUN) [skipped]\n" +
+		"    Server: stun:my-awesome-stun.com:3478 is Available\n" +
+		"    Not used, the local candidate is relay\n" +
+		"\n 3. Relay (TURN) [ok]\n" +
+		"    Server: turns:my-awesome-turn.com:443?transport=tcp is Unavailable\n" +
+		"    Relayed endpoint: 10.0.0.1:10001\n" +
+		"\n 4. ICE candidate pair [ok]\n" +
+		"    Local: relay 10.0.0.1:10001\n" +
+		"    Remote: prflx 10.0.10.1:10002\n" +
+		"    Direct: false\n" +
+		"\n 5. WireGuard tunnel [ok]\n" +
+		"    Last handshake: 2002-02-02 02:02:03\n" +
+		"    Transfer (received/sent): 2.0 KiB/1000 B\n" +
+		"\n 6. Remote peer [ok]\n" +
+		"    FQDN: peer-2.awesome-domain.com\n" +
+		"    NetBird IP: 192.168.178.102\n" +
+		"    Status: Connected\n" +
+		"    Latency: -\n"
+
+	assert.Equal(t, expected, parseNetworkPathReport(report))
+}
+
+func TestNetworkPathReportDisconnectedPeer(t *testing.T) {
+	report := buildNetworkPathReport(overview, peerStateDetailOutput{
+		FQDN:   "peer-3.awesome-domain.com",
+		IP:     "192.168.178.103",
+		Status: "Disconnected",
+	})
+
+	assert.Equal(t, "none", report.Path)
+	assert.Equal(t, hopFailed, report.Hops[3].Status)
+	assert.Equal(t, []string{"No candidate pair selected, the peer is Disconnected"}, report.Hops[3].Details)
+	assert.Equal(t, hopFailed, report.Hops[4].Status)
+}
+
+func TestParseSTUNServers(t *testing.T) {
+	uris, err := parseSTUNServers([]string{"stun.cloudflare.com:3478", "stun:stun.l.google.com:19302", "stun.example.com"})
+	require.NoError(t, err)
+	require.Len(t, uris, 3)
+	assert.Equal(t, "stun:stun.cloudflare.com:3478", uris[0].String())
+	assert.Equal(t, "stun:stun.l.google.com:19302", uris[1].String())
+	assert.Equal(t, 3478, uris[2].Port)
+
+	_, err = parseSTUNServers([]string{"stun.example.com:port"})
+	assert.Error(t, err)
+}
+
+func TestParseSTUNTest(t *testing.T) {
+	uri, err := stun.ParseURI("stun:stun.cloudflare.com:3478")
+	require.NoError(t, err)
+
+	output := mapSTUNTest(&relay.NATTypeResult{
+		URI:              uri,
+		MappedAddr:       "203.0.113.10:40000",
+		ResponseTime:     23 * time.Millisecond,
+		SecondMappedAddr: "203.0.113.10:40001",
+		NATType:          relay.NATTypeSymmetric,
+	})
+
+	expected := "STUN server: stun:stun.cloudflare.com:3478\n" +
+		"Mapped address: 203.0.113.10:40000 (responded in 23ms)\n" +
+		"Alternate address: not advertised, the server doesn't support RFC 5780\n" +
+		"Second mapped address: 203.0.113.10:40001\n" +
+		"Hairpin: not supported\n" +
+		"NAT type: symmetric\n" +
+		"  Every destination gets another mapped address, so the address learned from STUN is useless to the other peers. " +
+		"Peer-to-peer connections work only with peers that are reachable without hole punching.\n" +
+		"Relay needed: yes\n"
+	assert.Equal(t, expected, parseSTUNTest(output))
+}
+
+func TestParseTURNServer(t *testing.T) {
+	uri, err := parseTURNServer("turn.netbird.io:3478")
+	require.NoError(t, err)
+	assert.Equal(t, stun.SchemeTypeTURN, uri.Scheme)
+	assert.Equal(t, stun.ProtoTypeUDP, uri.Proto)
+
+	uri, err = parseTURNServer("turns:turn.netbird.io:443?transport=tcp")
+	require.NoError(t, err)
+	assert.Equal(t, stun.SchemeTypeTURNS, uri.Scheme)
+	assert.Equal(t, stun.ProtoTypeTCP, uri.Proto)
+}
+
+func TestParseTURNTest(t *testing.T) {
+	uri, err := parseTURNServer("turn.netbird.io:3478")
+	require.NoError(t, err)
+
+	output := mapTURNTest(&relay.TURNCheckResult{
+		URI:           uri,
+		RelayedAddr:   "203.0.113.10:50000",
+		PeerAddr:      "203.0.113.10:50001",
+		ChannelBound:  true,
+		RTT:           23 * time.Millisecond,
+		BytesSent:     30_000_000,
+		BytesReceived: 24_000_000,
+		Duration:      2 * time.Second,
+	})
+
+	expected := "TURN server: turn:turn.netbird.io:3478?transport=udp\n" +
+		"  allocation: ok, relayed address 203.0.113.10:50000\n" +
+		"  peer allocation: ok, relayed address 203.0.113.10:50001\n" +
+		"  permission: ok\n" +
+		"  channel binding: ok\n" +
+		"  data: ok, round-trip latency 23ms\n" +
+		"Round-trip latency: 23ms\n" +
+		"Throughput: 12.00 MBps (28.6 MiB sent, 22.9 MiB received)\n"
+	assert.Equal(t, expected, parseTURNTest(output))
+
+	output = mapTURNTest(&relay.TURNCheckResult{
+		URI:         uri,
+		FailedStage: relay.TURNStageAllocation,
+		Err:         errors.New("allocate: all retransmissions failed for 5aVDGYK6jZSmi3Ad"),
+	})
+
+	expected = "TURN server: turn:turn.netbird.io:3478?transport=udp\n" +
+		"  allocation: failed, allocate: all retransmissions failed for 5aVDGYK6jZSmi3Ad\n" +
+		"  peer allocation: skipped\n" +
+		"  permission: skipped\n" +
+		"  channel binding: skipped\n" +
+		"  data: skipped\n" +
+		"Hint: The TURN server didn't answer. Allow outbound UDP to turn.netbird.io:3478 in the firewall, or try another transport, e.g., --server turn:turn.netbird.io:443?transport=tcp\n"
+	assert.Equal(t, expected, parseTURNTest(output))
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pion/stun/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/netbirdio/netbird/client/internal"
+	"github.com/netbirdio/netbird/client/internal/relay"
+	"github.com/netbirdio/netbird/util"
+)
+
+const (
+	turnTestTimeout = 5 * time.Second
+	turnURIPrefix   = "turn:"
+	turnsURIPrefix  = "turns:"
+	stageOK         = "ok"
+	stageFailed     = "failed"
+	stageSkipped    = "skipped"
+)
+
+var (
+	turnTestServer     string
+	turnTestUsername   string
+	turnTestCredential string
+	turnTestDuration   time.Duration
+)
+
+type turnTestStage struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type turnTestOutput struct {
+	Server         string          `json:"server"`
+	Stages         []turnTestStage `json:"stages"`
+	RTT            time.Duration   `json:"rtt,omitempty"`
+	ThroughputMBps float64         `json:"throughputMBps,omitempty"`
+	BytesSent      int64           `json:"bytesSent,omitempty"`
+	BytesReceived  int64           `json:"bytesReceived,omitempty"`
+	Hint           string          `json:"hint,omitempty"`
+}
+
+var diagTURNTestCmd = &cobra.Command{
+	Use: "turn-test",
+	Short: "test a TURN server by allocating a relayed address and a test peer, binding a channel between them and measuring the latency and the throughput, " +
+		"e.g., netbird diag turn-test --server turn.netbird.io:3478 --username user --credential pass",
+	RunE: diagTURNTestFunc,
+}
+
+func init() {
+	diagCmd.AddCommand(diagTURNTestCmd)
+	diagTURNTestCmd.Flags().StringVar(&turnTestServer, "server", "", "TURN server as host:port or turn(s): URI, e.g., --server turn:turn.netbird.io:443?transport=tcp")
+	diagTURNTestCmd.Flags().StringVar(&turnTestUsername, "username", "", "TURN username")
+	diagTURNTestCmd.Flags().StringVar(&turnTestCredential, "credential", "", "TURN password")
+	diagTURNTestCmd.Flags().DurationVar(&turnTestDuration, "duration", 3*time.Second, "duration of the throughput test")
+	diagTURNTestCmd.Flags().BoolVar(&diagJSONFlag, "json", false, "display the TURN test result in json format")
+	_ = diagTURNTestCmd.MarkFlagRequired("server")
+}
+
+func diagTURNTestFunc(cmd *cobra.Command, _ []string) error {
+	SetFlagsFromEnvVars(rootCmd)
+
+	cmd.SetOut(cmd.OutOrStdout())
+
+	err := util.InitLog(logLevel, "console")
+	if err != nil {
+		return fmt.Errorf("failed initializing log %v", err)
+	}
+
+	uri, err := parseTURNServer(turnTestServer)
+	if err != nil {
+		return err
+	}
+	uri.Username = turnTestUsername
+	uri.Password = turnTestCredential
+
+	ctx := internal.CtxInitState(context.Background())
+	result := relay.CheckTURN(ctx, uri, turnTestDuration, turnTestTimeout)
+	output := mapTURNTest(result)
+
+	if diagJSONFlag {
+		jsonBytes, err := json.Marshal(output)
+		if err != nil {
+			return fmt.Errorf("json marshal failed")
+		}
+		cmd.Println(string(jsonBytes))
+	} else {
+		cmd.Print(parseTURNTest(output))
+	}
+
+	if result.Err != nil {
+		return fmt.Errorf("TURN test failed at the %s stage", result.FailedStage)
+	}
+	return nil
+}
+
+// parseTURNServer accepts turn: and turns: URIs and host:port addresses, which are tested over UDP
+func parseTURNServer(server string) (*stun.URI, error) {
+	raw := server
+	if !strings.HasPrefix(raw, turnURIPrefix) && !strings.HasPrefix(raw, turnsURIPrefix) {
+		raw = turnURIPrefix + raw
+	}
+	uri, err := stun.ParseURI(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TURN server %s: %v", server, err)
+	}
+	return uri, nil
+}
+
+// mapTURNTest lists the stages in the order CheckTURN runs them, the stages after the failed one are skipped
+func mapTURNTest(result *relay.TURNCheckResult) turnTestOutput {
+	output := turnTestOutput{Server: result.URI.String()}
+
+	stages := []struct {
+		name   string
+		detail string
+	}{
+		{relay.TURNStageAllocation, fmt.Sprintf("relayed address %s", result.RelayedAddr)},
+		{relay.TURNStagePeerAllocation, fmt.Sprintf("relayed address %s", result.PeerAddr)},
+		{relay.TURNStagePermission, ""},
+		{relay.TURNStageChannelBinding, ""},
+		{relay.TURNStageData, fmt.Sprintf("round-trip latency %s", result.RTT.Round(time.Millisecond))},
+	}
+
+	failed := false
+	for _, stage := range stages {
+		switch {
+		case failed:
+			output.Stages = append(output.Stages, turnTestStage{Name: stage.name, Status: stageSkipped})
+		case stage.name == result.FailedStage:
+			failed = true
+			output.Stages = append(output.Stages, turnTestStage{Name: stage.name, Status: stageFailed, Detail: result.Err.Error()})
+		default:
+			output.Stages = append(output.Stages, turnTestStage{Name: stage.name, Status: stageOK, Detail: stage.detail})
+		}
+	}
+
+	if failed {
+		output.Hint = turnTestHint(result)
+		return output
+	}
+
+	output.RTT = result.RTT
+	output.ThroughputMBps = result.Throughput() / 1e6
+	output.BytesSent = result.BytesSent
+	output.BytesReceived = result.BytesReceived
+	return output
+}
+
+// turnTestHint suggests the firewall or server configuration fix for the failed stage
+func turnTestHint(result *relay.TURNCheckResult) string {
+	transport := strings.ToUpper(result.URI.Proto.String())
+	switch result.FailedStage {
+	case relay.TURNStageAllocation:
+		errString := result.Err.Error()
+		if strings.Contains(errString, "retransmissions failed") || strings.Contains(errString, "dial") || strings.Contains(errString, "timeout") {
+			return fmt.Sprintf("The TURN server didn't answer. Allow outbound %s to %s:%d in the firewall, or try another transport, e.g., --server turn:%s:443?transport=tcp",
+				transport, result.URI.Host, result.URI.Port, result.URI.Host)
+		}
+		return "The TURN server refused the allocation. Check --username and --credential, the credentials pushed by management are short-lived"
+	case relay.TURNStagePeerAllocation:
+		return "The TURN server refused a second allocation for the same user, it may limit the allocations per user, e.g., user-quota in coturn"
+	case relay.TURNStagePermission, relay.TURNStageData:
+		return fmt.Sprintf("Relayed packets don't reach the test peer. The server may deny relaying to its own addresses, e.g., denied-peer-ip in coturn, "+
+			"or a firewall in front of %s drops its relay port range, e.g., min-port and max-port in coturn", result.URI.Host)
+	case relay.TURNStageChannelBinding:
+		return fmt.Sprintf("The allocation works but the channel binding doesn't. A firewall may drop %s from %s:%d after the allocation, check its session timeouts",
+			transport, result.URI.Host, result.URI.Port)
+	default:
+		return ""
+	}
+}
+
+func parseTURNTest(output turnTestOutput) string {
+	summary := fmt.Sprintf("TURN server: %s\n", output.Server)
+	for _, stage := range output.Stages {
+		summary += fmt.Sprintf("  %s: %s", stage.Name, stage.Status)
+		if stage.Detail != "" {
+			summary += fmt.Sprintf(", %s", stage.Detail)
+		}
+		summary += "\n"
+	}
+
+	if output.Hint != "" {
+		summary += fmt.Sprintf("Hint: %s\n", output.Hint)
+		return summary
+	}
+
+	summary += fmt.Sprintf("Round-trip latency: %s\n", output.RTT.Round(time.Millisecond))
+	summary += fmt.Sprintf("Throughput: %.2f MBps (%s sent, %s received)\n", output.ThroughputMBps, toIEC(output.BytesSent), toIEC(output.BytesReceived))
+	return summary
+}
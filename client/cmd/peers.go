@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+
+	"github.com/netbirdio/netbird/client/internal"
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/client/proto"
+	"github.com/netbirdio/netbird/util"
+)
+
+const wireguardPersistentKeepalive = 25
+
+var (
+	peersConnectedOnlyFlag bool
+	peersJSONFlag          bool
+	peersNamesFlag         []string
+)
+
+var peersCmd = &cobra.Command{
+	Use:   "peers",
+	Short: "inspect the peers known to the Netbird Service",
+}
+
+var peersFindCmd = &cobra.Command{
+	Use:   "find <fqdn-pattern> [fqdn-pattern...]",
+	Short: "find peers by FQDN glob patterns, e.g., netbird peers find 'database-*' '*.eu.netbird.cloud'",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  peersFindFunc,
+}
+
+var peersRouteTestCmd = &cobra.Command{
+	Use:   "route-test <peer-fqdn-or-hostname> <destination-cidr>",
+	Short: "show which NetBird route and routing peer would serve a destination, e.g., netbird peers route-test router.netbird.cloud 10.0.0.0/24",
+	Args:  cobra.ExactArgs(2),
+	RunE:  peersRouteTestFunc,
+}
+
+var peersExportWireguardPeerSectionsCmd = &cobra.Command{
+	Use:   "export-wireguard-peer-sections",
+	Short: "export the WireGuard [Peer] sections of the given peers, e.g., netbird peers export-wireguard-peer-sections --peers peer1.netbird.cloud,peer2",
+	RunE:  peersExportWireguardPeerSectionsFunc,
+}
+
+func init() {
+	peersCmd.AddCommand(peersFindCmd)
+	peersCmd.AddCommand(peersRouteTestCmd)
+	peersCmd.AddCommand(peersExportWireguardPeerSectionsCmd)
+	peersExportWireguardPeerSectionsCmd.Flags().StringSliceVar(&peersNamesFlag, "peers", []string{}, "list of one or more peer FQDNs or hostnames to export, e.g., --peers peer1.netbird.cloud,peer2")
+	peersExportWireguardPeerSectionsCmd.Flags().BoolVar(&peersConnectedOnlyFlag, "connected-only", false, "export only connected peers")
+	_ = peersExportWireguardPeerSectionsCmd.MarkFlagRequired("peers")
+	peersFindCmd.Flags().BoolVar(&peersConnectedOnlyFlag, "connected-only", false, "display only connected peers")
+	peersFindCmd.Flags().BoolVar(&peersJSONFlag, "json", false, "display matching peers as a json array")
+}
+
+func peersFindFunc(cmd *cobra.Command, args []string) error {
+	SetFlagsFromEnvVars(rootCmd)
+
+	cmd.SetOut(cmd.OutOrStdout())
+
+	patterns, err := parsePeerPatterns(args)
+	if err != nil {
+		return err
+	}
+
+	err = util.InitLog(logLevel, "console")
+	if err != nil {
+		return fmt.Errorf("failed initializing log %v", err)
+	}
+
+	ctx := internal.CtxInitState(context.Background())
+
+	resp, err := getStatus(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	overview := convertToStatusOutputOverview(resp)
+	matched := findPeers(overview.Peers.Details, patterns, peersConnectedOnlyFlag)
+
+	if peersJSONFlag {
+		jsonBytes, err := json.Marshal(matched)
+		if err != nil {
+			return fmt.Errorf("json marshal failed")
+		}
+		cmd.Println(string(jsonBytes))
+		return nil
+	}
+
+	if len(matched) == 0 {
+		cmd.Println("No peers matched")
+		return nil
+	}
+
+	cmd.Print(parsePeers(peersStateOutput{Details: matched}, overview.RosenpassEnabled, overview.RosenpassPermissive))
+	return nil
+}
+
+func peersRouteTestFunc(cmd *cobra.Command, args []string) error {
+	SetFlagsFromEnvVars(rootCmd)
+
+	cmd.SetOut(cmd.OutOrStdout())
+
+	err := util.InitLog(logLevel, "console")
+	if err != nil {
+		return fmt.Errorf("failed initializing log %v", err)
+	}
+
+	ctx := internal.CtxInitState(context.Background())
+
+	conn, err := dialDaemon(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	resp, err := proto.NewDaemonServiceClient(conn).RouteTest(cmd.Context(), &proto.RouteTestRequest{
+		PeerFqdn:    args[0],
+		Destination: args[1],
+	})
+	if err != nil {
+		return fmt.Errorf("route test failed: %v", status.Convert(err).Message())
+	}
+
+	cmd.Print(parseRouteTest(args[1], resp))
+	return nil
+}
+
+func parseRouteTest(destination string, resp *proto.RouteTestResponse) string {
+	if !resp.GetFound() {
+		return fmt.Sprintf("No NetBird route matches %s, traffic will follow the system routing table\n", destination)
+	}
+
+	routePath := "split-tunnel"
+	if resp.GetFullTunnel() {
+		routePath = "full-tunnel"
+	}
+
+	summary := fmt.Sprintf(
+		"Destination: %s\n"+
+			"Route: %s\n"+
+			"Gateway: %s\n"+
+			"Metric: %d\n"+
+			"Path: %s\n",
+		destination,
+		resp.GetRoutePrefix(),
+		resp.GetGatewayFqdn(),
+		resp.GetMetric(),
+		routePath,
+	)
+
+	if !resp.GetServedByPeer() {
+		summary += fmt.Sprintf("Note: %s is routed through %s, not %s\n", destination, resp.GetGatewayFqdn(), resp.GetPeerFqdn())
+	}
+
+	return summary
+}
+
+func peersExportWireguardPeerSectionsFunc(cmd *cobra.Command, _ []string) error {
+	SetFlagsFromEnvVars(rootCmd)
+
+	cmd.SetOut(cmd.OutOrStdout())
+
+	err := util.InitLog(logLevel, "console")
+	if err != nil {
+		return fmt.Errorf("failed initializing log %v", err)
+	}
+
+	ctx := internal.CtxInitState(context.Background())
+
+	resp, err := getStatus(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	overview := convertToStatusOutputOverview(resp)
+	selected, err := selectPeersByName(overview.Peers.Details, peersNamesFlag)
+	if err != nil {
+		return err
+	}
+
+	cmd.Print(parseWireguardPeerSections(selected, peersConnectedOnlyFlag))
+	return nil
+}
+
+// selectPeersByName returns the peers matching the given FQDNs or hostnames in the requested order
+func selectPeersByName(peers []peerStateDetailOutput, names []string) ([]peerStateDetailOutput, error) {
+	selected := make([]peerStateDetailOutput, 0, len(names))
+	var unknown []string
+	for _, name := range names {
+		lowerName := strings.ToLower(name)
+		found := false
+		for _, peerState := range peers {
+			fqdn := strings.ToLower(peerState.FQDN)
+			if fqdn == lowerName || strings.Split(fqdn, ".")[0] == lowerName {
+				selected = append(selected, peerState)
+				found = true
+				break
+			}
+		}
+		if !found {
+			unknown = append(unknown, name)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown peers: %s. Run netbird status -d to list the available peers", strings.Join(unknown, ", "))
+	}
+	return selected, nil
+}
+
+// parseWireguardPeerSections renders a WireGuard [Peer] section for every peer
+func parseWireguardPeerSections(peers []peerStateDetailOutput, connectedOnly bool) string {
+	var sections []string
+	for _, peerState := range peers {
+		if connectedOnly && peerState.Status != peer.StatusConnected.String() {
+			continue
+		}
+
+		allowedIPs := []string{peerState.IP}
+		if !strings.Contains(peerState.IP, "/") {
+			allowedIPs[0] = peerState.IP + "/32"
+		}
+		allowedIPs = append(allowedIPs, peerState.Routes...)
+
+		sections = append(sections, fmt.Sprintf(
+			"[Peer]\n"+
+				"# %s\n"+
+				"PublicKey = %s\n"+
+				"AllowedIPs = %s\n"+
+				"PersistentKeepalive = %d\n",
+			peerState.FQDN,
+			peerState.PubKey,
+			strings.Join(allowedIPs, ", "),
+			wireguardPersistentKeepalive,
+		))
+	}
+	return strings.Join(sections, "\n")
+}
+
+func parsePeerPatterns(args []string) ([]string, error) {
+	patterns := make([]string, 0, len(args))
+	for _, arg := range args {
+		pattern := strings.ToLower(arg)
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("got an invalid peer pattern: pattern %s, error %s", arg, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// findPeers returns the peers whose FQDN matches any of the given glob patterns
+func findPeers(peers []peerStateDetailOutput, patterns []string, connectedOnly bool) []peerStateDetailOutput {
+	matched := make([]peerStateDetailOutput, 0)
+	for _, peerState := range peers {
+		if connectedOnly && peerState.Status != peer.StatusConnected.String() {
+			continue
+		}
+
+		fqdn := strings.ToLower(peerState.FQDN)
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, fqdn); ok {
+				matched = append(matched, peerState)
+				break
+			}
+		}
+	}
+	return matched
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netbirdio/netbird/client/internal"
+	"github.com/netbirdio/netbird/util"
+)
+
+const (
+	publicDNSResolver = "8.8.8.8:53"
+	dnsTestTimeout    = 2 * time.Second
+	systemResolver    = "system"
+)
+
+var peersTestDNSCmd = &cobra.Command{
+	Use:   "test-dns <fqdn>",
+	Short: "resolve a peer FQDN with the system resolver, the management name servers and a public resolver and compare the answers, e.g., netbird peers test-dns peer.netbird.cloud",
+	Args:  cobra.ExactArgs(1),
+	RunE:  peersTestDNSFunc,
+}
+
+// dnsResolver is a named resolver used by test-dns
+type dnsResolver struct {
+	name     string
+	resolver *net.Resolver
+}
+
+type dnsTestResult struct {
+	Resolver  string   `json:"resolver"`
+	Result    []string `json:"result"`
+	LatencyMs int64    `json:"latency_ms"`
+	Match     bool     `json:"match"`
+	Error     string   `json:"error,omitempty"`
+}
+
+func init() {
+	peersCmd.AddCommand(peersTestDNSCmd)
+	peersTestDNSCmd.Flags().BoolVar(&peersJSONFlag, "json", false, "display the results as a json array")
+}
+
+func peersTestDNSFunc(cmd *cobra.Command, args []string) error {
+	SetFlagsFromEnvVars(rootCmd)
+
+	cmd.SetOut(cmd.OutOrStdout())
+
+	err := util.InitLog(logLevel, "console")
+	if err != nil {
+		return fmt.Errorf("failed initializing log %v", err)
+	}
+
+	ctx := internal.CtxInitState(context.Background())
+
+	resp, err := getStatus(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	overview := convertToStatusOutputOverview(resp)
+
+	resolvers := []dnsResolver{{name: systemResolver, resolver: net.DefaultResolver}}
+	for _, server := range managementNameServers(overview.NSServerGroups) {
+		resolvers = append(resolvers, dnsResolver{name: server, resolver: newServerResolver(server)})
+	}
+	resolvers = append(resolvers, dnsResolver{name: publicDNSResolver, resolver: newServerResolver(publicDNSResolver)})
+
+	results := testDNS(cmd.Context(), args[0], resolvers, dnsTestTimeout)
+
+	if peersJSONFlag {
+		jsonBytes, err := json.Marshal(results)
+		if err != nil {
+			return fmt.Errorf("json marshal failed")
+		}
+		cmd.Println(string(jsonBytes))
+		return nil
+	}
+
+	cmd.Print(parseDNSTestResults(results))
+	return nil
+}
+
+// managementNameServers returns the unique servers of the enabled name server groups pushed by management
+func managementNameServers(groups []nsServerGroupStateOutput) []string {
+	var servers []string
+	seen := make(map[string]struct{})
+	for _, group := range groups {
+		if !group.Enabled {
+			continue
+		}
+		for _, server := range group.Servers {
+			if _, ok := seen[server]; ok {
+				continue
+			}
+			seen[server] = struct{}{}
+			servers = append(servers, server)
+		}
+	}
+	return servers
+}
+
+// newServerResolver returns a resolver sending its queries to the given host:port server instead of the system resolver
+func newServerResolver(server string) *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, server)
+		},
+	}
+}
+
+// testDNS resolves the FQDN with every resolver. An answer matches when it equals the answer of the first resolver
+func testDNS(ctx context.Context, fqdn string, resolvers []dnsResolver, timeout time.Duration) []dnsTestResult {
+	results := make([]dnsTestResult, 0, len(resolvers))
+	for _, r := range resolvers {
+		lookupCtx, cancel := context.WithTimeout(ctx, timeout)
+		start := time.Now()
+		addrs, err := r.resolver.LookupHost(lookupCtx, fqdn)
+		latency := time.Since(start)
+		cancel()
+
+		result := dnsTestResult{
+			Resolver:  r.name,
+			Result:    []string{},
+			LatencyMs: latency.Milliseconds(),
+		}
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			sort.Strings(addrs)
+			result.Result = addrs
+		}
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		return results
+	}
+
+	reference := strings.Join(results[0].Result, ",")
+	for i := range results {
+		results[i].Match = results[i].Error == "" && strings.Join(results[i].Result, ",") == reference
+	}
+	return results
+}
+
+func parseDNSTestResults(results []dnsTestResult) string {
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("%-24s %-10s %-6s %s\n", "RESOLVER", "LATENCY", "MATCH", "RESULT"))
+	for _, result := range results {
+		answer := strings.Join(result.Result, ", ")
+		if result.Error != "" {
+			answer = "error: " + result.Error
+		}
+
+		match := "no"
+		if result.Match {
+			match = "yes"
+		}
+
+		output.WriteString(fmt.Sprintf("%-24s %-10s %-6s %s\n", result.Resolver, fmt.Sprintf("%dms", result.LatencyMs), match, answer))
+	}
+	return output.String()
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func startTestDNSServer(t *testing.T, answer string) string {
+	t.Helper()
+
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &dns.Server{
+		PacketConn: pc,
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+			m := new(dns.Msg)
+			m.SetReply(r)
+			if r.Question[0].Qtype == dns.TypeA {
+				rr, _ := dns.NewRR(r.Question[0].Name + " 60 IN A " + answer)
+				m.Answer = append(m.Answer, rr)
+			}
+			_ = w.WriteMsg(m)
+		}),
+	}
+	go func() {
+		_ = server.ActivateAndServe()
+	}()
+	t.Cleanup(func() {
+		_ = server.Shutdown()
+	})
+
+	return pc.LocalAddr().String()
+}
+
+func TestTestDNS(t *testing.T) {
+	netbirdServer := startTestDNSServer(t, "100.64.0.10")
+	splitBrainServer := startTestDNSServer(t, "10.0.0.10")
+
+	resolvers := []dnsResolver{
+		{name: "netbird", resolver: newServerResolver(netbirdServer)},
+		{name: "management", resolver: newServerResolver(netbirdServer)},
+		{name: "public", resolver: newServerResolver(splitBrainServer)},
+		{name: "unreachable", resolver: newServerResolver("127.0.0.1:1")},
+	}
+
+	results := testDNS(context.Background(), "peer.netbird.cloud", resolvers, time.Second)
+	require.Len(t, results, 4)
+
+	assert.Equal(t, []string{"100.64.0.10"}, results[0].Result)
+	assert.True(t, results[0].Match)
+	assert.True(t, results[1].Match)
+	assert.Equal(t, []string{"10.0.0.10"}, results[2].Result)
+	assert.False(t, results[2].Match)
+	assert.NotEmpty(t, results[3].Error)
+	assert.False(t, results[3].Match)
+}
+
+func TestManagementNameServers(t *testing.T) {
+	groups := []nsServerGroupStateOutput{
+		{Servers: []string{"8.8.8.8:53", "1.1.1.1:53"}, Enabled: true},
+		{Servers: []string{"8.8.8.8:53"}, Enabled: true},
+		{Servers: []string{"9.9.9.9:53"}, Enabled: false},
+	}
+	assert.Equal(t, []string{"8.8.8.8:53", "1.1.1.1:53"}, managementNameServers(groups))
+}
+
+func TestParseDNSTestResults(t *testing.T) {
+	results := []dnsTestResult{
+		{Resolver: "system", Result: []string{"100.64.0.10"}, LatencyMs: 1, Match: true},
+		{Resolver: "8.8.8.8:53", Result: []string{}, LatencyMs: 20, Error: "no such host"},
+	}
+
+	expected := "RESOLVER                 LATENCY    MATCH  RESULT\n" +
+		"system                   1ms        yes    100.64.0.10\n" +
+		"8.8.8.8:53               20ms       no     error: no such host\n"
+	assert.Equal(t, expected, parseDNSTestResults(results))
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+
+	"github.com/netbirdio/netbird/client/internal"
+	"github.com/netbirdio/netbird/client/proto"
+	"github.com/netbirdio/netbird/util"
+)
+
+var peersRekeyWaitFlag bool
+
+var peersRekeyCmd = &cobra.Command{
+	Use: "rekey <fqdn>",
+	Short: "drop the WireGuard session keys of a peer and start a new handshake, the identity key of the peer doesn't change, " +
+		"e.g., netbird peers rekey peer.netbird.cloud --wait",
+	Args: cobra.ExactArgs(1),
+	RunE: peersRekeyFunc,
+}
+
+func init() {
+	peersCmd.AddCommand(peersRekeyCmd)
+	peersRekeyCmd.Flags().BoolVar(&peersRekeyWaitFlag, "wait", false, "wait up to 30 seconds for the handshake of the new session and display its time")
+}
+
+func peersRekeyFunc(cmd *cobra.Command, args []string) error {
+	SetFlagsFromEnvVars(rootCmd)
+
+	cmd.SetOut(cmd.OutOrStdout())
+
+	err := util.InitLog(logLevel, "console")
+	if err != nil {
+		return fmt.Errorf("failed initializing log %v", err)
+	}
+
+	ctx := internal.CtxInitState(context.Background())
+
+	conn, err := dialDaemon(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	resp, err := proto.NewDaemonServiceClient(conn).RekeyPeer(cmd.Context(), &proto.RekeyPeerRequest{
+		PeerFqdn: args[0],
+		Wait:     peersRekeyWaitFlag,
+	})
+	if err != nil {
+		return fmt.Errorf("rekey failed: %v", status.Convert(err).Message())
+	}
+
+	cmd.Print(parseRekeyPeer(resp))
+	return nil
+}
+
+func parseRekeyPeer(resp *proto.RekeyPeerResponse) string {
+	summary := fmt.Sprintf("Dropped the WireGuard session keys of %s (%s)\n", resp.GetFqdn(), resp.GetPublicKey())
+	if resp.GetLastHandshakeTime() == nil {
+		return summary + "The new session starts with the next packet to the peer\n"
+	}
+	return summary + fmt.Sprintf("New session handshake: %s\n", resp.GetLastHandshakeTime().AsTime().Local().Format("2006-01-02 15:04:05"))
+}
@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+func TestParsingRekeyPeer(t *testing.T) {
+	resp := &proto.RekeyPeerResponse{Fqdn: "peer-1.awesome-domain.com", PublicKey: "Pubkey1"}
+	assert.Equal(t, "Dropped the WireGuard session keys of peer-1.awesome-domain.com (Pubkey1)\n"+
+		"The new session starts with the next packet to the peer\n", parseRekeyPeer(resp))
+
+	handshake := time.Date(2024, 1, 1, 10, 0, 0, 0, time.Local)
+	resp.LastHandshakeTime = timestamppb.New(handshake)
+	assert.Equal(t, "Dropped the WireGuard session keys of peer-1.awesome-domain.com (Pubkey1)\n"+
+		"New session handshake: 2024-01-01 10:00:00\n", parseRekeyPeer(resp))
+}
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/netbirdio/netbird/client/internal"
+	"github.com/netbirdio/netbird/util"
+)
+
+const (
+	defaultResolvedDropInFile = "/etc/systemd/resolved.conf.d/netbird.conf"
+	resolvedConfFile          = "/etc/systemd/resolved.conf"
+	resolvedServiceName       = "systemd-resolved"
+)
+
+var (
+	resolvedOutputFlag    string
+	resolvedApplyFlag     bool
+	resolvedDNSServerFlag string
+)
+
+var peersExportSystemdResolvedCmd = &cobra.Command{
+	Use:   "export-systemd-resolved",
+	Short: "generate a systemd-resolved drop-in routing the NetBird domains to the NetBird DNS server, e.g., netbird peers export-systemd-resolved --output " + defaultResolvedDropInFile,
+	RunE:  peersExportSystemdResolvedFunc,
+}
+
+func init() {
+	peersCmd.AddCommand(peersExportSystemdResolvedCmd)
+	peersExportSystemdResolvedCmd.Flags().StringVar(&resolvedOutputFlag, "output", "", "write the drop-in to the given file instead of stdout, defaults to "+defaultResolvedDropInFile+" with --apply")
+	peersExportSystemdResolvedCmd.Flags().BoolVar(&resolvedApplyFlag, "apply", false, "write the drop-in and restart systemd-resolved")
+	peersExportSystemdResolvedCmd.Flags().StringVar(&resolvedDNSServerFlag, "dns-server", "", "NetBird DNS server address, defaults to the address the DNS server of this peer listens on, e.g., --dns-server 100.64.255.254")
+}
+
+func peersExportSystemdResolvedFunc(cmd *cobra.Command, _ []string) error {
+	SetFlagsFromEnvVars(rootCmd)
+
+	cmd.SetOut(cmd.OutOrStdout())
+
+	if resolvedApplyFlag && runtime.GOOS != "linux" {
+		return fmt.Errorf("--apply is only supported on Linux")
+	}
+
+	err := util.InitLog(logLevel, "console")
+	if err != nil {
+		return fmt.Errorf("failed initializing log %v", err)
+	}
+
+	ctx := internal.CtxInitState(context.Background())
+
+	resp, err := getStatus(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	overview := convertToStatusOutputOverview(resp)
+
+	dnsServer := resolvedDNSServerFlag
+	if dnsServer == "" {
+		dnsServer, err = resolvedDNSServer(resp.GetFullStatus().GetLocalPeerState().GetDnsListenAddress())
+		if err != nil {
+			return err
+		}
+	}
+
+	dropIn := parseSystemdResolvedDropIn(dnsServer, resolvedDomains(overview))
+
+	output := resolvedOutputFlag
+	if resolvedApplyFlag && output == "" {
+		output = defaultResolvedDropInFile
+	}
+
+	if output == "" {
+		cmd.Print(dropIn)
+		return nil
+	}
+
+	for _, conflict := range findResolvedConflicts(resolvedConfigFiles(), output) {
+		cmd.PrintErrf("Warning: %s\n", conflict)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+		return fmt.Errorf("failed creating %s: %v", filepath.Dir(output), err)
+	}
+	if err := os.WriteFile(output, []byte(dropIn), 0644); err != nil {
+		return fmt.Errorf("failed writing %s: %v", output, err)
+	}
+	cmd.Printf("systemd-resolved drop-in written to %s\n", output)
+
+	if !resolvedApplyFlag {
+		return nil
+	}
+
+	if out, err := exec.Command("systemctl", "restart", resolvedServiceName).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed restarting %s: %v, %s", resolvedServiceName, err, strings.TrimSpace(string(out)))
+	}
+	cmd.Printf("%s restarted\n", resolvedServiceName)
+	return nil
+}
+
+// resolvedDomains returns the routing domains of the peers domain and the name server groups.
+// The catch-all ~. domain is added only when a name server group resolves all domains
+func resolvedDomains(overview statusOutputOverview) []string {
+	var domains []string
+	seen := make(map[string]struct{})
+	add := func(domain string) {
+		domain = "~" + strings.TrimSuffix(domain, ".")
+		if _, ok := seen[domain]; ok {
+			return
+		}
+		seen[domain] = struct{}{}
+		domains = append(domains, domain)
+	}
+
+	if _, peersDomain, found := strings.Cut(overview.FQDN, "."); found && peersDomain != "" {
+		add(peersDomain)
+	}
+
+	catchAll := false
+	for _, group := range overview.NSServerGroups {
+		if !group.Enabled {
+			continue
+		}
+		if len(group.Domains) == 0 {
+			catchAll = true
+		}
+		for _, domain := range group.Domains {
+			add(domain)
+		}
+	}
+
+	if catchAll {
+		domains = append(domains, "~.")
+	}
+	return domains
+}
+
+// resolvedDNSServer converts the ip:port the daemon DNS server listens on to a DNS= value, the port is kept
+// only when it isn't 53 since the local resolver falls back to another port when 53 is taken
+func resolvedDNSServer(listenAddress string) (string, error) {
+	if listenAddress == "" {
+		return "", fmt.Errorf("the DNS address of this peer is unknown, make sure the client is connected with DNS enabled or use --dns-server")
+	}
+
+	host, port, err := net.SplitHostPort(listenAddress)
+	if err != nil {
+		return "", fmt.Errorf("failed parsing the DNS address %s: %v", listenAddress, err)
+	}
+	if port == "53" {
+		return host, nil
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+func parseSystemdResolvedDropIn(dnsServer string, domains []string) string {
+	return fmt.Sprintf(
+		"# Generated by netbird peers export-systemd-resolved\n"+
+			"[Resolve]\n"+
+			"DNS=%s\n"+
+			"Domains=%s\n",
+		dnsServer,
+		strings.Join(domains, " "),
+	)
+}
+
+// resolvedConfigFiles returns the main systemd-resolved config and its drop-ins
+func resolvedConfigFiles() []string {
+	files := []string{resolvedConfFile}
+	dropIns, _ := filepath.Glob(filepath.Join(filepath.Dir(defaultResolvedDropInFile), "*.conf"))
+	return append(files, dropIns...)
+}
+
+// findResolvedConflicts reports the config files, other than the target, that set DNS or Domains in their [Resolve] section
+func findResolvedConflicts(files []string, target string) []string {
+	var conflicts []string
+	for _, file := range files {
+		if filepath.Clean(file) == filepath.Clean(target) {
+			continue
+		}
+
+		keys, err := resolvedSectionKeys(file)
+		if err != nil {
+			continue
+		}
+		for _, key := range []string{"DNS", "Domains"} {
+			if value, ok := keys[key]; ok && value != "" {
+				conflicts = append(conflicts, fmt.Sprintf("%s already sets %s=%s", file, key, value))
+			}
+		}
+	}
+	return conflicts
+}
+
+func resolvedSectionKeys(file string) (map[string]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	keys := make(map[string]string)
+	inResolve := false
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "["):
+			inResolve = line == "[Resolve]"
+		case inResolve:
+			if key, value, found := strings.Cut(line, "="); found {
+				keys[strings.TrimSpace(key)] = strings.TrimSpace(value)
+			}
+		}
+	}
+	return keys, scanner.Err()
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvedDomains(t *testing.T) {
+	overview := statusOutputOverview{
+		FQDN: "peer-a.netbird.cloud",
+		NSServerGroups: []nsServerGroupStateOutput{
+			{Domains: []string{"corp.example.com.", "netbird.cloud"}, Enabled: true},
+			{Domains: []string{"disabled.example.com"}, Enabled: false},
+		},
+	}
+	assert.Equal(t, []string{"~netbird.cloud", "~corp.example.com"}, resolvedDomains(overview))
+
+	overview.NSServerGroups = append(overview.NSServerGroups, nsServerGroupStateOutput{Servers: []string{"8.8.8.8:53"}, Enabled: true})
+	assert.Equal(t, []string{"~netbird.cloud", "~corp.example.com", "~."}, resolvedDomains(overview))
+}
+
+func TestParseSystemdResolvedDropIn(t *testing.T) {
+	expected := "# Generated by netbird peers export-systemd-resolved\n" +
+		"[Resolve]\n" +
+		"DNS=100.64.0.1\n" +
+		"Domains=~netbird.cloud ~.\n"
+	assert.Equal(t, expected, parseSystemdResolvedDropIn("100.64.0.1", []string{"~netbird.cloud", "~."}))
+}
+
+func TestResolvedDNSServer(t *testing.T) {
+	dnsServer, err := resolvedDNSServer("100.64.0.1:53")
+	require.NoError(t, err)
+	assert.Equal(t, "100.64.0.1", dnsServer)
+
+	dnsServer, err = resolvedDNSServer("127.0.0.153:5053")
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.153:5053", dnsServer)
+
+	_, err = resolvedDNSServer("")
+	assert.ErrorContains(t, err, "use --dns-server")
+}
+
+func TestFindResolvedConflicts(t *testing.T) {
+	dir := t.TempDir()
+	mainConf := filepath.Join(dir, "resolved.conf")
+	otherDropIn := filepath.Join(dir, "other.conf")
+	target := filepath.Join(dir, "netbird.conf")
+
+	require.NoError(t, os.WriteFile(mainConf, []byte("[Resolve]\n#DNS=\nDNSSEC=no\n"), 0644))
+	require.NoError(t, os.WriteFile(otherDropIn, []byte("[Resolve]\nDomains=~.\n[Other]\nDNS=1.1.1.1\n"), 0644))
+	require.NoError(t, os.WriteFile(target, []byte("[Resolve]\nDNS=100.64.0.1\n"), 0644))
+
+	conflicts := findResolvedConflicts([]string{mainConf, otherDropIn, target, filepath.Join(dir, "missing.conf")}, target)
+	assert.Equal(t, []string{otherDropIn + " already sets Domains=~."}, conflicts)
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+func TestFindPeers(t *testing.T) {
+	peers := []peerStateDetailOutput{
+		{FQDN: "database-1.eu.netbird.cloud", Status: "Connected"},
+		{FQDN: "database-2.us.netbird.cloud", Status: "Idle"},
+		{FQDN: "web-1.eu.netbird.cloud", Status: "Connected"},
+		{FQDN: "web-2.us.netbird.cloud", Status: "Connected"},
+	}
+
+	tests := []struct {
+		name          string
+		patterns      []string
+		connectedOnly bool
+		expected      []string
+	}{
+		{
+			name:     "prefix pattern",
+			patterns: []string{"database-*"},
+			expected: []string{"database-1.eu.netbird.cloud", "database-2.us.netbird.cloud"},
+		},
+		{
+			name:     "suffix pattern",
+			patterns: []string{"*.eu.netbird.cloud"},
+			expected: []string{"database-1.eu.netbird.cloud", "web-1.eu.netbird.cloud"},
+		},
+		{
+			name:     "multiple patterns are or-ed",
+			patterns: []string{"database-2*", "web-2*"},
+			expected: []string{"database-2.us.netbird.cloud", "web-2.us.netbird.cloud"},
+		},
+		{
+			name:          "connected only",
+			patterns:      []string{"database-*"},
+			connectedOnly: true,
+			expected:      []string{"database-1.eu.netbird.cloud"},
+		},
+		{
+			name:     "no match",
+			patterns: []string{"cache-*"},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patterns, err := parsePeerPatterns(tt.patterns)
+			require.NoError(t, err)
+
+			matched := findPeers(peers, patterns, tt.connectedOnly)
+
+			fqdns := make([]string, 0, len(matched))
+			for _, p := range matched {
+				fqdns = append(fqdns, p.FQDN)
+			}
+			assert.Equal(t, tt.expected, fqdns)
+		})
+	}
+}
+
+func TestParsePeerPatternsInvalid(t *testing.T) {
+	_, err := parsePeerPatterns([]string{"database-["})
+	assert.Error(t, err)
+}
+
+func TestParseRouteTest(t *testing.T) {
+	resp := &proto.RouteTestResponse{
+		Found:        true,
+		RoutePrefix:  "10.0.0.0/16",
+		GatewayFqdn:  "router-a.netbird.cloud",
+		Metric:       9999,
+		PeerFqdn:     "router-a.netbird.cloud",
+		ServedByPeer: true,
+	}
+
+	expected := "Destination: 10.0.1.10\n" +
+		"Route: 10.0.0.0/16\n" +
+		"Gateway: router-a.netbird.cloud\n" +
+		"Metric: 9999\n" +
+		"Path: split-tunnel\n"
+	assert.Equal(t, expected, parseRouteTest("10.0.1.10", resp))
+
+	resp.PeerFqdn = "router-b.netbird.cloud"
+	resp.ServedByPeer = false
+	assert.Equal(t, expected+"Note: 10.0.1.10 is routed through router-a.netbird.cloud, not router-b.netbird.cloud\n",
+		parseRouteTest("10.0.1.10", resp))
+
+	assert.Equal(t, "No NetBird route matches 10.0.1.10, traffic will follow the system routing table\n",
+		parseRouteTest("10.0.1.10", &proto.RouteTestResponse{}))
+}
+
+func TestParseWireguardPeerSections(t *testing.T) {
+	peers := []peerStateDetailOutput{
+		{
+			FQDN:   "peer-1.awesome-domain.com",
+			IP:     "100.64.0.1",
+			PubKey: "Pubkey1",
+			Status: "Connected",
+			Routes: []string{"10.1.0.0/24"},
+		},
+		{
+			FQDN:   "peer-2.awesome-domain.com",
+			IP:     "100.64.0.2",
+			PubKey: "Pubkey2",
+			Status: "Disconnected",
+		},
+	}
+
+	selected, err := selectPeersByName(peers, []string{"peer-2", "PEER-1.awesome-domain.com"})
+	require.NoError(t, err)
+
+	expected := "[Peer]\n" +
+		"# peer-2.awesome-domain.com\n" +
+		"PublicKey = Pubkey2\n" +
+		"AllowedIPs = 100.64.0.2/32\n" +
+		"PersistentKeepalive = 25\n" +
+		"\n" +
+		"[Peer]\n" +
+		"# peer-1.awesome-domain.com\n" +
+		"PublicKey = Pubkey1\n" +
+		"AllowedIPs = 100.64.0.1/32, 10.1.0.0/24\n" +
+		"PersistentKeepalive = 25\n"
+	assert.Equal(t, expected, parseWireguardPeerSections(selected, false))
+
+	assert.NotContains(t, parseWireguardPeerSections(selected, true), "Pubkey2")
+
+	_, err = selectPeersByName(peers, []string{"peer-1", "peer-3"})
+	assert.EqualError(t, err, "unknown peers: peer-3. Run netbird status -d to list the available peers")
+}
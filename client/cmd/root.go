@@ -25,15 +25,19 @@ import (
 )
 
 const (
-	externalIPMapFlag       = "external-ip-map"
-	dnsResolverAddress      = "dns-resolver-address"
-	enableRosenpassFlag     = "enable-rosenpass"
-	rosenpassPermissiveFlag = "rosenpass-permissive"
-	preSharedKeyFlag        = "preshared-key"
-	interfaceNameFlag       = "interface-name"
-	wireguardPortFlag       = "wireguard-port"
-	disableAutoConnectFlag  = "disable-auto-connect"
-	serverSSHAllowedFlag    = "allow-server-ssh"
+	externalIPMapFlag          = "external-ip-map"
+	dnsResolverAddress         = "dns-resolver-address"
+	enableRosenpassFlag        = "enable-rosenpass"
+	rosenpassPermissiveFlag    = "rosenpass-permissive"
+	preSharedKeyFlag           = "preshared-key"
+	interfaceNameFlag          = "interface-name"
+	wireguardPortFlag          = "wireguard-port"
+	disableAutoConnectFlag     = "disable-auto-connect"
+	serverSSHAllowedFlag       = "allow-server-ssh"
+	customDNSTTLFlag           = "custom-dns-ttl"
+	forceTCPFlag               = "force-tcp"
+	autoTCPFlag                = "auto-tcp"
+	peerAllowedIPsOverrideFlag = "peer-allowed-ips-override"
 )
 
 var (
@@ -63,6 +67,12 @@ var (
 	wireguardPort           uint16
 	serviceName             string
 	autoConnectDisabled     bool
+	customDNSTTL            time.Duration
+	forceTCP                bool
+	autoTCP                 bool
+	peerAllowedIPsOverrides []string
+	noTruncate              bool
+	truncateAt              int
 	rootCmd                 = &cobra.Command{
 		Use:          "netbird",
 		Short:        "",
@@ -76,6 +86,29 @@ func Execute() error {
 	return rootCmd.Execute()
 }
 
+// ExitCodeError is returned by commands that need to exit with a specific code
+type ExitCodeError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitCodeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitCodeError) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the exit code for the error returned by Execute
+func ExitCode(err error) int {
+	var exitErr *ExitCodeError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return 1
+}
+
 func init() {
 	defaultConfigPathDir = "/etc/netbird/"
 	defaultLogFileDir = "/var/log/netbird/"
@@ -117,6 +150,9 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&setupKey, "setup-key", "k", "", "Setup key obtained from the Management Service Dashboard (used to register peer)")
 	rootCmd.PersistentFlags().StringVar(&preSharedKey, preSharedKeyFlag, "", "Sets Wireguard PreSharedKey property. If set, then only peers that have the same key can communicate.")
 	rootCmd.PersistentFlags().StringVarP(&hostName, "hostname", "n", "", "Sets a custom hostname for the device")
+	rootCmd.PersistentFlags().BoolVar(&noTruncate, "no-truncate", false, "display long values such as public keys and relay URLs in full")
+	rootCmd.PersistentFlags().IntVar(&truncateAt, "truncate-at", 0, "maximum display length of long values such as public keys and relay URLs in the human-readable output, e.g., --truncate-at 40")
+	rootCmd.MarkFlagsMutuallyExclusive("no-truncate", "truncate-at")
 	rootCmd.AddCommand(serviceCmd)
 	rootCmd.AddCommand(upCmd)
 	rootCmd.AddCommand(downCmd)
@@ -124,8 +160,11 @@ func init() {
 	rootCmd.AddCommand(loginCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(sshCmd)
-	serviceCmd.AddCommand(runCmd, startCmd, stopCmd, restartCmd) // service control commands are subcommands of service
-	serviceCmd.AddCommand(installCmd, uninstallCmd)              // service installer commands are subcommands of service
+	rootCmd.AddCommand(peersCmd)
+	rootCmd.AddCommand(diagCmd)
+	serviceCmd.AddCommand(runCmd, startCmd, stopCmd, restartCmd)   // service control commands are subcommands of service
+	serviceCmd.AddCommand(installCmd, uninstallCmd)                // service installer commands are subcommands of service
+	serviceCmd.AddCommand(enableAutostartCmd, disableAutostartCmd) // service autostart commands are subcommands of service
 	upCmd.PersistentFlags().StringSliceVar(&natExternalIPs, externalIPMapFlag, nil,
 		`Sets external IPs maps between local addresses and interfaces.`+
 			`You can specify a comma-separated list with a single IP and IP/IP or IP/Interface Name. `+
@@ -143,6 +182,18 @@ func init() {
 	upCmd.PersistentFlags().BoolVar(&rosenpassPermissive, rosenpassPermissiveFlag, false, "[Experimental] Enable Rosenpass in permissive mode to allow this peer to accept WireGuard connections without requiring Rosenpass functionality from peers that do not have Rosenpass enabled.")
 	upCmd.PersistentFlags().BoolVar(&serverSSHAllowed, serverSSHAllowedFlag, false, "Allow SSH server on peer. If enabled, the SSH server will be permitted")
 	upCmd.PersistentFlags().BoolVar(&autoConnectDisabled, disableAutoConnectFlag, false, "Disables auto-connect feature. If enabled, then the client won't connect automatically when the service starts.")
+	upCmd.PersistentFlags().DurationVar(&customDNSTTL, customDNSTTLFlag, internal.DefaultDNSTTL,
+		`Sets the TTL of the DNS records NetBird pushes to the local resolver. `+
+			`Must be between 1s and 24h, a connected client applies it on the next DNS update. E.g. --custom-dns-ttl 30s`,
+	)
+	upCmd.PersistentFlags().BoolVar(&forceTCP, forceTCPFlag, false, "Connect to peers only through TURN relays reachable over TCP. Use it when UDP is blocked by the network.")
+	upCmd.PersistentFlags().BoolVar(&autoTCP, autoTCPFlag, false, "Switch a peer connection to TURN relays over TCP when UDP connectivity checks keep failing.")
+	upCmd.PersistentFlags().StringArrayVar(&peerAllowedIPsOverrides, peerAllowedIPsOverrideFlag, nil,
+		`Adds CIDRs to the allowed IPs management pushes for a peer, matched by its FQDN or leading labels. `+
+			`Repeat the flag for several peers, the given overrides replace the previous ones. `+
+			`An empty string "" clears the previous configuration. `+
+			`E.g. --peer-allowed-ips-override peer-a.netbird.cloud=10.0.0.0/8,192.168.0.0/16 or --peer-allowed-ips-override ""`,
+	)
 }
 
 // SetupCloseHandler handles SIGTERM signal and exits with success
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var enableAutostartCmd = &cobra.Command{
+	Use:   "enable-autostart",
+	Short: "starts Netbird service automatically at boot",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setAutostart(cmd, true)
+	},
+}
+
+var disableAutostartCmd = &cobra.Command{
+	Use:   "disable-autostart",
+	Short: "stops starting Netbird service automatically at boot",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setAutostart(cmd, false)
+	},
+}
+
+func setAutostart(cmd *cobra.Command, enable bool) error {
+	SetFlagsFromEnvVars(rootCmd)
+
+	cmd.SetOut(cmd.OutOrStdout())
+
+	if runtime.GOOS != "windows" && os.Geteuid() != 0 {
+		return fmt.Errorf("changing the service autostart requires root privileges, please run: sudo netbird service %s", cmd.Name())
+	}
+
+	command, err := autostartCommand(runtime.GOOS, serviceName, enable)
+	if err != nil {
+		return err
+	}
+
+	cmd.Printf("Running: %s\n", strings.Join(command, " "))
+
+	out, err := exec.Command(command[0], command[1:]...).CombinedOutput()
+	if err != nil {
+		hint := ""
+		if runtime.GOOS == "windows" {
+			hint = ", make sure the command prompt runs as Administrator"
+		}
+		return fmt.Errorf("failed to change the autostart of the %s service: %v %s%s", serviceName, err, strings.TrimSpace(string(out)), hint)
+	}
+
+	if enable {
+		cmd.Println("Netbird service will start automatically at boot")
+	} else {
+		cmd.Println("Netbird service won't start automatically at boot")
+	}
+	return nil
+}
+
+// autostartCommand returns the service manager command enabling or disabling the service start at boot
+func autostartCommand(goos, name string, enable bool) ([]string, error) {
+	switch goos {
+	case "linux":
+		action := "disable"
+		if enable {
+			action = "enable"
+		}
+		return []string{"systemctl", action, name}, nil
+	case "darwin":
+		action := "unload"
+		if enable {
+			action = "load"
+		}
+		return []string{"launchctl", action, "-w", fmt.Sprintf("/Library/LaunchDaemons/%s.plist", name)}, nil
+	case "windows":
+		startType := "demand"
+		if enable {
+			startType = "auto"
+		}
+		return []string{"sc.exe", "config", name, "start=", startType}, nil
+	default:
+		return nil, fmt.Errorf("service autostart is not supported on %s", goos)
+	}
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutostartCommand(t *testing.T) {
+	tests := []struct {
+		goos     string
+		enable   bool
+		expected []string
+	}{
+		{goos: "linux", enable: true, expected: []string{"systemctl", "enable", "netbird"}},
+		{goos: "linux", enable: false, expected: []string{"systemctl", "disable", "netbird"}},
+		{goos: "darwin", enable: true, expected: []string{"launchctl", "load", "-w", "/Library/LaunchDaemons/netbird.plist"}},
+		{goos: "darwin", enable: false, expected: []string{"launchctl", "unload", "-w", "/Library/LaunchDaemons/netbird.plist"}},
+		{goos: "windows", enable: true, expected: []string{"sc.exe", "config", "netbird", "start=", "auto"}},
+		{goos: "windows", enable: false, expected: []string{"sc.exe", "config", "netbird", "start=", "demand"}},
+	}
+
+	for _, tt := range tests {
+		command, err := autostartCommand(tt.goos, "netbird", tt.enable)
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, command)
+	}
+
+	_, err := autostartCommand("plan9", "netbird", true)
+	assert.Error(t, err)
+}
@@ -23,7 +23,7 @@ func (p *program) Start(svc service.Service) error {
 	// Start should not block. Do the actual work async.
 	log.Info("starting Netbird service") //nolint
 	// in any case, even if configuration does not exists we run daemon to serve CLI gRPC API.
-	p.serv = grpc.NewServer()
+	p.serv = grpc.NewServer(grpc.Creds(server.NewPeerCredentials()))
 
 	split := strings.Split(daemonAddr, "://")
 	switch split[0] {
@@ -6,36 +6,62 @@ import (
 	"fmt"
 	"net"
 	"net/netip"
+	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/status"
 	"gopkg.in/yaml.v3"
 
 	"github.com/netbirdio/netbird/client/internal"
 	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/client/internal/ping"
 	"github.com/netbirdio/netbird/client/proto"
 	"github.com/netbirdio/netbird/util"
 	"github.com/netbirdio/netbird/version"
 )
 
 type peerStateDetailOutput struct {
-	FQDN                   string           `json:"fqdn" yaml:"fqdn"`
-	IP                     string           `json:"netbirdIp" yaml:"netbirdIp"`
-	PubKey                 string           `json:"publicKey" yaml:"publicKey"`
-	Status                 string           `json:"status" yaml:"status"`
-	LastStatusUpdate       time.Time        `json:"lastStatusUpdate" yaml:"lastStatusUpdate"`
-	ConnType               string           `json:"connectionType" yaml:"connectionType"`
-	Direct                 bool             `json:"direct" yaml:"direct"`
-	IceCandidateType       iceCandidateType `json:"iceCandidateType" yaml:"iceCandidateType"`
-	IceCandidateEndpoint   iceCandidateType `json:"iceCandidateEndpoint" yaml:"iceCandidateEndpoint"`
-	LastWireguardHandshake time.Time        `json:"lastWireguardHandshake" yaml:"lastWireguardHandshake"`
-	TransferReceived       int64            `json:"transferReceived" yaml:"transferReceived"`
-	TransferSent           int64            `json:"transferSent" yaml:"transferSent"`
-	RosenpassEnabled       bool             `json:"quantumResistance" yaml:"quantumResistance"`
-	Routes                 []string         `json:"routes" yaml:"routes"`
+	FQDN                   string                `json:"fqdn" yaml:"fqdn"`
+	IP                     string                `json:"netbirdIp" yaml:"netbirdIp"`
+	PubKey                 string                `json:"publicKey" yaml:"publicKey"`
+	Status                 string                `json:"status" yaml:"status"`
+	LastStatusUpdate       time.Time             `json:"lastStatusUpdate" yaml:"lastStatusUpdate"`
+	ConnType               string                `json:"connectionType" yaml:"connectionType"`
+	Direct                 bool                  `json:"direct" yaml:"direct"`
+	IceCandidateType       iceCandidateType      `json:"iceCandidateType" yaml:"iceCandidateType"`
+	IceCandidateEndpoint   iceCandidateType      `json:"iceCandidateEndpoint" yaml:"iceCandidateEndpoint"`
+	LastWireguardHandshake time.Time             `json:"lastWireguardHandshake" yaml:"lastWireguardHandshake"`
+	TransferReceived       int64                 `json:"transferReceived" yaml:"transferReceived"`
+	TransferSent           int64                 `json:"transferSent" yaml:"transferSent"`
+	RosenpassEnabled       bool                  `json:"quantumResistance" yaml:"quantumResistance"`
+	Routes                 []string              `json:"routes" yaml:"routes"`
+	Latency                time.Duration         `json:"latency,omitempty" yaml:"latency,omitempty"`
+	TCPTransport           bool                  `json:"tcpTransport,omitempty" yaml:"tcpTransport,omitempty"`
+	AdvertisedRoutes       []string              `json:"advertisedRoutes,omitempty" yaml:"advertisedRoutes,omitempty"`
+	AllowedIPs             []string              `json:"allowedIps,omitempty" yaml:"allowedIps,omitempty"`
+	AllowedIPsOverride     []string              `json:"allowedIpsOverride,omitempty" yaml:"allowedIpsOverride,omitempty"`
+	OSInfo                 *peerOSInfoOutput     `json:"osInfo,omitempty" yaml:"osInfo,omitempty"`
+	ICMPUnreachable        bool                  `json:"icmpUnreachable,omitempty" yaml:"icmpUnreachable,omitempty"`
+	Via                    string                `json:"via,omitempty" yaml:"via,omitempty"`
+	CipherSuite            string                `json:"cipherSuite,omitempty" yaml:"cipherSuite,omitempty"`
+	ActiveExitNode         bool                  `json:"activeExitNode,omitempty" yaml:"activeExitNode,omitempty"`
+	Transport              *transportStatsOutput `json:"transport,omitempty" yaml:"transport,omitempty"`
+	Group                  string                `json:"group,omitempty" yaml:"group,omitempty"`
+	RelayServer            string                `json:"relayServer,omitempty" yaml:"relayServer,omitempty"`
+}
+
+type transportStatsOutput struct {
+	Protocol        string `json:"protocol" yaml:"protocol"`
+	LocalPort       int64  `json:"localPort" yaml:"localPort"`
+	RemoteAddress   string `json:"remoteAddress" yaml:"remoteAddress"`
+	PacketsSent     int64  `json:"packetsSent" yaml:"packetsSent"`
+	PacketsReceived int64  `json:"packetsReceived" yaml:"packetsReceived"`
+	Retransmits     int64  `json:"retransmits" yaml:"retransmits"`
 }
 
 type peersStateOutput struct {
@@ -80,10 +106,44 @@ type nsServerGroupStateOutput struct {
 	Error   string   `json:"error" yaml:"error"`
 }
 
+type osInfoOutput struct {
+	OS         string `json:"os" yaml:"os"`
+	Kernel     string `json:"kernel" yaml:"kernel"`
+	Arch       string `json:"arch" yaml:"arch"`
+	TotalRAMMB int64  `json:"totalRamMb" yaml:"totalRamMb"`
+}
+
+type peerOSInfoOutput struct {
+	Name          string `json:"name" yaml:"name"`
+	Version       string `json:"version" yaml:"version"`
+	KernelVersion string `json:"kernelVersion" yaml:"kernelVersion"`
+}
+
+type daemonProcessStatsOutput struct {
+	RSSBytes       uint64 `json:"rssBytes" yaml:"rssBytes"`
+	HeapInUseBytes uint64 `json:"heapInUseBytes" yaml:"heapInUseBytes"`
+	Goroutines     uint32 `json:"goroutines" yaml:"goroutines"`
+}
+
+type interfaceStatsOutput struct {
+	Name      string `json:"name" yaml:"name"`
+	Up        bool   `json:"up" yaml:"up"`
+	RxBytes   uint64 `json:"rxBytes" yaml:"rxBytes"`
+	TxBytes   uint64 `json:"txBytes" yaml:"txBytes"`
+	RxPackets uint64 `json:"rxPackets" yaml:"rxPackets"`
+	TxPackets uint64 `json:"txPackets" yaml:"txPackets"`
+	RxErrors  uint64 `json:"rxErrors" yaml:"rxErrors"`
+	TxErrors  uint64 `json:"txErrors" yaml:"txErrors"`
+}
+
 type statusOutputOverview struct {
 	Peers               peersStateOutput           `json:"peers" yaml:"peers"`
 	CliVersion          string                     `json:"cliVersion" yaml:"cliVersion"`
 	DaemonVersion       string                     `json:"daemonVersion" yaml:"daemonVersion"`
+	DaemonStartedAt     *time.Time                 `json:"daemonStartedAt,omitempty" yaml:"daemonStartedAt,omitempty"`
+	DaemonProcessStats  *daemonProcessStatsOutput  `json:"daemonProcessStats,omitempty" yaml:"daemonProcessStats,omitempty"`
+	DaemonPID           int32                      `json:"daemonPid,omitempty" yaml:"daemonPid,omitempty"`
+	DaemonConfigFile    *string                    `json:"daemonConfigFile,omitempty" yaml:"daemonConfigFile,omitempty"`
 	ManagementState     managementStateOutput      `json:"management" yaml:"management"`
 	SignalState         signalStateOutput          `json:"signal" yaml:"signal"`
 	Relays              relayStateOutput           `json:"relays" yaml:"relays"`
@@ -95,20 +155,155 @@ type statusOutputOverview struct {
 	RosenpassPermissive bool                       `json:"quantumResistancePermissive" yaml:"quantumResistancePermissive"`
 	Routes              []string                   `json:"routes" yaml:"routes"`
 	NSServerGroups      []nsServerGroupStateOutput `json:"dnsServers" yaml:"dnsServers"`
+	OSInfo              *osInfoOutput              `json:"osInfo,omitempty" yaml:"osInfo,omitempty"`
+	STUNCheck           *stunCheckOutput           `json:"stunCheck,omitempty" yaml:"stunCheck,omitempty"`
+	ClockSkew           *clockSkewOutput           `json:"clockSkew,omitempty" yaml:"clockSkew,omitempty"`
+	InterfaceStats      *interfaceStatsOutput      `json:"interfaceStats,omitempty" yaml:"interfaceStats,omitempty"`
+	StatusVersion       uint64                     `json:"statusVersion,omitempty" yaml:"statusVersion,omitempty"`
+	RelaySummary        *relaySummaryOutput        `json:"relaySummary,omitempty" yaml:"relaySummary,omitempty"`
+}
+
+// statusSnapshot is the status overview saved by --save-snapshot
+type statusSnapshot struct {
+	SnapshotTime time.Time `json:"snapshotTime"`
+	statusOutputOverview
 }
 
 var (
-	detailFlag           bool
-	ipv4Flag             bool
-	jsonFlag             bool
-	yamlFlag             bool
-	ipsFilter            []string
-	prefixNamesFilter    []string
-	statusFilter         string
-	ipsFilterMap         map[string]struct{}
-	prefixNamesFilterMap map[string]struct{}
+	detailFlag            bool
+	ipv4Flag              bool
+	ipv4OrFQDNFlag        bool
+	jsonFlag              bool
+	yamlFlag              bool
+	ipsFilter             []string
+	prefixNamesFilter     []string
+	statusFilter          string
+	ipsFilterMap          map[string]struct{}
+	prefixNamesFilterMap  map[string]struct{}
+	includeOSInfoFlag     bool
+	transportStatsFlag    bool
+	formatFlag            string
+	statusOutputFile      string
+	daemonUptimeFlag      bool
+	maxLineLengthFlag     int
+	groupByStatusFlag     bool
+	saveSnapshotFile      string
+	ipv4ListFlag          bool
+	connectedOnlyFlag     bool
+	fqdnListFlag          bool
+	peerTimelineFlag      string
+	timelineBucketsFlag   int
+	relayBypassCheckFlag  bool
+	colorByLatencyFlag    bool
+	latencyThresholdsFlag []int
+	compareSnapshotFile   string
+	exportGraphvizFlag    bool
+	exportMermaidFlag     bool
+	connectionMatrixFlag  bool
+	includePeerRoutes     bool
+	includeAllowedIPs     bool
+	noSummaryFlag         bool
+	postToURL             string
+	hecToken              string
+	peersChangedSinceArg  string
+	peersChangedSince     time.Time
+	sinceVersionFlag      uint64
+	notSeenSinceArg       string
+	notSeenBefore         time.Time
+	connectedSinceArg     string
+	connectedBefore       time.Time
+	jsonSchemaFlag        bool
+	checkAllFlag          bool
+	peersInGroupFilter    []string
+	groupMembersMap       map[string]struct{}
+	suppressNoPeersFlag   bool
+	daemonAddrAutodetect  bool
+	colorConnectedIPFlag  bool
+	aggregateByRelayFlag  bool
+	checkSignalOnly       bool
+	checkManagementOnly   bool
+	peersTopFlag          int
+	daemonMemoryFlag      bool
+	checkInterfaceFlag    bool
+	jsonLinesPeersFlag    bool
+	noNewlineAtEndFlag    bool
+	peersDirectOnlyFlag   bool
+	peerPublicKeyFlag     string
+	interfaceStatsFlag    bool
+	prometheusPushFlag    bool
+	pushgatewayURLFlag    string
+	pushgatewayJob        string
+	pushgatewayUser       string
+	pushgatewayPassword   string
+	pushgatewayGrouping   map[string]string
+	pushgatewayLabels     map[string]string
+	checkICMPAllFlag      bool
+	icmpCheckTimeoutFlag  time.Duration
+	excludePeersFilter    []string
+	excludePeersMap       map[string]struct{}
+	peerOSInfoFlag        bool
+	peersUnreachableFlag  bool
+	pagerDutyRoutingKey   string
+	slackWebhookURL       string
+	networksFilter        []string
+	networksFilterPrefix  []netip.Prefix
+	cloudWatchNamespace   string
+	checkClockSkewFlag    bool
+	includePeerRouteVia   bool
+	routeTestTimeoutFlag  time.Duration
+	daemonPIDFlag         bool
+	showSensitiveFlag     bool
+	ansibleFactsFlag      bool
+	ansibleFactName       string
+	timeFormatFlag        string
+	latencySLAFlag        map[string]int64
+	daemonConfigPathFlag  bool
+	includeCipherSuites   bool
+	checkCipherFlag       string
+	aliveCheckPeer        string
+	sinceSnapshotFile     string
+	peersGatewayFlag      bool
+	peersExitNodeFlag     bool
+	latencyReportFlag     bool
+	latencySamplesFlag    int
 )
 
+const (
+	junitFormat        = "junit"
+	telegrafJSONFormat = "telegraf-json"
+	datadogJSONFormat  = "datadog-json"
+	splunkHECFormat    = "splunk-hec"
+	nmapXMLFormat      = "nmap-xml"
+	pagerDutyFormat    = "pagerduty"
+	cloudWatchFormat   = "cloudwatch"
+	syslogFormat       = "syslog"
+	terraformFormat    = "terraform-output"
+	systemdFormat      = "systemd-status"
+	slackFormat        = "slack"
+	minMaxLineLength   = 20
+	minPublicKeyPrefix = 8
+	mebibyte           = 1024 * 1024
+
+	connectedGroup    = "Connected"
+	disconnectedGroup = "Disconnected"
+
+	defaultTimeFormat  = "2006-01-02 15:04:05"
+	rfc3339TimeFormat  = "rfc3339"
+	unixTimeFormat     = "unix"
+	relativeTimeFormat = "relative"
+)
+
+// statusFormats lists the values accepted by the --format flag
+var statusFormats = []string{junitFormat, telegrafJSONFormat, datadogJSONFormat, splunkHECFormat, nmapXMLFormat, pagerDutyFormat, cloudWatchFormat, syslogFormat, terraformFormat, systemdFormat, slackFormat}
+
+// statusOutputFlags select the output of the status command, only one of them can be set
+var statusOutputFlags = []string{"yaml", "ipv4", "ipv4-or-fqdn", "format", "ipv4-list", "fqdn-list", "peer-timeline", "compare-with-previous", "since-snapshot",
+	"export-graphviz", "export-mermaid", "connection-matrix-json", "json-schema", "json-lines-peers", "export-prometheus-push", "export-ansible-facts"}
+
+// statusCheckFlags select a check instead of the output, displayed as json with --json. --check-all is exclusive with
+// them but can be combined with --detail
+var statusCheckFlags = []string{"check-signal-only", "check-management-only", "check-interface", "check-latency-sla", "alive-check", "latency-report", "check-icmp-all"}
+
 var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "status of the Netbird Service",
@@ -119,21 +314,153 @@ func init() {
 	ipsFilterMap = make(map[string]struct{})
 	prefixNamesFilterMap = make(map[string]struct{})
 	statusCmd.PersistentFlags().BoolVarP(&detailFlag, "detail", "d", false, "display detailed status information in human-readable format")
-	statusCmd.PersistentFlags().BoolVar(&jsonFlag, "json", false, "display detailed status information in json format")
+	statusCmd.PersistentFlags().BoolVar(&jsonFlag, "json", false, "display detailed status information in compact json format, a single line")
+	statusCmd.PersistentFlags().BoolVar(&jsonFlag, "json-compact", false, "alias of --json, display detailed status information in compact json format, a single line")
 	statusCmd.PersistentFlags().BoolVar(&yamlFlag, "yaml", false, "display detailed status information in yaml format")
 	statusCmd.PersistentFlags().BoolVar(&ipv4Flag, "ipv4", false, "display only NetBird IPv4 of this peer, e.g., --ipv4 will output 100.64.0.33")
-	statusCmd.MarkFlagsMutuallyExclusive("detail", "json", "yaml", "ipv4")
+	statusCmd.PersistentFlags().BoolVar(&ipv4OrFQDNFlag, "ipv4-or-fqdn", false, "display only the FQDN of this peer, or its NetBird IPv4 when it has no FQDN, e.g., HOST=$(netbird status --ipv4-or-fqdn)")
+	statusCmd.PersistentFlags().StringVar(&formatFlag, "format", "", fmt.Sprintf("display status information in the given format (%s), e.g., --format junit", strings.Join(statusFormats, "|")))
+	statusCmd.PersistentFlags().BoolVar(&ipv4ListFlag, "ipv4-list", false, "display only the NetBird IPs of the peers, one per line")
+	statusCmd.PersistentFlags().BoolVar(&fqdnListFlag, "fqdn-list", false, "display only the FQDNs of the peers, one per line")
+	statusCmd.PersistentFlags().StringVar(&peerTimelineFlag, "peer-timeline", "", "display the connection history of the given peer over the last 24 hours as a timeline, e.g., --peer-timeline peer.netbird.cloud")
+	statusCmd.PersistentFlags().IntVar(&timelineBucketsFlag, "timeline-buckets", defaultTimelineBuckets, "number of buckets the 24 hours of --peer-timeline are divided into, e.g., --timeline-buckets 48 for 30 minutes buckets")
+	statusCmd.PersistentFlags().StringVar(&compareSnapshotFile, "compare-with-previous", "", "report only the peers that connected or disconnected since the snapshot saved by --save-snapshot. Exits with 1 when peers disconnected and 2 when peers connected, e.g., --compare-with-previous /tmp/netbird-status.json")
+	statusCmd.PersistentFlags().StringVar(&sinceSnapshotFile, "since-snapshot", "", "report the new and lost peers and the changes of the connection status, connection type and latency since the snapshot "+
+		"in the given file, then atomically replace it with the current status. The first run only creates it. Exits with 1 on any degradation, e.g., --since-snapshot /var/lib/netbird/status.json")
+	statusCmd.PersistentFlags().BoolVar(&exportGraphvizFlag, "export-graphviz", false, "display the peers connectivity as a Graphviz DOT graph, e.g., netbird status --export-graphviz | dot -Tpng > topology.png")
+	statusCmd.PersistentFlags().BoolVar(&exportMermaidFlag, "export-mermaid", false, "display the peers connectivity as a Mermaid diagram, written as a fenced code block when --output is a Markdown file, "+
+		"e.g., netbird status --export-mermaid --output topology.md")
+	statusCmd.PersistentFlags().BoolVar(&connectionMatrixFlag, "connection-matrix-json", false, "display the peers connectivity as a json graph of nodes and edges, consumable by D3.js or Cytoscape.js")
+	statusCmd.PersistentFlags().BoolVar(&jsonLinesPeersFlag, "json-lines-peers", false, "display only the peers, one json object per line, e.g., netbird status --json-lines-peers | jq -r .fqdn")
+	statusCmd.PersistentFlags().BoolVar(&noNewlineAtEndFlag, "no-newline-at-end", false, "omit the newline after the last peer of --json-lines-peers")
+	statusCmd.PersistentFlags().BoolVar(&prometheusPushFlag, "export-prometheus-push", false, "push the peers connectivity, latency and transfer as Prometheus metrics to a Pushgateway instead of displaying the status, e.g., --export-prometheus-push --pushgateway-url http://localhost:9091 --job netbird")
+	statusCmd.PersistentFlags().StringVar(&pushgatewayURLFlag, "pushgateway-url", defaultPushgatewayURL, "Pushgateway URL used with --export-prometheus-push")
+	statusCmd.PersistentFlags().StringVar(&pushgatewayJob, "job", defaultPushgatewayJob, "Pushgateway job name used with --export-prometheus-push")
+	statusCmd.PersistentFlags().StringVar(&pushgatewayUser, "pushgateway-user", "", "Pushgateway basic auth user used with --export-prometheus-push")
+	statusCmd.PersistentFlags().StringVar(&pushgatewayPassword, "pushgateway-password", "", "Pushgateway basic auth password used with --export-prometheus-push")
+	statusCmd.PersistentFlags().StringToStringVar(&pushgatewayGrouping, "grouping-key", map[string]string{}, "additional labels of the Pushgateway grouping key used with --export-prometheus-push, e.g., --grouping-key instance=host-1,env=prod")
+	statusCmd.PersistentFlags().StringToStringVar(&pushgatewayLabels, "prometheus-push-gateway-labels", map[string]string{}, "labels added to every metric pushed with --export-prometheus-push and to the grouping key, "+
+		"e.g., --prometheus-push-gateway-labels environment=prod,region=eu-west-1")
+	statusCmd.PersistentFlags().BoolVar(&ansibleFactsFlag, "export-ansible-facts", false, "display the status as Ansible custom facts, "+
+		"e.g., --export-ansible-facts --output /etc/ansible/facts.d/netbird.fact. Keep the file non-executable, Ansible runs executable facts instead of reading them")
+	statusCmd.PersistentFlags().StringVar(&ansibleFactName, "ansible-fact-name", defaultAnsibleFactName, "top-level key of --export-ansible-facts, the facts show up as ansible_local.<name>")
+	statusCmd.PersistentFlags().BoolVar(&jsonSchemaFlag, "json-schema", false, "display the JSON Schema of the --json output without contacting the daemon")
+	statusCmd.PersistentFlags().BoolVar(&checkAllFlag, "check-all", false, "check that management and signal are connected, at least one peer is connected, no peer is stuck connecting and the daemon version matches the CLI. "+
+		"Exits with 2, 3, 4, 5 or 6 for the first failing check respectively, displayed as json with --json")
+	statusCmd.PersistentFlags().BoolVar(&checkSignalOnly, "check-signal-only", false, "check only that signal is connected and exit with 1 otherwise, displayed as json with --json")
+	statusCmd.PersistentFlags().BoolVar(&checkManagementOnly, "check-management-only", false, "check only that management is connected and exit with 1 otherwise, displayed as json with --json")
+	statusCmd.PersistentFlags().BoolVar(&checkInterfaceFlag, "check-interface", false, "check that the WireGuard interface exists and has the expected IP, public key and at least one peer, and exit with 1 otherwise, displayed as json with --json")
+	statusCmd.PersistentFlags().StringToInt64Var(&latencySLAFlag, "check-latency-sla", map[string]int64{}, "check that the latency of every given peer is at most the given milliseconds and exit with 1 otherwise, "+
+		"displayed as json with --json, e.g., --check-latency-sla db.netbird.cloud=10,api=50")
+	statusCmd.PersistentFlags().StringVar(&aliveCheckPeer, "alive-check", "", "check that the given peer is connected and answers a ping over the tunnel within 500ms, e.g., for Kubernetes probes. "+
+		"Exits with 1 when the peer is not connected, 2 when it doesn't answer and 3 when it isn't in the peer list, e.g., --alive-check db.netbird.cloud")
+	statusCmd.PersistentFlags().BoolVar(&latencyReportFlag, "latency-report", false, "ping every connected peer and display the mean, standard deviation, minimum, maximum and percentiles of its latency, "+
+		"with a histogram of all the samples, displayed as json with --json")
+	statusCmd.PersistentFlags().IntVar(&latencySamplesFlag, "latency-samples", defaultLatencySamples, "number of pings per peer of --latency-report, e.g., --latency-samples 20")
+	statusCmd.PersistentFlags().BoolVar(&checkICMPAllFlag, "check-icmp-all", false, "ping every connected peer over the tunnel and exit with 1 when any doesn't answer, displayed as json with --json")
+	statusCmd.PersistentFlags().DurationVar(&icmpCheckTimeoutFlag, "check-icmp-timeout", icmpReachabilityTimeout, "timeout of every ping of --check-icmp-all, e.g., --check-icmp-timeout 2s")
+	statusCmd.PersistentFlags().StringVar(&postToURL, "post-to", "", "post the --format splunk-hec events to the given Splunk HTTP Event Collector URL, e.g., --post-to https://splunk:8088/services/collector/event")
+	statusCmd.PersistentFlags().StringVar(&hecToken, "hec-token", "", "Splunk HTTP Event Collector token used with --post-to")
+	statusCmd.PersistentFlags().StringVar(&statusOutputFile, "output", "", "write the status output to the given file instead of stdout, e.g., --output test-results.xml")
+	statusCmd.PersistentFlags().StringVar(&pagerDutyRoutingKey, "post-to-pagerduty", "", "post the --format pagerduty event to the PagerDuty Events API with the given integration routing key, e.g., --post-to-pagerduty R0UT1NGK3Y")
+	statusCmd.PersistentFlags().StringVar(&cloudWatchNamespace, "cloudwatch-namespace", defaultCloudWatchNamespace, "CloudWatch namespace of the --format cloudwatch metrics")
+	statusCmd.MarkFlagsMutuallyExclusive("post-to", "output")
+	statusCmd.PersistentFlags().StringVar(&slackWebhookURL, "post-to-slack", "", "post the --format slack message to the given Slack incoming webhook URL, e.g., --post-to-slack https://hooks.slack.com/services/T000/B000/XXXX")
+	statusCmd.MarkFlagsMutuallyExclusive("post-to-pagerduty", "post-to", "output")
+	statusCmd.MarkFlagsMutuallyExclusive("post-to-slack", "post-to-pagerduty", "post-to", "output")
+	statusCmd.MarkFlagsMutuallyExclusive("export-prometheus-push", "output")
 	statusCmd.PersistentFlags().StringSliceVar(&ipsFilter, "filter-by-ips", []string{}, "filters the detailed output by a list of one or more IPs, e.g., --filter-by-ips 100.64.0.100,100.64.0.200")
+	statusCmd.PersistentFlags().StringArrayVar(&networksFilter, "peers-by-network", []string{}, "filters the detailed output by peers whose NetBird IP is in one of the given networks, can be repeated, e.g., --peers-by-network 100.64.0.0/24")
+	statusCmd.PersistentFlags().StringSliceVar(&excludePeersFilter, "exclude-peers", []string{}, "excludes the peers with the given FQDNs, hostnames or NetBird IPs from the output and the peer counts, e.g., --exclude-peers backup-server,100.64.0.200")
 	statusCmd.PersistentFlags().StringSliceVar(&prefixNamesFilter, "filter-by-names", []string{}, "filters the detailed output by a list of one or more peer FQDN or hostnames, e.g., --filter-by-names peer-a,peer-b.netbird.cloud")
+	statusCmd.PersistentFlags().StringSliceVar(&peersInGroupFilter, "peers-in-group", []string{}, "filters the detailed output by peers belonging to any of the given management groups, e.g., --peers-in-group databases --peers-in-group web")
+	statusCmd.PersistentFlags().BoolVar(&peersGatewayFlag, "peers-gateway", false, "display only the routing peers, i.e., the peers advertising at least one network route, with their advertised routes in the detailed output")
+	statusCmd.PersistentFlags().BoolVar(&peersExitNodeFlag, "peers-exit-node", false, "display only the exit nodes, i.e., the peers advertising a default route, and mark the one routing the traffic of this peer with [ACTIVE]. "+
+		"Warns when this peer should use an exit node but none is connected, e.g., --peers-exit-node --connected-only")
+	statusCmd.PersistentFlags().StringVar(&peerPublicKeyFlag, "peer-public-key", "", fmt.Sprintf("display the detail of the peer with the given WireGuard public key, or of the peers whose key starts with it when at least %d characters are given, e.g., --peer-public-key Pubkey1P", minPublicKeyPrefix))
 	statusCmd.PersistentFlags().StringVar(&statusFilter, "filter-by-status", "", "filters the detailed output by connection status(connected|disconnected), e.g., --filter-by-status connected")
+	statusCmd.PersistentFlags().BoolVar(&connectedOnlyFlag, "connected-only", false, "display only connected peers, a shorthand for --filter-by-status connected")
+	statusCmd.MarkFlagsMutuallyExclusive("connected-only", "filter-by-status")
+	statusCmd.PersistentFlags().BoolVar(&peersDirectOnlyFlag, "peers-direct-only", false, "display only connected peers using a direct P2P connection, i.e., --connected-only without the relayed peers")
+	statusCmd.PersistentFlags().StringVar(&peersChangedSinceArg, "peers-changed-since", "", "filters the detailed output by peers whose connection status changed after the given RFC3339 timestamp, e.g., --peers-changed-since 2024-01-01T10:00:00Z")
+	statusCmd.PersistentFlags().Uint64Var(&sinceVersionFlag, "since-version", 0, "filters the detailed output by peers changed after the given status version, the current version is reported as statusVersion in json and yaml, e.g., --since-version 42")
+	statusCmd.MarkFlagsMutuallyExclusive("peers-changed-since", "since-version")
+	statusCmd.PersistentFlags().StringVar(&notSeenSinceArg, "peers-not-seen-since", "", "filters the detailed output by peers whose connection status didn't change for the given duration and exits with 1 when any is found, e.g., --peers-not-seen-since 7d")
+	statusCmd.PersistentFlags().StringVar(&connectedSinceArg, "connected-since", "", "filters the detailed output by peers connected for longer than the given duration, i.e., stable connections, e.g., --connected-since 5m or 2d")
+	statusCmd.PersistentFlags().BoolVar(&suppressNoPeersFlag, "suppress-no-peers", false, "exit silently with 0 when no peers match the filters, instead of printing an empty peers output")
+	statusCmd.PersistentFlags().BoolVar(&includePeerRoutes, "include-managed-routes-in-peers", false, "display the networks each peer advertises as a routing peer in its detailed output")
+	statusCmd.PersistentFlags().BoolVar(&includeAllowedIPs, "include-allowed-ips", false, "display the WireGuard allowed IPs configured for each peer in its detailed output")
+	statusCmd.PersistentFlags().BoolVar(&transportStatsFlag, "transport-stats", false, "display the transport layer statistics (protocol, ports, packets and retransmits) of each peer connection")
+	statusCmd.PersistentFlags().BoolVar(&noSummaryFlag, "no-summary", false, "omit the general summary from the detailed output and display only the peers, no-op with --json and --yaml")
+	statusCmd.PersistentFlags().StringVar(&saveSnapshotFile, "save-snapshot", "", "atomically save the current status as json to the given file, e.g., --save-snapshot /tmp/netbird-status.json")
+	statusCmd.MarkFlagsMutuallyExclusive("since-snapshot", "save-snapshot")
+	statusCmd.PersistentFlags().IntVar(&peersTopFlag, "peers-top", 0, "display only the given number of peers with the most bytes sent and received, as a json array with --json, e.g., --peers-top 5")
+	statusCmd.PersistentFlags().BoolVar(&groupByStatusFlag, "group-by-status", false, "group the peers output by connection status, connected peers first")
+	statusCmd.PersistentFlags().BoolVar(&aggregateByRelayFlag, "aggregate-by-relay", false, "group the peers of the detailed output by the relay server they use, direct peers last")
+	statusCmd.MarkFlagsMutuallyExclusive("group-by-status", "aggregate-by-relay")
+	statusCmd.PersistentFlags().IntVar(&maxLineLengthFlag, "max-line-length", 0, "wrap the lines of the detailed peers output at the given length, e.g., --max-line-length 80")
+	statusCmd.PersistentFlags().BoolVar(&daemonUptimeFlag, "daemon-uptime", false, "display how long the daemon has been running")
+	statusCmd.PersistentFlags().BoolVar(&daemonPIDFlag, "daemon-pid", false, "display the process ID of the daemon, e.g., to send it signals from scripts")
+	statusCmd.PersistentFlags().BoolVar(&daemonConfigPathFlag, "daemon-config-path", false, "display the path of the config file the daemon loaded")
+	statusCmd.PersistentFlags().BoolVar(&showSensitiveFlag, "show-sensitive", false, "show the --daemon-pid of a daemon running as root to users other than root and the daemon user")
+	statusCmd.PersistentFlags().BoolVar(&daemonMemoryFlag, "daemon-memory-usage", false, "display the resident set size, heap in use and goroutines of the daemon process, requires root or the daemon user")
+	statusCmd.PersistentFlags().BoolVar(&peerOSInfoFlag, "peer-os-info", false, "display the OS and kernel each peer reported to management in its detailed output")
+	statusCmd.PersistentFlags().BoolVar(&peersUnreachableFlag, "peers-unreachable", false, "ping the NetBird IP of every connected peer and mark the peers that don't answer within 500ms in the detailed output, exits with 1 when any is found")
+	statusCmd.PersistentFlags().BoolVar(&interfaceStatsFlag, "interface-stats", false, "display the bytes, packets and errors the OS counted on the WireGuard interface and its state in the detailed output")
+	statusCmd.PersistentFlags().BoolVar(&colorByLatencyFlag, "color-by-latency", false, "color the peer names of the detailed output by latency: green up to 10ms, yellow up to 100ms, orange up to 500ms and red above or when disconnected")
+	statusCmd.PersistentFlags().IntSliceVar(&latencyThresholdsFlag, "color-threshold-latency", defaultLatencyThresholds, "green, yellow and orange upper bounds of --color-by-latency in milliseconds, implies --color-by-latency. "+
+		"They are stored in the daemon config and used by the next runs, e.g., --color-threshold-latency 50,200,800")
+	statusCmd.PersistentFlags().BoolVar(&colorConnectedIPFlag, "color-connected-ip", false, "highlight the FQDN and NetBird IP of this peer in bold cyan in the summary")
+	statusCmd.PersistentFlags().BoolVar(&includePeerRouteVia, "include-routes-for-peer", false, "run a route test to every connected peer and display whether it is reached directly or through a routing peer in the detailed output")
+	statusCmd.PersistentFlags().BoolVar(&includeCipherSuites, "include-cipher-suites", false, "display the cipher suite of the WireGuard session of every connected peer in the detailed output")
+	statusCmd.PersistentFlags().StringVar(&checkCipherFlag, "check-cipher", "", "exit with 1 when a connected peer doesn't use the given cipher suite, "+
+		"e.g., --check-cipher ChaCha20-Poly1305/Curve25519+Rosenpass")
+	statusCmd.PersistentFlags().DurationVar(&routeTestTimeoutFlag, "route-test-timeout", 2*time.Second, "timeout of every route test of --include-routes-for-peer")
+	statusCmd.PersistentFlags().StringVar(&timeFormatFlag, "time-format", defaultTimeFormat, "format of the timestamps in the human-readable output: rfc3339, unix, relative or a Go time layout, "+
+		"e.g., --time-format relative or --time-format '02 Jan 15:04'. The json and yaml output always use RFC3339")
+	statusCmd.PersistentFlags().BoolVar(&checkClockSkewFlag, "check-clock-skew", false, "compare the local time with the time of the management server and warn when they differ by more than 60 seconds, "+
+		"a failed check with --check-all when they do")
+	statusCmd.PersistentFlags().BoolVar(&relayBypassCheckFlag, "relay-bypass-check", false, "send a STUN binding request to the configured STUN servers and report the response time, mapped address and whether the NAT is symmetric")
+	statusCmd.PersistentFlags().BoolVar(&daemonAddrAutodetect, "daemon-addr-autodetect", false, "connect to the first common daemon socket that accepts connections, ignored when --daemon-addr is set")
+	statusCmd.PersistentFlags().BoolVar(&includeOSInfoFlag, "include-os-info", false, "include the OS, kernel, architecture and total RAM of the daemon host in the output")
+
+	markExclusiveWithOutputs("detail", "json")
+	markExclusiveWithOutputs("detail", "json-compact")
+	markExclusiveWithOutputs("check-all")
+	for _, checkFlag := range statusCheckFlags {
+		markExclusiveWithOutputs(checkFlag, "detail")
+	}
+	statusCmd.MarkFlagsMutuallyExclusive(append([]string{"check-all"}, statusCheckFlags...)...)
+}
+
+// markExclusiveWithOutputs marks the given flags, and every output flag, as mutually exclusive
+func markExclusiveWithOutputs(flags ...string) {
+	statusCmd.MarkFlagsMutuallyExclusive(append(flags, statusOutputFlags...)...)
 }
 
 func statusFunc(cmd *cobra.Command, args []string) error {
 	SetFlagsFromEnvVars(rootCmd)
+	SetFlagsFromEnvVars(cmd)
 
 	cmd.SetOut(cmd.OutOrStdout())
 
-	err := parseFilters()
+	if daemonAddrAutodetect && !rootCmd.PersistentFlags().Changed("daemon-addr") {
+		addr, err := autodetectDaemonAddr()
+		if err != nil {
+			return err
+		}
+		daemonAddr = addr
+	}
+
+	if jsonSchemaFlag {
+		schema, err := parseToJSONSchema()
+		if err != nil {
+			return err
+		}
+		return writeStatusOutput(cmd, schema)
+	}
+
+	err := parseStatusFlags(cmd)
 	if err != nil {
 		return err
 	}
@@ -145,7 +472,21 @@ func statusFunc(cmd *cobra.Command, args []string) error {
 
 	ctx := internal.CtxInitState(context.Background())
 
-	resp, err := getStatus(ctx, cmd)
+	conn, err := dialDaemon(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := proto.NewDaemonServiceClient(conn)
+
+	if colorByLatencyFlag {
+		err = syncLatencyThresholds(cmd.Context(), client, cmd.Flag("color-threshold-latency").Changed)
+		if err != nil {
+			return err
+		}
+	}
+
+	resp, err := requestStatus(cmd.Context(), client)
 	if err != nil {
 		return err
 	}
@@ -167,39 +508,451 @@ func statusFunc(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if ipv4OrFQDNFlag {
+		cmd.Print(parseFQDNOrInterfaceIP(resp.GetFullStatus().GetLocalPeerState()))
+		return nil
+	}
+
+	if peerTimelineFlag != "" {
+		events, err := getPeerEvents(cmd.Context(), client, peerTimelineFlag)
+		if err != nil {
+			return err
+		}
+		return writeStatusOutput(cmd, parsePeerTimeline(peerTimelineFlag, events, time.Now(), timelineBucketsFlag))
+	}
+
+	if len(peersInGroupFilter) > 0 {
+		groupMembersMap, err = getGroupMembers(cmd.Context(), client, peersInGroupFilter)
+		if err != nil {
+			return err
+		}
+	}
+
 	outputInformationHolder := convertToStatusOutputOverview(resp)
 
-	var statusOutputString string
+	if outputInformationHolder.Peers.Total == 0 && hasPeerFilters() {
+		if suppressNoPeersFlag {
+			return nil
+		}
+		cmd.PrintErrln("No peers match the specified filters.")
+	}
+
+	if peersExitNodeFlag && exitNodeConfiguredWithoutConnection(resp.GetFullStatus().GetPeers()) {
+		cmd.PrintErrln("Warning: this peer is configured to use an exit node but none is connected")
+	}
+
+	if peerPublicKeyFlag != "" {
+		matched := peersByPublicKey(outputInformationHolder.Peers, peerPublicKeyFlag)
+		if matched.Total == 0 {
+			return fmt.Errorf("No peer with public key %s found", peerPublicKeyFlag)
+		}
+		if matched.Total > 1 {
+			cmd.PrintErrf("Warning: %d peers have a public key starting with %s, use more characters to select a single peer\n", matched.Total, peerPublicKeyFlag)
+		}
+		outputInformationHolder.Peers = matched
+	}
+
+	if peersTopFlag > 0 {
+		outputInformationHolder.Peers = topPeersByTransfer(outputInformationHolder.Peers, peersTopFlag)
+	}
+
+	if aggregateByRelayFlag {
+		aggregateByRelay(&outputInformationHolder, relayHostsByIP(outputInformationHolder.Relays, net.LookupHost))
+	}
+
+	err = addDaemonDetails(cmd.Context(), client, &outputInformationHolder)
+	if err != nil {
+		return err
+	}
+
+	var icmpUnreachable int
+	if peersUnreachableFlag {
+		icmpUnreachable = markICMPUnreachablePeers(ctx, &outputInformationHolder.Peers, ping.Ping, icmpReachabilityTimeout)
+	}
+
+	if relayBypassCheckFlag {
+		outputInformationHolder.STUNCheck, err = runSTUNCheck(ctx, outputInformationHolder.Relays)
+		if err != nil {
+			return err
+		}
+	}
+
+	if checkClockSkewFlag {
+		outputInformationHolder.ClockSkew, err = checkClockSkew(ctx, outputInformationHolder.ManagementState.URL)
+		if err != nil {
+			return err
+		}
+	}
+
+	var interfaceChecks []healthCheck
+	var interfaceName string
+	if checkInterfaceFlag {
+		interfaceName, interfaceChecks, err = getInterfaceChecks(cmd.Context(), client)
+		if err != nil {
+			return err
+		}
+	}
+
+	var previousSnapshot *statusSnapshot
+	if compareSnapshotFile != "" {
+		previousSnapshot, err = loadSnapshot(compareSnapshotFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	var sinceSnapshot *statusSnapshot
+	if sinceSnapshotFile != "" {
+		sinceSnapshot, err = loadSnapshotIfExists(sinceSnapshotFile)
+		if err != nil {
+			return err
+		}
+		err = saveSnapshot(sinceSnapshotFile, outputInformationHolder, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
+	if saveSnapshotFile != "" {
+		err = saveSnapshot(saveSnapshotFile, outputInformationHolder, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
+	statusOutputString, failedChecksErr, err := parseStatusOutput(ctx, outputInformationHolder, interfaceName, interfaceChecks, previousSnapshot, sinceSnapshot)
+	if err != nil {
+		return err
+	}
+
+	if failedChecksErr == nil {
+		failedChecksErr = failedPeersCheckErr(outputInformationHolder.Peers, icmpUnreachable)
+	}
+
+	err = sendStatusOutput(cmd, statusOutputString)
+	if err != nil {
+		return err
+	}
+
+	return failedChecksErr
+}
+
+// parseStatusFlags validates the flags of the status command and enables the detailed output for the flags
+// which only show up in it
+func parseStatusFlags(cmd *cobra.Command) error {
+	err := parseFilters()
+	if err != nil {
+		return err
+	}
+
+	err = parseFormat()
+	if err != nil {
+		return err
+	}
+
+	err = parseMaxLineLength()
+	if err != nil {
+		return err
+	}
+
+	err = parseTimelineBuckets()
+	if err != nil {
+		return err
+	}
+
+	if cmd.Flag("color-threshold-latency").Changed {
+		colorByLatencyFlag = true
+	}
+
+	err = parseLatencyThresholds()
+	if err != nil {
+		return err
+	}
+
+	err = parseAnsibleFactName()
+	if err != nil {
+		return err
+	}
+
+	err = parseLatencySLAs()
+	if err != nil {
+		return err
+	}
+
+	err = parseLatencySamples()
+	if err != nil {
+		return err
+	}
+
+	err = parsePushgatewayLabels()
+	if err != nil {
+		return err
+	}
+
+	err = parseICMPCheckTimeout()
+	if err != nil {
+		return err
+	}
+
+	if peersTopFlag < 0 {
+		return fmt.Errorf("wrong peers top, should be a positive number, got: %d", peersTopFlag)
+	}
+
+	if transportStatsFlag || includePeerRoutes || includeAllowedIPs || noSummaryFlag || aggregateByRelayFlag || peersTopFlag > 0 || interfaceStatsFlag || peerOSInfoFlag || peersUnreachableFlag || includePeerRouteVia || includeCipherSuites {
+		enableDetailFlagWhenFilterFlag()
+	}
+	return nil
+}
+
+// addDaemonDetails requests the details of the daemon process and of its interface selected by the flags
+func addDaemonDetails(ctx context.Context, client proto.DaemonServiceClient, overview *statusOutputOverview) error {
+	var err error
+	if daemonUptimeFlag {
+		startedAt, err := getDaemonStartedAt(ctx, client)
+		if err != nil {
+			return err
+		}
+		overview.DaemonStartedAt = &startedAt
+	}
+
+	if daemonMemoryFlag {
+		overview.DaemonProcessStats, err = getDaemonProcessStats(ctx, client)
+		if err != nil {
+			return err
+		}
+	}
+
+	if daemonPIDFlag {
+		overview.DaemonPID, err = getDaemonPID(ctx, client)
+		if err != nil {
+			return err
+		}
+	}
+
+	if daemonConfigPathFlag {
+		configFile, err := getDaemonConfigFile(ctx, client)
+		if err != nil {
+			return err
+		}
+		overview.DaemonConfigFile = &configFile
+	}
+
+	if interfaceStatsFlag {
+		overview.InterfaceStats, err = getInterfaceStats(ctx, client)
+		if err != nil {
+			return err
+		}
+	}
+
+	if includePeerRouteVia {
+		getPeerRoutes(ctx, client, &overview.Peers)
+	}
+	return nil
+}
+
+// parseStatusOutput renders the output selected by the flags. failedChecksErr is set when the output reports a
+// failed check, err when it couldn't be rendered
+func parseStatusOutput(ctx context.Context, overview statusOutputOverview, interfaceName string, interfaceChecks []healthCheck, previousSnapshot, sinceSnapshot *statusSnapshot) (output string, failedChecksErr error, err error) {
 	switch {
+	case checkAllFlag:
+		checks := runHealthChecks(overview)
+		if jsonFlag {
+			output, err = parseHealthChecksToJSON(checks)
+		} else {
+			output = parseHealthChecks(checks)
+		}
+		failedChecksErr = failedHealthCheckErr(checks)
+	case checkSignalOnly:
+		signalState := overview.SignalState
+		output, err = parseServiceCheck("Signal", signalState.URL, signalState.Connected, signalState.Error, jsonFlag)
+		failedChecksErr = failedServiceCheckErr("Signal", signalState.Connected)
+	case checkManagementOnly:
+		managementState := overview.ManagementState
+		output, err = parseServiceCheck("Management", managementState.URL, managementState.Connected, managementState.Error, jsonFlag)
+		failedChecksErr = failedServiceCheckErr("Management", managementState.Connected)
+	case checkInterfaceFlag:
+		if jsonFlag {
+			output, err = parseHealthChecksToJSON(interfaceChecks)
+		} else {
+			output = fmt.Sprintf("Interface %s:\n%s", interfaceName, parseHealthChecks(interfaceChecks))
+		}
+		failedChecksErr = failedHealthCheckErr(interfaceChecks)
+	case aliveCheckPeer != "":
+		output, failedChecksErr = checkPeerAlive(ctx, overview.Peers.Details, aliveCheckPeer, ping.Ping, icmpReachabilityTimeout)
+	case checkICMPAllFlag:
+		icmpCheck := checkICMPAll(ctx, overview.Peers.Details, ping.Ping, icmpCheckTimeoutFlag)
+		if jsonFlag {
+			output, err = parseICMPCheckToJSON(icmpCheck)
+		} else {
+			output = parseICMPCheck(icmpCheck)
+		}
+		failedChecksErr = failedICMPCheckErr(icmpCheck)
+	case latencyReportFlag:
+		report := buildLatencyReport(ctx, overview.Peers.Details, ping.Ping, latencySamplesFlag, latencyReportPingTimeout)
+		if jsonFlag {
+			output, err = parseLatencyReportToJSON(report)
+		} else {
+			output = parseLatencyReport(report)
+		}
+	case len(latencySLAFlag) > 0:
+		results := checkLatencySLAs(overview.Peers.Details, latencySLAFlag)
+		if jsonFlag {
+			output, err = parseLatencySLAResultsToJSON(results)
+		} else {
+			output = parseLatencySLAResults(results)
+		}
+		failedChecksErr = failedLatencySLAErr(results)
+	case previousSnapshot != nil:
+		output, failedChecksErr = compareWithSnapshot(previousSnapshot, overview, time.Now())
+	case sinceSnapshotFile != "":
+		output, failedChecksErr = diffSinceSnapshot(sinceSnapshot, overview, sinceSnapshotFile)
+	case ipv4ListFlag:
+		output = parsePeersIPList(overview.Peers)
+	case fqdnListFlag:
+		output = parsePeersFQDNList(overview.Peers)
+	case jsonLinesPeersFlag:
+		output, err = parsePeersToJSONLines(overview.Peers, noNewlineAtEndFlag)
+	case prometheusPushFlag:
+		output = parseToPrometheus(overview, pushgatewayLabels)
+	case ansibleFactsFlag:
+		output, err = parseToAnsibleFacts(overview, ansibleFactName)
+	case exportGraphvizFlag:
+		output = parseToGraphviz(overview)
+	case exportMermaidFlag:
+		output = parseToMermaid(overview, statusOutputFile)
+	case connectionMatrixFlag:
+		output, err = parseToConnectionMatrixJSON(overview)
+	case formatFlag == junitFormat:
+		var failures int
+		output, failures, err = parseToJUnit(overview, time.Now())
+		if failures > 0 {
+			failedChecksErr = fmt.Errorf("%d of %d peers are not connected", failures, overview.Peers.Total)
+		}
+	case formatFlag == telegrafJSONFormat:
+		output, err = parseToTelegrafJSON(overview, time.Now())
+	case formatFlag == datadogJSONFormat:
+		output, err = parseToDatadog(overview, time.Now())
+	case formatFlag == splunkHECFormat:
+		output, err = parseToSplunkHEC(overview, time.Now())
+	case formatFlag == pagerDutyFormat:
+		output, err = parseToPagerDuty(overview, pagerDutyRoutingKey, time.Now())
+	case formatFlag == cloudWatchFormat:
+		output, err = parseToCloudWatch(overview, cloudWatchNamespace, time.Now())
+	case formatFlag == syslogFormat:
+		output = parseToSyslog(overview, time.Now())
+	case formatFlag == slackFormat:
+		output, err = parseToSlack(overview, time.Now())
+	case formatFlag == systemdFormat:
+		var ready bool
+		output, ready = parseToSystemdStatus(overview)
+		if !ready {
+			failedChecksErr = fmt.Errorf("management or signal is not connected")
+		}
+	case formatFlag == terraformFormat:
+		output, err = parseToTerraformOutput(overview)
+	case formatFlag == nmapXMLFormat:
+		output, err = parseToNmapXML(overview, time.Now())
+	case peersTopFlag > 0 && jsonFlag:
+		output, err = parsePeersToJSON(overview.Peers.Details)
 	case detailFlag:
-		statusOutputString = parseToFullDetailSummary(outputInformationHolder)
+		output = parseToFullDetailSummary(overview)
 	case jsonFlag:
-		statusOutputString, err = parseToJSON(outputInformationHolder)
+		output, err = parseToJSON(overview)
 	case yamlFlag:
-		statusOutputString, err = parseToYAML(outputInformationHolder)
+		output, err = parseToYAML(overview)
 	default:
-		statusOutputString = parseGeneralSummary(outputInformationHolder, false, false, false)
+		output = parseGeneralSummary(overview, false, false, false)
 	}
+	return output, failedChecksErr, err
+}
 
-	if err != nil {
-		return err
+// failedPeersCheckErr reports the peers matching --peers-not-seen-since, unreachable over ICMP or not using the
+// cipher suite of --check-cipher
+func failedPeersCheckErr(peers peersStateOutput, icmpUnreachable int) error {
+	if notSeenSinceArg != "" && peers.Total > 0 {
+		return fmt.Errorf("%d peers not seen since %s", peers.Total, notSeenSinceArg)
 	}
 
-	cmd.Print(statusOutputString)
+	if icmpUnreachable > 0 {
+		return fmt.Errorf("%d connected peers are unreachable over ICMP", icmpUnreachable)
+	}
 
+	if checkCipherFlag != "" {
+		if mismatched := peersWithoutCipher(peers, checkCipherFlag); len(mismatched) > 0 {
+			return fmt.Errorf("%d connected peers don't use the cipher suite %s: %s", len(mismatched), checkCipherFlag, strings.Join(mismatched, ", "))
+		}
+	}
 	return nil
 }
 
-func getStatus(ctx context.Context, cmd *cobra.Command) (*proto.StatusResponse, error) {
+// sendStatusOutput posts the output to the external service given by the flags, or writes it to the output file or
+// stdout when there is none
+func sendStatusOutput(cmd *cobra.Command, output string) error {
+	switch {
+	case postToURL != "":
+		return postToSplunkHEC(cmd.Context(), postToURL, hecToken, output)
+	case pagerDutyRoutingKey != "":
+		return postToPagerDuty(cmd.Context(), pagerDutyEventsURL, output)
+	case slackWebhookURL != "":
+		return postToSlack(cmd.Context(), slackWebhookURL, output)
+	case prometheusPushFlag:
+		return pushToPushgateway(cmd.Context(), pushgatewayURLFlag, pushgatewayJob, pushgatewayGroupingKey(pushgatewayGrouping, pushgatewayLabels), pushgatewayUser, pushgatewayPassword, output)
+	default:
+		return writeStatusOutput(cmd, output)
+	}
+}
+
+// saveSnapshot writes the overview to a temporary file next to the target and renames it, so readers never see a partial snapshot
+func saveSnapshot(file string, overview statusOutputOverview, now time.Time) error {
+	snapshot := statusSnapshot{
+		SnapshotTime:         now,
+		statusOutputOverview: overview,
+	}
+
+	if err := util.WriteJson(file, snapshot); err != nil {
+		return fmt.Errorf("failed saving status snapshot to %s: %v", file, err)
+	}
+	return nil
+}
+
+func writeStatusOutput(cmd *cobra.Command, output string) error {
+	if statusOutputFile == "" {
+		cmd.Print(output)
+		return nil
+	}
+
+	if err := os.WriteFile(statusOutputFile, []byte(output), 0644); err != nil {
+		return fmt.Errorf("failed writing status output to %s: %v", statusOutputFile, err)
+	}
+	return nil
+}
+
+// dialDaemon connects to the daemon, telling how to start it when it doesn't answer
+func dialDaemon(ctx context.Context) (*grpc.ClientConn, error) {
 	conn, err := DialClientGRPCServer(ctx, daemonAddr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to daemon error: %v\n"+
 			"If the daemon is not running please run: "+
 			"\nnetbird service install \nnetbird service start\n", err)
 	}
+	return conn, nil
+}
+
+func getStatus(ctx context.Context, cmd *cobra.Command) (*proto.StatusResponse, error) {
+	conn, err := dialDaemon(ctx)
+	if err != nil {
+		return nil, err
+	}
 	defer conn.Close()
 
-	resp, err := proto.NewDaemonServiceClient(conn).Status(cmd.Context(), &proto.StatusRequest{GetFullPeerStatus: true})
+	return requestStatus(cmd.Context(), proto.NewDaemonServiceClient(conn))
+}
+
+func requestStatus(ctx context.Context, client proto.DaemonServiceClient) (*proto.StatusResponse, error) {
+	resp, err := client.Status(ctx, &proto.StatusRequest{
+		GetFullPeerStatus: true,
+		IncludeOSInfo:     includeOSInfoFlag,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("status failed: %v", status.Convert(err).Message())
 	}
@@ -207,7 +960,90 @@ func getStatus(ctx context.Context, cmd *cobra.Command) (*proto.StatusResponse,
 	return resp, nil
 }
 
+func getDaemonStartedAt(ctx context.Context, client proto.DaemonServiceClient) (time.Time, error) {
+	resp, err := client.GetDaemonUptime(ctx, &proto.GetDaemonUptimeRequest{})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("get daemon uptime failed: %v", status.Convert(err).Message())
+	}
+
+	return resp.GetStartedAt().AsTime().Local().Truncate(time.Second), nil
+}
+
+func getDaemonProcessStats(ctx context.Context, client proto.DaemonServiceClient) (*daemonProcessStatsOutput, error) {
+	resp, err := client.GetProcessStats(ctx, &proto.GetProcessStatsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("get daemon process stats failed: %v", status.Convert(err).Message())
+	}
+
+	return &daemonProcessStatsOutput{
+		RSSBytes:       resp.GetRssBytes(),
+		HeapInUseBytes: resp.GetHeapInUseBytes(),
+		Goroutines:     resp.GetGoroutines(),
+	}, nil
+}
+
+func getDaemonPID(ctx context.Context, client proto.DaemonServiceClient) (int32, error) {
+	resp, err := client.GetProcessInfo(ctx, &proto.GetProcessInfoRequest{ShowSensitive: showSensitiveFlag})
+	if err != nil {
+		return 0, fmt.Errorf("get daemon process info failed: %v", status.Convert(err).Message())
+	}
+
+	return resp.GetPid(), nil
+}
+
+func getDaemonConfigFile(ctx context.Context, client proto.DaemonServiceClient) (string, error) {
+	resp, err := client.GetConfig(ctx, &proto.GetConfigRequest{})
+	if err != nil {
+		return "", fmt.Errorf("get daemon config failed: %v", status.Convert(err).Message())
+	}
+
+	return resp.GetConfigFile(), nil
+}
+
+func getInterfaceStats(ctx context.Context, client proto.DaemonServiceClient) (*interfaceStatsOutput, error) {
+	resp, err := client.GetInterfaceStats(ctx, &proto.GetInterfaceStatsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("get interface stats failed: %v", status.Convert(err).Message())
+	}
+
+	return &interfaceStatsOutput{
+		Name:      resp.GetInterfaceName(),
+		Up:        resp.GetUp(),
+		RxBytes:   resp.GetRxBytes(),
+		TxBytes:   resp.GetTxBytes(),
+		RxPackets: resp.GetRxPackets(),
+		TxPackets: resp.GetTxPackets(),
+		RxErrors:  resp.GetRxErrors(),
+		TxErrors:  resp.GetTxErrors(),
+	}, nil
+}
+
+func getInterfaceChecks(ctx context.Context, client proto.DaemonServiceClient) (string, []healthCheck, error) {
+	resp, err := client.VerifyInterface(ctx, &proto.VerifyInterfaceRequest{})
+	if err != nil {
+		return "", nil, fmt.Errorf("verify interface failed: %v", status.Convert(err).Message())
+	}
+
+	return resp.GetInterfaceName(), interfaceChecksFromProto(resp.GetChecks()), nil
+}
+
+func getGroupMembers(ctx context.Context, client proto.DaemonServiceClient, groups []string) (map[string]struct{}, error) {
+	resp, err := client.GetGroupMembers(ctx, &proto.GetGroupMembersRequest{Groups: groups})
+	if err != nil {
+		return nil, fmt.Errorf("get group members failed: %v", status.Convert(err).Message())
+	}
+
+	members := make(map[string]struct{}, len(resp.GetFqdns()))
+	for _, fqdn := range resp.GetFqdns() {
+		members[fqdn] = struct{}{}
+	}
+	return members, nil
+}
+
 func parseFilters() error {
+	if connectedOnlyFlag {
+		statusFilter = "connected"
+	}
 
 	switch strings.ToLower(statusFilter) {
 	case "", "disconnected", "connected":
@@ -229,18 +1065,125 @@ func parseFilters() error {
 		}
 	}
 
-	if len(prefixNamesFilter) > 0 {
-		for _, name := range prefixNamesFilter {
-			prefixNamesFilterMap[strings.ToLower(name)] = struct{}{}
+	networksFilterPrefix = nil
+	for _, network := range networksFilter {
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			return fmt.Errorf("got an invalid network in the filter: network %s, error %s", network, err)
+		}
+		networksFilterPrefix = append(networksFilterPrefix, prefix.Masked())
+		enableDetailFlagWhenFilterFlag()
+	}
+
+	excludePeersMap = make(map[string]struct{}, len(excludePeersFilter))
+	for _, excluded := range excludePeersFilter {
+		excluded = strings.ToLower(strings.TrimSpace(excluded))
+		if excluded == "" {
+			continue
+		}
+		excludePeersMap[excluded] = struct{}{}
+		enableDetailFlagWhenFilterFlag()
+	}
+
+	if len(prefixNamesFilter) > 0 {
+		for _, name := range prefixNamesFilter {
+			prefixNamesFilterMap[strings.ToLower(name)] = struct{}{}
+		}
+		enableDetailFlagWhenFilterFlag()
+	}
+
+	if peersChangedSinceArg != "" {
+		since, err := time.Parse(time.RFC3339, peersChangedSinceArg)
+		if err != nil {
+			return fmt.Errorf("got an invalid peers changed since timestamp, should be RFC3339, e.g., 2024-01-01T10:00:00Z: %s", err)
+		}
+		peersChangedSince = since
+		enableDetailFlagWhenFilterFlag()
+	}
+
+	if sinceVersionFlag > 0 {
+		enableDetailFlagWhenFilterFlag()
+	}
+
+	if len(peersInGroupFilter) > 0 {
+		enableDetailFlagWhenFilterFlag()
+	}
+
+	if peersDirectOnlyFlag {
+		enableDetailFlagWhenFilterFlag()
+	}
+
+	if peersGatewayFlag {
+		enableDetailFlagWhenFilterFlag()
+	}
+
+	if peersExitNodeFlag {
+		enableDetailFlagWhenFilterFlag()
+	}
+
+	if peerPublicKeyFlag != "" {
+		enableDetailFlagWhenFilterFlag()
+	}
+
+	if notSeenSinceArg != "" {
+		notSeenSince, err := parseDayDuration(notSeenSinceArg)
+		if err != nil {
+			return fmt.Errorf("got an invalid peers not seen since duration, e.g., 7d or 12h: %s", err)
+		}
+		notSeenBefore = time.Now().Add(-notSeenSince)
+		enableDetailFlagWhenFilterFlag()
+	}
+
+	if connectedSinceArg != "" {
+		connectedSince, err := parseDayDuration(connectedSinceArg)
+		if err != nil || connectedSince < 0 {
+			return fmt.Errorf("got an invalid connected since duration, e.g., 5m, 12h or 2d: %s", connectedSinceArg)
+		}
+		connectedBefore = time.Now().Add(-connectedSince)
+		enableDetailFlagWhenFilterFlag()
+	}
+
+	return nil
+}
+
+func parseMaxLineLength() error {
+	if maxLineLengthFlag != 0 && maxLineLengthFlag < minMaxLineLength {
+		return fmt.Errorf("wrong max line length, should be at least %d, got: %d", minMaxLineLength, maxLineLengthFlag)
+	}
+	return nil
+}
+
+func parseFormat() error {
+	if postToURL != "" && formatFlag != splunkHECFormat {
+		return fmt.Errorf("--post-to requires --format %s", splunkHECFormat)
+	}
+
+	if pagerDutyRoutingKey != "" && formatFlag != pagerDutyFormat {
+		return fmt.Errorf("--post-to-pagerduty requires --format %s", pagerDutyFormat)
+	}
+
+	if slackWebhookURL != "" && formatFlag != slackFormat {
+		return fmt.Errorf("--post-to-slack requires --format %s", slackFormat)
+	}
+
+	if formatFlag == cloudWatchFormat && strings.TrimSpace(cloudWatchNamespace) == "" {
+		return fmt.Errorf("--cloudwatch-namespace can't be empty")
+	}
+
+	if formatFlag == "" {
+		return nil
+	}
+
+	for _, format := range statusFormats {
+		if formatFlag == format {
+			return nil
 		}
-		enableDetailFlagWhenFilterFlag()
 	}
-
-	return nil
+	return fmt.Errorf("wrong format, should be one of %s, got: %s", strings.Join(statusFormats, "|"), formatFlag)
 }
 
 func enableDetailFlagWhenFilterFlag() {
-	if !detailFlag && !jsonFlag && !yamlFlag {
+	if !detailFlag && !jsonFlag && !yamlFlag && formatFlag == "" && !ipv4ListFlag && !fqdnListFlag && !exportGraphvizFlag && !exportMermaidFlag && !connectionMatrixFlag && !ansibleFactsFlag {
 		detailFlag = true
 	}
 }
@@ -280,11 +1223,25 @@ func convertToStatusOutputOverview(resp *proto.StatusResponse) statusOutputOverv
 		RosenpassPermissive: pbFullStatus.GetLocalPeerState().GetRosenpassPermissive(),
 		Routes:              pbFullStatus.GetLocalPeerState().GetRoutes(),
 		NSServerGroups:      mapNSGroups(pbFullStatus.GetDnsServers()),
+		OSInfo:              mapOSInfo(pbFullStatus.GetLocalPeerState()),
+		StatusVersion:       pbFullStatus.GetVersion(),
 	}
 
 	return overview
 }
 
+func mapOSInfo(localPeerState *proto.LocalPeerState) *osInfoOutput {
+	if localPeerState.GetOs() == "" && localPeerState.GetKernel() == "" && localPeerState.GetArch() == "" {
+		return nil
+	}
+	return &osInfoOutput{
+		OS:         localPeerState.GetOs(),
+		Kernel:     localPeerState.GetKernel(),
+		Arch:       localPeerState.GetArch(),
+		TotalRAMMB: localPeerState.GetTotalRAMMB(),
+	}
+}
+
 func mapRelays(relays []*proto.RelayState) relayStateOutput {
 	var relayStateDetail []relayStateOutputDetail
 
@@ -378,6 +1335,44 @@ func mapPeers(peers []*proto.PeerState) peersStateOutput {
 			TransferSent:           transferSent,
 			RosenpassEnabled:       pbPeerState.GetRosenpassEnabled(),
 			Routes:                 pbPeerState.GetRoutes(),
+			Latency:                pbPeerState.GetLatency().AsDuration(),
+			TCPTransport:           pbPeerState.GetTransportStats().GetProtocol() == "TCP",
+		}
+
+		if transportStatsFlag {
+			peerState.Transport = mapTransportStats(pbPeerState.GetTransportStats())
+		}
+
+		if includePeerRoutes || peersGatewayFlag {
+			peerState.AdvertisedRoutes = pbPeerState.GetAdvertisedRoutes()
+			if peerState.AdvertisedRoutes == nil {
+				peerState.AdvertisedRoutes = []string{}
+			}
+		}
+
+		if includeAllowedIPs {
+			peerState.AllowedIPs = pbPeerState.GetAllowedIPs()
+			if peerState.AllowedIPs == nil {
+				peerState.AllowedIPs = []string{}
+			}
+		}
+
+		peerState.AllowedIPsOverride = pbPeerState.GetAllowedIPsOverride()
+
+		if peerOSInfoFlag {
+			peerState.OSInfo = &peerOSInfoOutput{
+				Name:          pbPeerState.GetOsName(),
+				Version:       pbPeerState.GetOsVersion(),
+				KernelVersion: pbPeerState.GetKernelVersion(),
+			}
+		}
+
+		if peersExitNodeFlag {
+			peerState.ActiveExitNode = hasExitNodeRoute(peerState.Routes)
+		}
+
+		if includeCipherSuites || checkCipherFlag != "" {
+			peerState.CipherSuite = pbPeerState.GetCipherSuite()
 		}
 
 		peersStateDetail = append(peersStateDetail, peerState)
@@ -385,6 +1380,10 @@ func mapPeers(peers []*proto.PeerState) peersStateOutput {
 
 	sortPeersByIP(peersStateDetail)
 
+	if groupByStatusFlag {
+		groupPeersByStatus(peersStateDetail)
+	}
+
 	peersOverview := peersStateOutput{
 		Total:     len(peersStateDetail),
 		Connected: peersConnected,
@@ -393,6 +1392,20 @@ func mapPeers(peers []*proto.PeerState) peersStateOutput {
 	return peersOverview
 }
 
+func mapTransportStats(stats *proto.TransportStats) *transportStatsOutput {
+	if stats == nil {
+		return nil
+	}
+	return &transportStatsOutput{
+		Protocol:        stats.GetProtocol(),
+		LocalPort:       stats.GetLocalPort(),
+		RemoteAddress:   stats.GetRemoteAddress(),
+		PacketsSent:     stats.GetPacketsSent(),
+		PacketsReceived: stats.GetPacketsReceived(),
+		Retransmits:     stats.GetRetransmits(),
+	}
+}
+
 func sortPeersByIP(peersStateDetail []peerStateDetailOutput) {
 	if len(peersStateDetail) > 0 {
 		sort.SliceStable(peersStateDetail, func(i, j int) bool {
@@ -403,6 +1416,104 @@ func sortPeersByIP(peersStateDetail []peerStateDetailOutput) {
 	}
 }
 
+// peersWithoutCipher returns the FQDNs of the connected peers whose cipher suite differs from the expected one
+func peersWithoutCipher(peers peersStateOutput, expected string) []string {
+	var mismatched []string
+	for _, peerState := range peers.Details {
+		if peerState.Status != peer.StatusConnected.String() {
+			continue
+		}
+		if !strings.EqualFold(peerState.CipherSuite, expected) {
+			mismatched = append(mismatched, peerState.FQDN)
+		}
+	}
+	return mismatched
+}
+
+// groupPeersByStatus assigns the connection status group to every peer and moves the connected peers first,
+// keeping the existing order within each group
+func groupPeersByStatus(peersStateDetail []peerStateDetailOutput) {
+	for i := range peersStateDetail {
+		peersStateDetail[i].Group = disconnectedGroup
+		if peersStateDetail[i].Status == peer.StatusConnected.String() {
+			peersStateDetail[i].Group = connectedGroup
+		}
+	}
+
+	sort.SliceStable(peersStateDetail, func(i, j int) bool {
+		return peersStateDetail[i].Group == connectedGroup && peersStateDetail[j].Group != connectedGroup
+	})
+}
+
+// topPeersByTransfer keeps the n peers with the most bytes sent and received, the most active first
+func topPeersByTransfer(peers peersStateOutput, n int) peersStateOutput {
+	details := make([]peerStateDetailOutput, len(peers.Details))
+	copy(details, peers.Details)
+	sort.SliceStable(details, func(i, j int) bool {
+		return details[i].TransferSent+details[i].TransferReceived > details[j].TransferSent+details[j].TransferReceived
+	})
+	if len(details) > n {
+		details = details[:n]
+	}
+
+	top := peersStateOutput{Total: len(details), Details: details}
+	for _, peerState := range details {
+		if peerState.Status == peer.StatusConnected.String() {
+			top.Connected++
+		}
+	}
+	return top
+}
+
+// peersByPublicKey returns the peer with the given public key. When no key is equal, it returns the peers whose key
+// starts with it, provided it has at least minPublicKeyPrefix characters
+func peersByPublicKey(peers peersStateOutput, publicKey string) peersStateOutput {
+	var matched []peerStateDetailOutput
+	for _, peerState := range peers.Details {
+		if peerState.PubKey == publicKey {
+			matched = []peerStateDetailOutput{peerState}
+			break
+		}
+		if len(publicKey) >= minPublicKeyPrefix && strings.HasPrefix(peerState.PubKey, publicKey) {
+			matched = append(matched, peerState)
+		}
+	}
+
+	selected := peersStateOutput{Total: len(matched), Details: matched}
+	for _, peerState := range matched {
+		if peerState.Status == peer.StatusConnected.String() {
+			selected.Connected++
+		}
+	}
+	return selected
+}
+
+func parsePeersIPList(peers peersStateOutput) string {
+	var ipList string
+	for _, peerState := range peers.Details {
+		ipList += fmt.Sprintf("%s\n", peerState.IP)
+	}
+	return ipList
+}
+
+// parsePeersFQDNList returns the peer FQDNs without the trailing dot, sorted lexicographically
+func parsePeersFQDNList(peers peersStateOutput) string {
+	fqdns := make([]string, 0, len(peers.Details))
+	for _, peerState := range peers.Details {
+		if peerState.FQDN == "" {
+			continue
+		}
+		fqdns = append(fqdns, strings.TrimSuffix(peerState.FQDN, "."))
+	}
+	sort.Strings(fqdns)
+
+	var fqdnList string
+	for _, fqdn := range fqdns {
+		fqdnList += fmt.Sprintf("%s\n", fqdn)
+	}
+	return fqdnList
+}
+
 func parseInterfaceIP(interfaceIP string) string {
 	ip, _, err := net.ParseCIDR(interfaceIP)
 	if err != nil {
@@ -411,6 +1522,14 @@ func parseInterfaceIP(interfaceIP string) string {
 	return fmt.Sprintf("%s\n", ip)
 }
 
+// parseFQDNOrInterfaceIP returns the FQDN of the local peer, or its NetBird IP when management didn't assign one
+func parseFQDNOrInterfaceIP(localPeer *proto.LocalPeerState) string {
+	if fqdn := strings.TrimSpace(localPeer.GetFqdn()); fqdn != "" {
+		return fqdn + "\n"
+	}
+	return parseInterfaceIP(localPeer.GetIP())
+}
+
 func parseToJSON(overview statusOutputOverview) (string, error) {
 	jsonBytes, err := json.Marshal(overview)
 	if err != nil {
@@ -419,6 +1538,33 @@ func parseToJSON(overview statusOutputOverview) (string, error) {
 	return string(jsonBytes), err
 }
 
+// parsePeersToJSON renders the peers as a json array
+func parsePeersToJSON(peers []peerStateDetailOutput) (string, error) {
+	jsonBytes, err := json.Marshal(peers)
+	if err != nil {
+		return "", fmt.Errorf("json marshal failed")
+	}
+	return string(jsonBytes), nil
+}
+
+// parsePeersToJSONLines renders every peer as a json object on its own line, in the order of the detailed output
+func parsePeersToJSONLines(peers peersStateOutput, noNewlineAtEnd bool) (string, error) {
+	var output strings.Builder
+	for _, peerState := range peers.Details {
+		jsonBytes, err := json.Marshal(peerState)
+		if err != nil {
+			return "", fmt.Errorf("json marshal failed")
+		}
+		output.Write(jsonBytes)
+		output.WriteString("\n")
+	}
+
+	if noNewlineAtEnd {
+		return strings.TrimSuffix(output.String(), "\n"), nil
+	}
+	return output.String(), nil
+}
+
 func parseToYAML(overview statusOutputOverview) (string, error) {
 	yamlBytes, err := yaml.Marshal(overview)
 	if err != nil {
@@ -472,7 +1618,7 @@ func parseGeneralSummary(overview statusOutputOverview, showURL bool, showRelays
 				available = "Unavailable"
 				reason = fmt.Sprintf(", reason: %s", relay.Error)
 			}
-			relaysString += fmt.Sprintf("\n  [%s] is %s%s", relay.URI, available, reason)
+			relaysString += fmt.Sprintf("\n  [%s] is %s%s", truncateValue(relay.URI), available, reason)
 		}
 	} else {
 		relaysString = fmt.Sprintf("%d/%d Available", overview.Relays.Available, overview.Relays.Total)
@@ -523,9 +1669,56 @@ func parseGeneralSummary(overview statusOutputOverview, showURL bool, showRelays
 
 	peersCountString := fmt.Sprintf("%d/%d Connected", overview.Peers.Connected, overview.Peers.Total)
 
+	var daemonUptimeString string
+	if overview.DaemonStartedAt != nil {
+		daemonUptimeString = fmt.Sprintf("Daemon uptime: %s\n", formatUptime(time.Since(*overview.DaemonStartedAt)))
+	}
+
+	if overview.DaemonProcessStats != nil {
+		daemonUptimeString += fmt.Sprintf("Daemon memory: %d MiB RSS, %d MiB heap, %d goroutines\n",
+			overview.DaemonProcessStats.RSSBytes/mebibyte,
+			overview.DaemonProcessStats.HeapInUseBytes/mebibyte,
+			overview.DaemonProcessStats.Goroutines,
+		)
+	}
+
+	if overview.DaemonPID > 0 {
+		daemonUptimeString += fmt.Sprintf("Daemon PID: %d\n", overview.DaemonPID)
+	}
+
+	if overview.DaemonConfigFile != nil {
+		configFile := *overview.DaemonConfigFile
+		if configFile == "" {
+			configFile = "(defaults, no file)"
+		}
+		daemonUptimeString += fmt.Sprintf("Config file: %s\n", configFile)
+	}
+
+	var osInfoString string
+	if overview.OSInfo != nil {
+		osInfoString = fmt.Sprintf(
+			"OS: %s\n"+
+				"Kernel: %s\n"+
+				"Arch: %s\n"+
+				"Total RAM: %d MB\n",
+			overview.OSInfo.OS,
+			overview.OSInfo.Kernel,
+			overview.OSInfo.Arch,
+			overview.OSInfo.TotalRAMMB,
+		)
+	}
+
+	fqdn := overview.FQDN
+	if colorConnectedIPFlag {
+		fqdn = highlight(fqdn)
+		interfaceIP = highlight(interfaceIP)
+	}
+
 	summary := fmt.Sprintf(
 		"Daemon version: %s\n"+
+			"%s"+
 			"CLI version: %s\n"+
+			"%s"+
 			"Management: %s\n"+
 			"Signal: %s\n"+
 			"Relays: %s\n"+
@@ -537,23 +1730,80 @@ func parseGeneralSummary(overview statusOutputOverview, showURL bool, showRelays
 			"Routes: %s\n"+
 			"Peers count: %s\n",
 		overview.DaemonVersion,
+		daemonUptimeString,
 		version.NetbirdVersion(),
+		osInfoString,
 		managementConnString,
 		signalConnString,
 		relaysString,
 		dnsServersString,
-		overview.FQDN,
+		fqdn,
 		interfaceIP,
 		interfaceTypeString,
 		rosenpassEnabledStatus,
 		routes,
 		peersCountString,
 	)
+
+	if overview.STUNCheck != nil {
+		summary += parseSTUNCheck(overview.STUNCheck)
+	}
+
+	if overview.InterfaceStats != nil {
+		summary += parseInterfaceStats(overview.InterfaceStats)
+	}
+
+	if overview.ClockSkew != nil {
+		summary += parseClockSkew(overview.ClockSkew)
+	}
 	return summary
 }
 
+// parsePeerOSInfo renders the OS info as, e.g., Ubuntu 22.04 LTS (Linux 5.15.0)
+func parsePeerOSInfo(osInfo *peerOSInfoOutput) string {
+	osString := strings.TrimSpace(osInfo.Name + " " + osInfo.Version)
+	if osString == "" {
+		return "unknown"
+	}
+	if osInfo.KernelVersion != "" {
+		osString += fmt.Sprintf(" (%s)", osInfo.KernelVersion)
+	}
+	return osString
+}
+
+func parseInterfaceStats(stats *interfaceStatsOutput) string {
+	state := "DOWN"
+	if stats.Up {
+		state = "UP"
+	}
+
+	return fmt.Sprintf(
+		"Interface stats:\n"+
+			"  Name: %s\n"+
+			"  State: %s\n"+
+			"  RX: %s, %d packets, %d errors\n"+
+			"  TX: %s, %d packets, %d errors\n",
+		stats.Name,
+		state,
+		toIEC(int64(stats.RxBytes)),
+		stats.RxPackets,
+		stats.RxErrors,
+		toIEC(int64(stats.TxBytes)),
+		stats.TxPackets,
+		stats.TxErrors,
+	)
+}
+
 func parseToFullDetailSummary(overview statusOutputOverview) string {
 	parsedPeersString := parsePeers(overview.Peers, overview.RosenpassEnabled, overview.RosenpassPermissive)
+	if maxLineLengthFlag > 0 {
+		parsedPeersString = wrapLongLines(parsedPeersString, maxLineLengthFlag)
+	}
+
+	if noSummaryFlag {
+		return strings.TrimPrefix(parsedPeersString, "\n")
+	}
+
 	summary := parseGeneralSummary(overview, true, true, true)
 
 	return fmt.Sprintf(
@@ -566,106 +1816,299 @@ func parseToFullDetailSummary(overview statusOutputOverview) string {
 }
 
 func parsePeers(peers peersStateOutput, rosenpassEnabled, rosenpassPermissive bool) string {
+	if groupByStatusFlag {
+		return parseGroupedPeers(peers, rosenpassEnabled, rosenpassPermissive)
+	}
+
+	if aggregateByRelayFlag {
+		return parseRelayGroupedPeers(peers, rosenpassEnabled, rosenpassPermissive)
+	}
+
 	var (
 		peersString = ""
 	)
 
 	for _, peerState := range peers.Details {
+		peersString += parsePeer(peerState, rosenpassEnabled, rosenpassPermissive)
+	}
+	return peersString
+}
 
-		localICE := "-"
-		if peerState.IceCandidateType.Local != "" {
-			localICE = peerState.IceCandidateType.Local
+// parseGroupedPeers renders the connected peers and the disconnected peers in two groups with a header each
+func parseGroupedPeers(peers peersStateOutput, rosenpassEnabled, rosenpassPermissive bool) string {
+	var connectedString, disconnectedString string
+	var connected, disconnected int
+	for _, peerState := range peers.Details {
+		if peerState.Status == peer.StatusConnected.String() {
+			connected++
+			connectedString += parsePeer(peerState, rosenpassEnabled, rosenpassPermissive)
+			continue
 		}
+		disconnected++
+		disconnectedString += parsePeer(peerState, rosenpassEnabled, rosenpassPermissive)
+	}
 
-		remoteICE := "-"
-		if peerState.IceCandidateType.Remote != "" {
-			remoteICE = peerState.IceCandidateType.Remote
-		}
+	return fmt.Sprintf(
+		"\n--- %s (%d) ---%s\n"+
+			"\n--- %s (%d) ---%s",
+		connectedGroup,
+		connected,
+		connectedString,
+		disconnectedGroup,
+		disconnected,
+		disconnectedString,
+	)
+}
 
-		localICEEndpoint := "-"
-		if peerState.IceCandidateEndpoint.Local != "" {
-			localICEEndpoint = peerState.IceCandidateEndpoint.Local
-		}
+func parsePeer(peerState peerStateDetailOutput, rosenpassEnabled, rosenpassPermissive bool) string {
+	localICE := "-"
+	if peerState.IceCandidateType.Local != "" {
+		localICE = peerState.IceCandidateType.Local
+	}
+
+	remoteICE := "-"
+	if peerState.IceCandidateType.Remote != "" {
+		remoteICE = peerState.IceCandidateType.Remote
+	}
+
+	localICEEndpoint := "-"
+	if peerState.IceCandidateEndpoint.Local != "" {
+		localICEEndpoint = peerState.IceCandidateEndpoint.Local
+	}
+
+	remoteICEEndpoint := "-"
+	if peerState.IceCandidateEndpoint.Remote != "" {
+		remoteICEEndpoint = peerState.IceCandidateEndpoint.Remote
+	}
+	status := peerState.Status
+	if peerState.ICMPUnreachable {
+		status = icmpUnreachableStatus
+	}
+
+	lastStatusUpdate := "-"
+	if !peerState.LastStatusUpdate.IsZero() {
+		lastStatusUpdate = formatTimestamp(peerState.LastStatusUpdate)
+	}
+
+	lastWireGuardHandshake := "-"
+	if !peerState.LastWireguardHandshake.IsZero() && peerState.LastWireguardHandshake != time.Unix(0, 0) {
+		lastWireGuardHandshake = formatTimestamp(peerState.LastWireguardHandshake)
+	}
 
-		remoteICEEndpoint := "-"
-		if peerState.IceCandidateEndpoint.Remote != "" {
-			remoteICEEndpoint = peerState.IceCandidateEndpoint.Remote
+	rosenpassEnabledStatus := "false"
+	if rosenpassEnabled {
+		if peerState.RosenpassEnabled {
+			rosenpassEnabledStatus = "true"
+		} else {
+			if rosenpassPermissive {
+				rosenpassEnabledStatus = "false (remote didn't enable quantum resistance)"
+			} else {
+				rosenpassEnabledStatus = "false (connection won't work without a permissive mode)"
+			}
 		}
-		lastStatusUpdate := "-"
-		if !peerState.LastStatusUpdate.IsZero() {
-			lastStatusUpdate = peerState.LastStatusUpdate.Format("2006-01-02 15:04:05")
+	} else {
+		if peerState.RosenpassEnabled {
+			rosenpassEnabledStatus = "false (connection might not work without a remote permissive mode)"
 		}
+	}
+
+	routes := "-"
+	if len(peerState.Routes) > 0 {
+		sort.Strings(peerState.Routes)
+		routes = strings.Join(peerState.Routes, ", ")
+	}
+
+	peerLabel := peerState.FQDN
+	if colorByLatencyFlag {
+		peerLabel = colorize(peerLabel, latencyColor(peerState))
+	}
+	if peerState.ActiveExitNode {
+		peerLabel += " [ACTIVE]"
+	}
+
+	peerString := fmt.Sprintf(
+		"\n %s:\n"+
+			"  NetBird IP: %s\n"+
+			"  Public key: %s\n"+
+			"  Status: %s\n"+
+			"  -- detail --\n"+
+			"  Connection type: %s\n"+
+			"  Direct: %t\n"+
+			"  ICE candidate (Local/Remote): %s/%s\n"+
+			"  ICE candidate endpoints (Local/Remote): %s/%s\n"+
+			"  Last connection update: %s\n"+
+			"  Last WireGuard handshake: %s\n"+
+			"  Transfer status (received/sent) %s/%s\n"+
+			"  Quantum resistance: %s\n"+
+			"  Routes: %s\n",
+		peerLabel,
+		peerState.IP,
+		truncateValue(peerState.PubKey),
+		status,
+		peerState.ConnType,
+		peerState.Direct,
+		localICE,
+		remoteICE,
+		localICEEndpoint,
+		remoteICEEndpoint,
+		lastStatusUpdate,
+		lastWireGuardHandshake,
+		toIEC(peerState.TransferReceived),
+		toIEC(peerState.TransferSent),
+		rosenpassEnabledStatus,
+		routes,
+	)
 
-		lastWireGuardHandshake := "-"
-		if !peerState.LastWireguardHandshake.IsZero() && peerState.LastWireguardHandshake != time.Unix(0, 0) {
-			lastWireGuardHandshake = peerState.LastWireguardHandshake.Format("2006-01-02 15:04:05")
+	if peerState.Latency > 0 {
+		peerString += fmt.Sprintf("  Latency: %s\n", peerState.Latency.String())
+	}
+
+	if peerState.TCPTransport {
+		peerString += "  Transport: TCP\n"
+	}
+
+	if peerState.AdvertisedRoutes != nil {
+		advertisedRoutes := "none"
+		if len(peerState.AdvertisedRoutes) > 0 {
+			sort.Strings(peerState.AdvertisedRoutes)
+			advertisedRoutes = strings.Join(peerState.AdvertisedRoutes, ", ")
 		}
+		peerString += fmt.Sprintf("  Advertised routes: %s\n", advertisedRoutes)
+	}
 
-		rosenpassEnabledStatus := "false"
-		if rosenpassEnabled {
-			if peerState.RosenpassEnabled {
-				rosenpassEnabledStatus = "true"
-			} else {
-				if rosenpassPermissive {
-					rosenpassEnabledStatus = "false (remote didn't enable quantum resistance)"
-				} else {
-					rosenpassEnabledStatus = "false (connection won't work without a permissive mode)"
-				}
-			}
-		} else {
-			if peerState.RosenpassEnabled {
-				rosenpassEnabledStatus = "false (connection might not work without a remote permissive mode)"
-			}
+	if peerState.AllowedIPs != nil {
+		allowedIPs := "none"
+		if len(peerState.AllowedIPs) > 0 {
+			allowedIPs = strings.Join(peerState.AllowedIPs, ", ")
 		}
+		peerString += fmt.Sprintf("  Allowed IPs: %s\n", allowedIPs)
+	}
+
+	if peerState.OSInfo != nil {
+		peerString += fmt.Sprintf("  OS: %s\n", parsePeerOSInfo(peerState.OSInfo))
+	}
+
+	if len(peerState.AllowedIPsOverride) > 0 {
+		peerString += fmt.Sprintf("  Allowed IPs override: %s\n", strings.Join(peerState.AllowedIPsOverride, ", "))
+	}
+
+	if peerState.Via != "" {
+		peerString += fmt.Sprintf("  Via: %s\n", peerState.Via)
+	}
 
-		routes := "-"
-		if len(peerState.Routes) > 0 {
-			sort.Strings(peerState.Routes)
-			routes = strings.Join(peerState.Routes, ", ")
-		}
-
-		peerString := fmt.Sprintf(
-			"\n %s:\n"+
-				"  NetBird IP: %s\n"+
-				"  Public key: %s\n"+
-				"  Status: %s\n"+
-				"  -- detail --\n"+
-				"  Connection type: %s\n"+
-				"  Direct: %t\n"+
-				"  ICE candidate (Local/Remote): %s/%s\n"+
-				"  ICE candidate endpoints (Local/Remote): %s/%s\n"+
-				"  Last connection update: %s\n"+
-				"  Last WireGuard handshake: %s\n"+
-				"  Transfer status (received/sent) %s/%s\n"+
-				"  Quantum resistance: %s\n"+
-				"  Routes: %s\n",
-			peerState.FQDN,
-			peerState.IP,
-			peerState.PubKey,
-			peerState.Status,
-			peerState.ConnType,
-			peerState.Direct,
-			localICE,
-			remoteICE,
-			localICEEndpoint,
-			remoteICEEndpoint,
-			lastStatusUpdate,
-			lastWireGuardHandshake,
-			toIEC(peerState.TransferReceived),
-			toIEC(peerState.TransferSent),
-			rosenpassEnabledStatus,
-			routes,
+	if peerState.CipherSuite != "" {
+		peerString += fmt.Sprintf("  Cipher: %s\n", peerState.CipherSuite)
+	}
+
+	if peerState.Transport != nil {
+		peerString += fmt.Sprintf(
+			"  -- transport --\n"+
+				"  Protocol: %s\n"+
+				"  Local port: %d\n"+
+				"  Remote address: %s\n"+
+				"  Packets (received/sent): %d/%d\n"+
+				"  Retransmits: %d\n",
+			peerState.Transport.Protocol,
+			peerState.Transport.LocalPort,
+			peerState.Transport.RemoteAddress,
+			peerState.Transport.PacketsReceived,
+			peerState.Transport.PacketsSent,
+			peerState.Transport.Retransmits,
 		)
+	}
+
+	return peerString
+}
 
-		peersString += peerString
+// wrapLongLines wraps every line longer than maxLen. Continuation lines are indented two spaces
+// deeper than the original line and long values without spaces, like public keys, are split
+func wrapLongLines(text string, maxLen int) string {
+	lines := strings.Split(text, "\n")
+	wrapped := make([]string, 0, len(lines))
+	for _, line := range lines {
+		wrapped = append(wrapped, wrapLine(line, maxLen)...)
 	}
-	return peersString
+	return strings.Join(wrapped, "\n")
+}
+
+func wrapLine(line string, maxLen int) []string {
+	if len(line) <= maxLen {
+		return []string{line}
+	}
+
+	indent := line[:len(line)-len(strings.TrimLeft(line, " "))]
+	continuationIndent := indent + "  "
+
+	// keep the label on the first line and move as much of the value as fits next to it
+	labelEnd := len(indent)
+	if idx := strings.Index(line, ": "); idx >= 0 {
+		labelEnd = idx + 2
+	}
+	if labelEnd >= maxLen {
+		labelEnd = len(indent)
+	}
+
+	result := make([]string, 0)
+	prefix := line[:labelEnd]
+	rest := line[labelEnd:]
+	for rest != "" {
+		width := maxLen - len(prefix)
+		if width < 1 {
+			width = 1
+		}
+		if len(rest) <= width {
+			result = append(result, prefix+rest)
+			break
+		}
+
+		cut := width
+		if idx := strings.LastIndex(rest[:width+1], " "); idx > 0 {
+			cut = idx
+		}
+		result = append(result, strings.TrimRight(prefix+rest[:cut], " "))
+		rest = strings.TrimLeft(rest[cut:], " ")
+		prefix = continuationIndent
+	}
+	return result
+}
+
+// parseDayDuration parses a duration that, besides the time.ParseDuration units, accepts a number of days, e.g., 7d
+func parseDayDuration(value string) (time.Duration, error) {
+	if days, found := strings.CutSuffix(value, "d"); found {
+		count, err := strconv.Atoi(days)
+		if err != nil || count < 0 {
+			return 0, fmt.Errorf("invalid number of days %s", value)
+		}
+		return time.Duration(count) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// hasPeerFilters reports whether any filter that can exclude peers from the output is set
+func hasPeerFilters() bool {
+	return statusFilter != "" ||
+		len(ipsFilter) > 0 ||
+		len(networksFilter) > 0 ||
+		len(excludePeersMap) > 0 ||
+		len(prefixNamesFilter) > 0 ||
+		len(peersInGroupFilter) > 0 ||
+		!peersChangedSince.IsZero() ||
+		sinceVersionFlag > 0 ||
+		!notSeenBefore.IsZero() ||
+		!connectedBefore.IsZero() ||
+		peersDirectOnlyFlag ||
+		peersGatewayFlag ||
+		peersExitNodeFlag
 }
 
 func skipDetailByFilters(peerState *proto.PeerState, isConnected bool) bool {
 	statusEval := false
 	ipEval := false
 	nameEval := false
+	changedEval := false
+	groupEval := false
+	directEval := false
+	routingEval := false
 
 	if statusFilter != "" {
 		lowerStatusFilter := strings.ToLower(statusFilter)
@@ -683,6 +2126,14 @@ func skipDetailByFilters(peerState *proto.PeerState, isConnected bool) bool {
 		}
 	}
 
+	if len(networksFilterPrefix) > 0 && !inNetworks(peerState.IP, networksFilterPrefix) {
+		ipEval = true
+	}
+
+	if len(excludePeersMap) > 0 && isExcludedPeer(peerState) {
+		nameEval = true
+	}
+
 	if len(prefixNamesFilter) > 0 {
 		for prefixNameFilter := range prefixNamesFilterMap {
 			if !strings.HasPrefix(peerState.Fqdn, prefixNameFilter) {
@@ -692,7 +2143,116 @@ func skipDetailByFilters(peerState *proto.PeerState, isConnected bool) bool {
 		}
 	}
 
-	return statusEval || ipEval || nameEval
+	if !peersChangedSince.IsZero() && !peerState.GetConnStatusUpdate().AsTime().After(peersChangedSince) {
+		changedEval = true
+	}
+
+	if sinceVersionFlag > 0 && peerState.GetVersion() <= sinceVersionFlag {
+		changedEval = true
+	}
+
+	if !notSeenBefore.IsZero() && !peerState.GetConnStatusUpdate().AsTime().Before(notSeenBefore) {
+		changedEval = true
+	}
+
+	if !connectedBefore.IsZero() && (!isConnected || !peerState.GetConnStatusUpdate().AsTime().Before(connectedBefore)) {
+		changedEval = true
+	}
+
+	if len(peersInGroupFilter) > 0 {
+		if _, ok := groupMembersMap[peerState.GetFqdn()]; !ok {
+			groupEval = true
+		}
+	}
+
+	if peersDirectOnlyFlag && (!isConnected || peerState.GetRelayed()) {
+		directEval = true
+	}
+
+	if peersGatewayFlag && len(peerState.GetAdvertisedRoutes()) == 0 {
+		routingEval = true
+	}
+
+	if peersExitNodeFlag && !hasExitNodeRoute(peerState.GetAdvertisedRoutes()) {
+		routingEval = true
+	}
+
+	return statusEval || ipEval || nameEval || changedEval || groupEval || directEval || routingEval
+}
+
+// isExcludedPeer reports whether the FQDN, hostname or NetBird IP of the peer is in --exclude-peers
+func isExcludedPeer(peerState *proto.PeerState) bool {
+	fqdn := strings.ToLower(peerState.GetFqdn())
+	for _, identifier := range []string{fqdn, strings.Split(fqdn, ".")[0], strings.Split(peerState.GetIP(), "/")[0]} {
+		if _, ok := excludePeersMap[identifier]; ok && identifier != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// inNetworks reports whether the IP is in any of the networks
+func inNetworks(ip string, networks []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	for _, network := range networks {
+		if network.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatTimestamp renders a timestamp of the human-readable output in the --time-format
+func formatTimestamp(t time.Time) string {
+	switch timeFormatFlag {
+	case rfc3339TimeFormat:
+		return t.Format(time.RFC3339)
+	case unixTimeFormat:
+		return strconv.FormatInt(t.Unix(), 10)
+	case relativeTimeFormat:
+		d := time.Since(t)
+		if d < 0 {
+			return fmt.Sprintf("in %s", formatUptime(-d))
+		}
+		return fmt.Sprintf("%s ago", formatUptime(d))
+	case "":
+		return t.Format(defaultTimeFormat)
+	default:
+		return t.Format(timeFormatFlag)
+	}
+}
+
+// formatUptime renders a duration as days, hours and minutes, e.g., 3d 2h 14m
+func formatUptime(d time.Duration) string {
+	days := int64(d / (24 * time.Hour))
+	hours := int64(d/time.Hour) % 24
+	minutes := int64(d/time.Minute) % 60
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh %dm", days, hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+// truncateValue shortens long values of the human-readable output to --truncate-at characters, ending them with an ellipsis.
+// Nothing is truncated with --no-truncate or without --truncate-at
+func truncateValue(value string) string {
+	if noTruncate || truncateAt <= 0 {
+		return value
+	}
+
+	runes := []rune(value)
+	if len(runes) <= truncateAt {
+		return value
+	}
+	return string(runes[:truncateAt-1]) + "…"
 }
 
 func toIEC(b int64) string {
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+// Exit codes of --alive-check, 0 means the peer is connected and answers pings
+const (
+	aliveCheckDisconnectedExitCode = 1
+	aliveCheckUnreachableExitCode  = 2
+	aliveCheckNotFoundExitCode     = 3
+)
+
+// checkPeerAlive checks that the named peer is connected and answers a ping over the tunnel within the timeout.
+// It returns a one-line diagnostic and an ExitCodeError telling the failure apart
+func checkPeerAlive(ctx context.Context, peers []peerStateDetailOutput, name string, ping pingFunc, timeout time.Duration) (string, error) {
+	selected, err := selectPeersByName(peers, []string{name})
+	if err != nil {
+		return fmt.Sprintf("%s: not found in the peer list\n", name), &ExitCodeError{
+			Code: aliveCheckNotFoundExitCode,
+			Err:  fmt.Errorf("peer %s not found", name),
+		}
+	}
+
+	peerState := selected[0]
+	if peerState.Status != peer.StatusConnected.String() {
+		return fmt.Sprintf("%s: %s\n", peerState.FQDN, strings.ToLower(peerState.Status)), &ExitCodeError{
+			Code: aliveCheckDisconnectedExitCode,
+			Err:  fmt.Errorf("peer %s is not connected", peerState.FQDN),
+		}
+	}
+
+	addr, err := netip.ParseAddr(strings.Split(peerState.IP, "/")[0])
+	if err != nil {
+		return fmt.Sprintf("%s: connected, invalid IP %s\n", peerState.FQDN, peerState.IP), &ExitCodeError{
+			Code: aliveCheckUnreachableExitCode,
+			Err:  fmt.Errorf("peer %s has an invalid IP: %v", peerState.FQDN, err),
+		}
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	rtt, err := ping(pingCtx, addr)
+	if err != nil {
+		return fmt.Sprintf("%s: connected but unreachable over ICMP at %s\n", peerState.FQDN, addr), &ExitCodeError{
+			Code: aliveCheckUnreachableExitCode,
+			Err:  fmt.Errorf("peer %s is unreachable over ICMP: %v", peerState.FQDN, err),
+		}
+	}
+
+	return fmt.Sprintf("%s: alive, ICMP reply from %s in %s\n", peerState.FQDN, addr, rtt.Round(time.Microsecond)), nil
+}
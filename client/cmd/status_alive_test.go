@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPeerAlive(t *testing.T) {
+	peers := []peerStateDetailOutput{
+		{FQDN: "peer-1.awesome-domain.com", IP: "100.64.0.1", Status: "Connected"},
+		{FQDN: "peer-2.awesome-domain.com", IP: "100.64.0.2", Status: "Idle"},
+		{FQDN: "peer-3.awesome-domain.com", IP: "100.64.0.3", Status: "Connected"},
+	}
+	ping := func(_ context.Context, addr netip.Addr) (time.Duration, error) {
+		if addr == netip.MustParseAddr("100.64.0.3") {
+			return 0, errors.New("timeout")
+		}
+		return 2 * time.Millisecond, nil
+	}
+
+	tests := []struct {
+		name           string
+		peer           string
+		expectedOutput string
+		expectedCode   int
+	}{
+		{
+			name:           "alive",
+			peer:           "peer-1",
+			expectedOutput: "peer-1.awesome-domain.com: alive, ICMP reply from 100.64.0.1 in 2ms\n",
+		},
+		{
+			name:           "disconnected",
+			peer:           "peer-2.awesome-domain.com",
+			expectedOutput: "peer-2.awesome-domain.com: idle\n",
+			expectedCode:   aliveCheckDisconnectedExitCode,
+		},
+		{
+			name:           "unreachable",
+			peer:           "peer-3",
+			expectedOutput: "peer-3.awesome-domain.com: connected but unreachable over ICMP at 100.64.0.3\n",
+			expectedCode:   aliveCheckUnreachableExitCode,
+		},
+		{
+			name:           "not found",
+			peer:           "peer-4",
+			expectedOutput: "peer-4: not found in the peer list\n",
+			expectedCode:   aliveCheckNotFoundExitCode,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			output, err := checkPeerAlive(context.Background(), peers, tc.peer, ping, icmpReachabilityTimeout)
+			assert.Equal(t, tc.expectedOutput, output)
+			if tc.expectedCode == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			var exitErr *ExitCodeError
+			require.ErrorAs(t, err, &exitErr)
+			assert.Equal(t, tc.expectedCode, exitErr.Code)
+		})
+	}
+}
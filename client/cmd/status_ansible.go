@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+const defaultAnsibleFactName = "netbird"
+
+// ansibleFactNameRegex matches the names Ansible accepts as variable names
+var ansibleFactNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type ansiblePeerFact struct {
+	FQDN      string `json:"fqdn"`
+	IP        string `json:"ip"`
+	Status    string `json:"status"`
+	ConnType  string `json:"conn_type"`
+	LatencyMs int64  `json:"latency_ms"`
+}
+
+type ansibleFacts struct {
+	FQDN                string            `json:"fqdn"`
+	IP                  string            `json:"ip"`
+	ManagementConnected bool              `json:"management_connected"`
+	SignalConnected     bool              `json:"signal_connected"`
+	PeersConnected      int               `json:"peers_connected"`
+	PeersTotal          int               `json:"peers_total"`
+	Peers               []ansiblePeerFact `json:"peers"`
+}
+
+func parseAnsibleFactName() error {
+	if !ansibleFactNameRegex.MatchString(ansibleFactName) {
+		return fmt.Errorf("wrong Ansible fact name, should be a valid variable name, got: %s", ansibleFactName)
+	}
+	return nil
+}
+
+// parseToAnsibleFacts renders the status as Ansible custom facts under the given name. Ansible reads the facts of
+// non-executable files in facts.d as JSON, they show up as ansible_local.<name> in playbooks
+func parseToAnsibleFacts(overview statusOutputOverview, name string) (string, error) {
+	facts := ansibleFacts{
+		FQDN:                overview.FQDN,
+		IP:                  overview.IP,
+		ManagementConnected: overview.ManagementState.Connected,
+		SignalConnected:     overview.SignalState.Connected,
+		PeersConnected:      overview.Peers.Connected,
+		PeersTotal:          overview.Peers.Total,
+		Peers:               make([]ansiblePeerFact, 0, len(overview.Peers.Details)),
+	}
+	for _, peerState := range overview.Peers.Details {
+		facts.Peers = append(facts.Peers, ansiblePeerFact{
+			FQDN:      peerState.FQDN,
+			IP:        peerState.IP,
+			Status:    peerState.Status,
+			ConnType:  peerState.ConnType,
+			LatencyMs: peerState.Latency.Milliseconds(),
+		})
+	}
+
+	jsonBytes, err := json.MarshalIndent(map[string]ansibleFacts{name: facts}, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("json marshal failed")
+	}
+	return string(jsonBytes) + "\n", nil
+}
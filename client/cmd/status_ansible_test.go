@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsingToAnsibleFacts(t *testing.T) {
+	ansibleOverview := overview
+	ansibleOverview.Peers = peersStateOutput{
+		Total:     1,
+		Connected: 1,
+		Details: []peerStateDetailOutput{
+			{FQDN: "peer-1.awesome-domain.com", IP: "192.168.178.101", Status: "Connected", ConnType: "P2P", Latency: 10 * time.Millisecond},
+		},
+	}
+
+	facts, err := parseToAnsibleFacts(ansibleOverview, "vpn")
+	require.NoError(t, err)
+
+	expected := `{
+  "vpn": {
+    "fqdn": "some-localhost.awesome-domain.com",
+    "ip": "192.168.178.100/16",
+    "management_connected": true,
+    "signal_connected": true,
+    "peers_connected": 1,
+    "peers_total": 1,
+    "peers": [
+      {
+        "fqdn": "peer-1.awesome-domain.com",
+        "ip": "192.168.178.101",
+        "status": "Connected",
+        "conn_type": "P2P",
+        "latency_ms": 10
+      }
+    ]
+  }
+}
+`
+	assert.Equal(t, expected, facts)
+	assert.True(t, json.Valid([]byte(facts)))
+}
+
+func TestAnsibleFactName(t *testing.T) {
+	t.Cleanup(func() {
+		ansibleFactName = defaultAnsibleFactName
+	})
+
+	for _, name := range []string{"netbird", "_vpn", "netbird_2"} {
+		ansibleFactName = name
+		assert.NoError(t, parseAnsibleFactName(), name)
+	}
+	for _, name := range []string{"", "2vpn", "net-bird", "net.bird"} {
+		ansibleFactName = name
+		assert.Error(t, parseAnsibleFactName(), name)
+	}
+}
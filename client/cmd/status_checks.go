@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+const (
+	checkPassedMark = "✓"
+	checkFailedMark = "✗"
+)
+
+// healthCheck is the result of a single --check-all check. ExitCode is used when it is the first failing check
+type healthCheck struct {
+	Name     string `json:"name" yaml:"name"`
+	Pass     bool   `json:"pass" yaml:"pass"`
+	Message  string `json:"message,omitempty" yaml:"message,omitempty"`
+	ExitCode int    `json:"-" yaml:"-"`
+}
+
+type healthChecksOutput struct {
+	Checks []healthCheck `json:"checks" yaml:"checks"`
+}
+
+// serviceCheckOutput is the --check-signal-only and --check-management-only json output
+type serviceCheckOutput struct {
+	Connected bool   `json:"connected" yaml:"connected"`
+	URL       string `json:"url" yaml:"url"`
+	Error     string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// runHealthChecks runs the --check-all checks in the order their failures take precedence
+func runHealthChecks(overview statusOutputOverview) []healthCheck {
+	var connecting []string
+	for _, peerState := range overview.Peers.Details {
+		if peerState.Status == peer.StatusConnecting.String() {
+			connecting = append(connecting, peerState.FQDN)
+		}
+	}
+
+	checks := []healthCheck{
+		{
+			Name:     "management",
+			Pass:     overview.ManagementState.Connected,
+			Message:  overview.ManagementState.Error,
+			ExitCode: 2,
+		},
+		{
+			Name:     "signal",
+			Pass:     overview.SignalState.Connected,
+			Message:  overview.SignalState.Error,
+			ExitCode: 3,
+		},
+		{
+			Name:     "peers",
+			Pass:     overview.Peers.Connected > 0,
+			Message:  fmt.Sprintf("%d/%d peers connected", overview.Peers.Connected, overview.Peers.Total),
+			ExitCode: 4,
+		},
+		{
+			Name:     "peers-warnings",
+			Pass:     len(connecting) == 0,
+			ExitCode: 5,
+		},
+		{
+			Name:     "version",
+			Pass:     overview.CliVersion == overview.DaemonVersion,
+			Message:  fmt.Sprintf("CLI %s, daemon %s", overview.CliVersion, overview.DaemonVersion),
+			ExitCode: 6,
+		},
+	}
+
+	if len(connecting) > 0 {
+		checks[3].Message = fmt.Sprintf("peers stuck connecting: %s", strings.Join(connecting, ", "))
+	}
+
+	if overview.ClockSkew != nil {
+		checks = append(checks, healthCheck{
+			Name:     "clock-skew",
+			Pass:     !overview.ClockSkew.exceeded(),
+			Message:  fmt.Sprintf("%+ds", int64(overview.ClockSkew.Skew/time.Second)),
+			ExitCode: 1,
+		})
+	}
+
+	return checks
+}
+
+// interfaceChecksFromProto converts the daemon interface checks, every failing check exits with 1
+func interfaceChecksFromProto(checks []*proto.InterfaceCheck) []healthCheck {
+	converted := make([]healthCheck, 0, len(checks))
+	for _, check := range checks {
+		converted = append(converted, healthCheck{
+			Name:     check.GetName(),
+			Pass:     check.GetPass(),
+			Message:  check.GetMessage(),
+			ExitCode: 1,
+		})
+	}
+	return converted
+}
+
+// failedHealthCheckErr returns an error with the exit code of the first failing check, nil when all checks passed
+func failedHealthCheckErr(checks []healthCheck) error {
+	for _, check := range checks {
+		if !check.Pass {
+			return &ExitCodeError{
+				Code: check.ExitCode,
+				Err:  fmt.Errorf("%s check failed", check.Name),
+			}
+		}
+	}
+	return nil
+}
+
+func parseHealthChecksToJSON(checks []healthCheck) (string, error) {
+	jsonBytes, err := json.Marshal(healthChecksOutput{Checks: checks})
+	if err != nil {
+		return "", fmt.Errorf("json marshal failed")
+	}
+	return string(jsonBytes), nil
+}
+
+// parseHealthChecks renders a check mark or a cross for every check
+func parseHealthChecks(checks []healthCheck) string {
+	var output strings.Builder
+	for _, check := range checks {
+		mark := checkPassedMark
+		if !check.Pass {
+			mark = checkFailedMark
+		}
+
+		output.WriteString(fmt.Sprintf("%s %s", mark, check.Name))
+		if check.Message != "" {
+			output.WriteString(fmt.Sprintf(": %s", check.Message))
+		}
+		output.WriteString("\n")
+	}
+	return output.String()
+}
+
+// parseServiceCheck renders the connection state of a single service as one line or as json
+func parseServiceCheck(service, url string, connected bool, connErr string, asJSON bool) (string, error) {
+	if asJSON {
+		jsonBytes, err := json.Marshal(serviceCheckOutput{Connected: connected, URL: url, Error: connErr})
+		if err != nil {
+			return "", fmt.Errorf("json marshal failed")
+		}
+		return string(jsonBytes) + "\n", nil
+	}
+
+	if connected {
+		return fmt.Sprintf("%s: Connected to %s\n", service, url), nil
+	}
+
+	line := fmt.Sprintf("%s: Disconnected from %s", service, url)
+	if connErr != "" {
+		line += fmt.Sprintf(", reason: %s", connErr)
+	}
+	return line + "\n", nil
+}
+
+// failedServiceCheckErr returns an error exiting with 1 when the service isn't connected
+func failedServiceCheckErr(service string, connected bool) error {
+	if connected {
+		return nil
+	}
+	return fmt.Errorf("%s is not connected", strings.ToLower(service))
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+func TestHealthChecks(t *testing.T) {
+	healthy := statusOutputOverview{
+		Peers: peersStateOutput{
+			Total:     2,
+			Connected: 1,
+			Details: []peerStateDetailOutput{
+				{FQDN: "peer-1.netbird.cloud", Status: "Connected"},
+				{FQDN: "peer-2.netbird.cloud", Status: "Idle"},
+			},
+		},
+		ManagementState: managementStateOutput{Connected: true},
+		SignalState:     signalStateOutput{Connected: true},
+		CliVersion:      "0.27.0",
+		DaemonVersion:   "0.27.0",
+	}
+
+	checks := runHealthChecks(healthy)
+	require.Len(t, checks, 5)
+	assert.NoError(t, failedHealthCheckErr(checks))
+
+	testCases := []struct {
+		name         string
+		modify       func(overview *statusOutputOverview)
+		expectedCode int
+	}{
+		{
+			name:         "management disconnected",
+			modify:       func(o *statusOutputOverview) { o.ManagementState.Connected = false; o.SignalState.Connected = false },
+			expectedCode: 2,
+		},
+		{
+			name:         "signal disconnected",
+			modify:       func(o *statusOutputOverview) { o.SignalState.Connected = false },
+			expectedCode: 3,
+		},
+		{
+			name:         "no peer connected",
+			modify:       func(o *statusOutputOverview) { o.Peers.Connected = 0 },
+			expectedCode: 4,
+		},
+		{
+			name: "peer stuck connecting",
+			modify: func(o *statusOutputOverview) {
+				o.Peers.Details = []peerStateDetailOutput{{FQDN: "peer-1.netbird.cloud", Status: "Connecting"}}
+			},
+			expectedCode: 5,
+		},
+		{
+			name:         "version mismatch",
+			modify:       func(o *statusOutputOverview) { o.DaemonVersion = "0.26.0" },
+			expectedCode: 6,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			overview := healthy
+			tc.modify(&overview)
+			err := failedHealthCheckErr(runHealthChecks(overview))
+			require.Error(t, err)
+			assert.Equal(t, tc.expectedCode, ExitCode(err))
+		})
+	}
+}
+
+func TestParsingHealthChecks(t *testing.T) {
+	checks := []healthCheck{
+		{Name: "management", Pass: true, ExitCode: 2},
+		{Name: "signal", Pass: false, Message: "connection refused", ExitCode: 3},
+	}
+
+	jsonString, err := parseHealthChecksToJSON(checks)
+	require.NoError(t, err)
+	assert.Equal(t, `{"checks":[{"name":"management","pass":true},{"name":"signal","pass":false,"message":"connection refused"}]}`, jsonString)
+
+	expected := "✓ management\n" +
+		"✗ signal: connection refused\n"
+	assert.Equal(t, expected, parseHealthChecks(checks))
+}
+
+func TestParseServiceCheck(t *testing.T) {
+	output, err := parseServiceCheck("Signal", "https://signal.netbird.io:443", true, "", false)
+	require.NoError(t, err)
+	assert.Equal(t, "Signal: Connected to https://signal.netbird.io:443\n", output)
+	assert.NoError(t, failedServiceCheckErr("Signal", true))
+
+	output, err = parseServiceCheck("Management", "https://api.netbird.io:443", false, "connection refused", false)
+	require.NoError(t, err)
+	assert.Equal(t, "Management: Disconnected from https://api.netbird.io:443, reason: connection refused\n", output)
+
+	checkErr := failedServiceCheckErr("Management", false)
+	assert.EqualError(t, checkErr, "management is not connected")
+	assert.Equal(t, 1, ExitCode(checkErr))
+
+	output, err = parseServiceCheck("Signal", "https://signal.netbird.io:443", true, "", true)
+	require.NoError(t, err)
+	assert.Equal(t, `{"connected":true,"url":"https://signal.netbird.io:443"}`+"\n", output)
+}
+
+func TestInterfaceChecksFromProto(t *testing.T) {
+	checks := interfaceChecksFromProto([]*proto.InterfaceCheck{
+		{Name: "exists", Pass: true},
+		{Name: "ip", Message: "expected 100.64.0.1/16, found []"},
+	})
+
+	assert.Equal(t, "✓ exists\n✗ ip: expected 100.64.0.1/16, found []\n", parseHealthChecks(checks))
+
+	checkErr := failedHealthCheckErr(checks)
+	assert.EqualError(t, checkErr, "ip check failed")
+	assert.Equal(t, 1, ExitCode(checkErr))
+}
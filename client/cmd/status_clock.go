@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// clockSkewThreshold is well below the 3 minutes of skew that break WireGuard handshakes
+	clockSkewThreshold = 60 * time.Second
+	clockSkewTimeout   = 5 * time.Second
+)
+
+type clockSkewOutput struct {
+	Server string        `json:"server" yaml:"server"`
+	Skew   time.Duration `json:"skew" yaml:"skew"`
+}
+
+// exceeded reports whether the skew is above the threshold in either direction
+func (c *clockSkewOutput) exceeded() bool {
+	return c.Skew > clockSkewThreshold || c.Skew < -clockSkewThreshold
+}
+
+// checkClockSkew compares the local time with the Date header of the management server response. The local time is
+// taken in the middle of the request, a positive skew means the local clock is ahead
+func checkClockSkew(ctx context.Context, managementURL string) (*clockSkewOutput, error) {
+	ctx, cancel := context.WithTimeout(ctx, clockSkewTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, managementURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating the clock skew request: %v", err)
+	}
+
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the time of the management server %s: %v", managementURL, err)
+	}
+	defer resp.Body.Close()
+	local := start.Add(time.Since(start) / 2)
+
+	dateHeader := resp.Header.Get("Date")
+	if dateHeader == "" {
+		return nil, fmt.Errorf("management server %s didn't send a Date header", managementURL)
+	}
+	serverTime, err := http.ParseTime(dateHeader)
+	if err != nil {
+		return nil, fmt.Errorf("management server %s sent an invalid Date header %s: %v", managementURL, dateHeader, err)
+	}
+
+	return &clockSkewOutput{
+		Server: managementURL,
+		Skew:   local.Sub(serverTime).Round(time.Second),
+	}, nil
+}
+
+// parseClockSkew renders the skew in green, or in red with a warning when it exceeds the threshold
+func parseClockSkew(clockSkew *clockSkewOutput) string {
+	seconds := int64(clockSkew.Skew / time.Second)
+	if clockSkew.exceeded() {
+		return fmt.Sprintf("Clock skew: %s\n", colorize(fmt.Sprintf("%+ds ⚠ (may cause handshake failures)", seconds), colorRed))
+	}
+	if seconds < 0 {
+		seconds = -seconds
+	}
+	return fmt.Sprintf("Clock skew: %s\n", colorize(fmt.Sprintf("±%ds", seconds), colorGreen))
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckClockSkew(t *testing.T) {
+	serverOffset := -185 * time.Second
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Date", time.Now().Add(serverOffset).UTC().Format(http.TimeFormat))
+	}))
+	defer server.Close()
+
+	clockSkew, err := checkClockSkew(context.Background(), server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, server.URL, clockSkew.Server)
+	assert.InDelta(t, 185, clockSkew.Skew.Seconds(), 1, "the local clock is ahead of the server")
+	assert.True(t, clockSkew.exceeded())
+
+	serverOffset = 0
+	clockSkew, err = checkClockSkew(context.Background(), server.URL)
+	require.NoError(t, err)
+	assert.InDelta(t, 0, clockSkew.Skew.Seconds(), 1)
+	assert.False(t, clockSkew.exceeded())
+}
+
+func TestParsingClockSkew(t *testing.T) {
+	assert.Equal(t, "Clock skew: \033[38;5;46m±2s\033[0m\n", parseClockSkew(&clockSkewOutput{Skew: -2 * time.Second}))
+	assert.Equal(t, "Clock skew: \033[38;5;196m+185s ⚠ (may cause handshake failures)\033[0m\n", parseClockSkew(&clockSkewOutput{Skew: 185 * time.Second}))
+	assert.Equal(t, "Clock skew: \033[38;5;196m-61s ⚠ (may cause handshake failures)\033[0m\n", parseClockSkew(&clockSkewOutput{Skew: -61 * time.Second}))
+
+	checks := runHealthChecks(statusOutputOverview{ClockSkew: &clockSkewOutput{Skew: 185 * time.Second}})
+	clockCheck := checks[len(checks)-1]
+	assert.Equal(t, "clock-skew", clockCheck.Name)
+	assert.False(t, clockCheck.Pass)
+	assert.Equal(t, 1, clockCheck.ExitCode)
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+const (
+	defaultCloudWatchNamespace = "NetBird/Peers"
+	cloudWatchHostDimension    = "Host"
+	cloudWatchPeerDimension    = "Peer"
+)
+
+// cloudWatchMetric declares a metric whose value is a root member of the EMF event with the same name
+type cloudWatchMetric struct {
+	Name string `json:"Name"`
+	Unit string `json:"Unit"`
+}
+
+type cloudWatchMetricDirective struct {
+	Namespace  string             `json:"Namespace"`
+	Dimensions [][]string         `json:"Dimensions"`
+	Metrics    []cloudWatchMetric `json:"Metrics"`
+}
+
+type cloudWatchMetadata struct {
+	Timestamp         int64                       `json:"Timestamp"`
+	CloudWatchMetrics []cloudWatchMetricDirective `json:"CloudWatchMetrics"`
+}
+
+// cloudWatchEvent is a peer in the CloudWatch Embedded Metric Format, the dimensions and metric values are root members
+type cloudWatchEvent struct {
+	AWS            cloudWatchMetadata `json:"_aws"`
+	Host           string             `json:"Host"`
+	Peer           string             `json:"Peer"`
+	ConnectionType string             `json:"ConnectionType"`
+	Connected      int                `json:"Connected"`
+	Latency        int64              `json:"Latency"`
+	BytesReceived  int64              `json:"BytesReceived"`
+	BytesSent      int64              `json:"BytesSent"`
+}
+
+var cloudWatchPeerMetrics = []cloudWatchMetric{
+	{Name: "Connected", Unit: "Count"},
+	{Name: "Latency", Unit: "Milliseconds"},
+	{Name: "BytesReceived", Unit: "Bytes"},
+	{Name: "BytesSent", Unit: "Bytes"},
+}
+
+// parseToCloudWatch renders one CloudWatch Embedded Metric Format event per peer. The events are newline separated,
+// which is what the CloudWatch agent and the Lambda log ingestion expect
+func parseToCloudWatch(overview statusOutputOverview, namespace string, now time.Time) (string, error) {
+	metadata := cloudWatchMetadata{
+		Timestamp: now.UnixMilli(),
+		CloudWatchMetrics: []cloudWatchMetricDirective{{
+			Namespace:  namespace,
+			Dimensions: [][]string{{cloudWatchHostDimension, cloudWatchPeerDimension}},
+			Metrics:    cloudWatchPeerMetrics,
+		}},
+	}
+
+	var events strings.Builder
+	for _, peerState := range overview.Peers.Details {
+		jsonBytes, err := json.Marshal(cloudWatchEvent{
+			AWS:            metadata,
+			Host:           overview.FQDN,
+			Peer:           peerState.FQDN,
+			ConnectionType: peerState.ConnType,
+			Connected:      boolToInt(peerState.Status == peer.StatusConnected.String()),
+			Latency:        peerState.Latency.Milliseconds(),
+			BytesReceived:  peerState.TransferReceived,
+			BytesSent:      peerState.TransferSent,
+		})
+		if err != nil {
+			return "", fmt.Errorf("json marshal failed")
+		}
+		events.Write(jsonBytes)
+		events.WriteString("\n")
+	}
+	return events.String(), nil
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsingToCloudWatch(t *testing.T) {
+	cloudWatchOverview := overview
+	cloudWatchOverview.Peers = peersStateOutput{
+		Details: []peerStateDetailOutput{
+			{
+				FQDN:             "peer-1.awesome-domain.com",
+				Status:           "Connected",
+				ConnType:         "P2P",
+				Latency:          12 * time.Millisecond,
+				TransferReceived: 200,
+				TransferSent:     100,
+			},
+			{
+				FQDN:     "peer-2.awesome-domain.com",
+				Status:   "Disconnected",
+				ConnType: "-",
+			},
+		},
+	}
+
+	events, err := parseToCloudWatch(cloudWatchOverview, "Custom/Namespace", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	metadata := `{"_aws":{"Timestamp":1704067200000,"CloudWatchMetrics":[{"Namespace":"Custom/Namespace","Dimensions":[["Host","Peer"]],` +
+		`"Metrics":[{"Name":"Connected","Unit":"Count"},{"Name":"Latency","Unit":"Milliseconds"},{"Name":"BytesReceived","Unit":"Bytes"},{"Name":"BytesSent","Unit":"Bytes"}]}]},`
+	lines := strings.Split(strings.TrimSuffix(events, "\n"), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, metadata+`"Host":"some-localhost.awesome-domain.com","Peer":"peer-1.awesome-domain.com","ConnectionType":"P2P",`+
+		`"Connected":1,"Latency":12,"BytesReceived":200,"BytesSent":100}`, lines[0])
+	assert.Equal(t, metadata+`"Host":"some-localhost.awesome-domain.com","Peer":"peer-2.awesome-domain.com","ConnectionType":"-",`+
+		`"Connected":0,"Latency":0,"BytesReceived":0,"BytesSent":0}`, lines[1])
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/status"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+// ANSI 256-color codes of the --color-by-latency gradient
+const (
+	colorGreen  = 46
+	colorYellow = 226
+	colorOrange = 208
+	colorRed    = 196
+
+	// colorCyan is used by --color-connected-ip
+	colorCyan = 51
+)
+
+// defaultLatencyThresholds are the green, yellow and orange upper bounds of the gradient in milliseconds
+var defaultLatencyThresholds = []int{10, 100, 500}
+
+// parseLatencyThresholds validates the --color-threshold-latency bounds
+func parseLatencyThresholds() error {
+	if len(latencyThresholdsFlag) != len(defaultLatencyThresholds) {
+		return fmt.Errorf("wrong latency thresholds, should be %d milliseconds, got: %s", len(defaultLatencyThresholds), formatThresholds(latencyThresholdsFlag))
+	}
+
+	for i, threshold := range latencyThresholdsFlag {
+		if threshold < 0 || (i > 0 && threshold <= latencyThresholdsFlag[i-1]) {
+			return fmt.Errorf("wrong latency thresholds, should be strictly increasing non-negative milliseconds, got: %s", formatThresholds(latencyThresholdsFlag))
+		}
+	}
+	return nil
+}
+
+// syncLatencyThresholds stores the --color-threshold-latency bounds in the daemon config when they are set and loads
+// the stored ones otherwise, the defaults stay when none are stored
+func syncLatencyThresholds(ctx context.Context, client proto.DaemonServiceClient, changed bool) error {
+	if changed {
+		thresholds := make([]int32, 0, len(latencyThresholdsFlag))
+		for _, threshold := range latencyThresholdsFlag {
+			thresholds = append(thresholds, int32(threshold))
+		}
+
+		_, err := client.SetLatencyColorThresholds(ctx, &proto.SetLatencyColorThresholdsRequest{Thresholds: thresholds})
+		if err != nil {
+			return fmt.Errorf("failed storing the latency thresholds: %v", status.Convert(err).Message())
+		}
+		return nil
+	}
+
+	resp, err := client.GetConfig(ctx, &proto.GetConfigRequest{})
+	if err != nil {
+		return fmt.Errorf("get daemon config failed: %v", status.Convert(err).Message())
+	}
+
+	if len(resp.GetLatencyColorThresholds()) == 0 {
+		return nil
+	}
+
+	thresholds := make([]int, 0, len(resp.GetLatencyColorThresholds()))
+	for _, threshold := range resp.GetLatencyColorThresholds() {
+		thresholds = append(thresholds, int(threshold))
+	}
+	latencyThresholdsFlag = thresholds
+	return parseLatencyThresholds()
+}
+
+func formatThresholds(thresholds []int) string {
+	formatted := make([]string, 0, len(thresholds))
+	for _, threshold := range thresholds {
+		formatted = append(formatted, fmt.Sprint(threshold))
+	}
+	return strings.Join(formatted, ",")
+}
+
+// latencyColor returns the gradient color of a peer: green up to the first threshold, yellow up to the second,
+// orange up to the third and red above. Connected peers without latency data are green and peers that are not
+// connected are red
+func latencyColor(peerState peerStateDetailOutput) int {
+	if peerState.Status != peer.StatusConnected.String() {
+		return colorRed
+	}
+
+	switch {
+	case peerState.Latency <= time.Duration(latencyThresholdsFlag[0])*time.Millisecond:
+		return colorGreen
+	case peerState.Latency <= time.Duration(latencyThresholdsFlag[1])*time.Millisecond:
+		return colorYellow
+	case peerState.Latency <= time.Duration(latencyThresholdsFlag[2])*time.Millisecond:
+		return colorOrange
+	default:
+		return colorRed
+	}
+}
+
+func colorize(text string, color int) string {
+	return fmt.Sprintf("\033[38;5;%dm%s\033[0m", color, text)
+}
+
+// highlight renders the text in bold cyan
+func highlight(text string) string {
+	return fmt.Sprintf("\033[1;38;5;%dm%s\033[0m", colorCyan, text)
+}
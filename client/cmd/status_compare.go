@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/util"
+)
+
+// exit codes of --compare-with-previous
+const (
+	peersDisconnectedExitCode = 1
+	peersConnectedExitCode    = 2
+)
+
+// latencyChangeFactor is the ratio between the previous and the current latency --since-snapshot reports
+const latencyChangeFactor = 2
+
+func loadSnapshot(file string) (*statusSnapshot, error) {
+	snapshot := &statusSnapshot{}
+	if _, err := util.ReadJson(file, snapshot); err != nil {
+		return nil, fmt.Errorf("failed loading status snapshot from %s: %v", file, err)
+	}
+	return snapshot, nil
+}
+
+// loadSnapshotIfExists loads the snapshot of the file, it returns nil when the file doesn't exist yet
+func loadSnapshotIfExists(file string) (*statusSnapshot, error) {
+	if _, err := os.Stat(file); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return loadSnapshot(file)
+}
+
+// diffSinceSnapshot reports the new and lost peers and the peers whose connection status, connection type or
+// latency changed since the snapshot. A nil snapshot is the first run, which has no changes. The returned error
+// is set when the changes include a degradation: a lost or disconnected peer, a direct connection falling back to
+// the relay or a latency growing by more than latencyChangeFactor
+func diffSinceSnapshot(previous *statusSnapshot, current statusOutputOverview, file string) (string, error) {
+	if previous == nil {
+		return fmt.Sprintf("No snapshot at %s yet, saved the current status as the baseline\n", file), nil
+	}
+
+	previousPeers := make(map[string]peerStateDetailOutput, len(previous.Peers.Details))
+	for _, peerState := range previous.Peers.Details {
+		previousPeers[peerState.PubKey] = peerState
+	}
+
+	var changes []string
+	var degradations int
+	for _, peerState := range current.Peers.Details {
+		previousState, ok := previousPeers[peerState.PubKey]
+		if !ok {
+			changes = append(changes, fmt.Sprintf("[NEW] %s (%s)", peerState.FQDN, strings.ToLower(peerState.Status)))
+			continue
+		}
+		delete(previousPeers, peerState.PubKey)
+
+		wasConnected := previousState.Status == peer.StatusConnected.String()
+		isConnected := peerState.Status == peer.StatusConnected.String()
+		switch {
+		case wasConnected && !isConnected:
+			degradations++
+			changes = append(changes, fmt.Sprintf("[DISCONNECTED] %s", peerState.FQDN))
+			continue
+		case !wasConnected && isConnected:
+			changes = append(changes, fmt.Sprintf("[CONNECTED] %s (%s)", peerState.FQDN, peerState.ConnType))
+			continue
+		case !isConnected:
+			continue
+		}
+
+		if previousState.ConnType != peerState.ConnType {
+			if peerState.ConnType == "Relayed" {
+				degradations++
+			}
+			changes = append(changes, fmt.Sprintf("[CONNECTION TYPE] %s: %s -> %s", peerState.FQDN, previousState.ConnType, peerState.ConnType))
+		}
+
+		if previousState.Latency <= 0 || peerState.Latency <= 0 {
+			continue
+		}
+		if peerState.Latency > latencyChangeFactor*previousState.Latency {
+			degradations++
+		} else if previousState.Latency <= latencyChangeFactor*peerState.Latency {
+			continue
+		}
+		changes = append(changes, fmt.Sprintf("[LATENCY] %s: %s -> %s", peerState.FQDN,
+			previousState.Latency.Round(time.Millisecond), peerState.Latency.Round(time.Millisecond)))
+	}
+
+	for _, previousState := range previous.Peers.Details {
+		if _, ok := previousPeers[previousState.PubKey]; ok {
+			degradations++
+			changes = append(changes, fmt.Sprintf("[LOST] %s", previousState.FQDN))
+		}
+	}
+
+	if len(changes) == 0 {
+		return fmt.Sprintf("No peer changes since the snapshot taken at %s\n", formatTimestamp(previous.SnapshotTime)), nil
+	}
+
+	output := strings.Join(changes, "\n") + "\n"
+	if degradations > 0 {
+		return output, fmt.Errorf("%d peer degradations since the snapshot taken at %s", degradations, formatTimestamp(previous.SnapshotTime))
+	}
+	return output, nil
+}
+
+// compareWithSnapshot reports the peers whose connection status changed since the snapshot. The returned error
+// carries exit code 1 when peers disconnected, otherwise exit code 2 when peers connected
+func compareWithSnapshot(previous *statusSnapshot, current statusOutputOverview, now time.Time) (string, error) {
+	previousPeers := make(map[string]peerStateDetailOutput, len(previous.Peers.Details))
+	for _, peerState := range previous.Peers.Details {
+		previousPeers[peerState.PubKey] = peerState
+	}
+
+	var changes []string
+	var disconnected, connected int
+	for _, peerState := range current.Peers.Details {
+		previousState, ok := previousPeers[peerState.PubKey]
+		if !ok {
+			continue
+		}
+
+		wasConnected := previousState.Status == peer.StatusConnected.String()
+		isConnected := peerState.Status == peer.StatusConnected.String()
+		if wasConnected == isConnected {
+			continue
+		}
+
+		changedAt := peerState.LastStatusUpdate
+		if changedAt.IsZero() || changedAt.Before(previousState.LastStatusUpdate) {
+			changedAt = now
+		}
+		previousDuration := formatShortDuration(changedAt.Sub(previousState.LastStatusUpdate))
+
+		if wasConnected {
+			disconnected++
+			changes = append(changes, fmt.Sprintf("[DISCONNECTED] %s (was connected for %s)", peerState.FQDN, previousDuration))
+		} else {
+			connected++
+			changes = append(changes, fmt.Sprintf("[CONNECTED] %s (was disconnected for %s)", peerState.FQDN, previousDuration))
+		}
+	}
+
+	if len(changes) == 0 {
+		return fmt.Sprintf("No peer changes since the snapshot taken at %s\n", formatTimestamp(previous.SnapshotTime)), nil
+	}
+
+	output := strings.Join(changes, "\n") + "\n"
+	switch {
+	case disconnected > 0:
+		return output, &ExitCodeError{
+			Code: peersDisconnectedExitCode,
+			Err:  fmt.Errorf("%d peers disconnected since the previous snapshot", disconnected),
+		}
+	default:
+		return output, &ExitCodeError{
+			Code: peersConnectedExitCode,
+			Err:  fmt.Errorf("%d peers connected since the previous snapshot", connected),
+		}
+	}
+}
+
+// formatShortDuration formats a duration with minute precision, e.g., 3h, 20m or 1d2h
+func formatShortDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	days := d / (24 * time.Hour)
+	hours := (d % (24 * time.Hour)) / time.Hour
+	minutes := (d % time.Hour) / time.Minute
+
+	var formatted string
+	if days > 0 {
+		formatted += fmt.Sprintf("%dd", days)
+	}
+	if hours > 0 {
+		formatted += fmt.Sprintf("%dh", hours)
+	}
+	if minutes > 0 || formatted == "" {
+		formatted += fmt.Sprintf("%dm", minutes)
+	}
+	return formatted
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareWithSnapshot(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	previous := statusOutputOverview{
+		Peers: peersStateOutput{
+			Details: []peerStateDetailOutput{
+				{FQDN: "peer-1.awesome-domain.com", PubKey: "Pubkey1", Status: "Connected", LastStatusUpdate: now.Add(-4 * time.Hour)},
+				{FQDN: "peer-2.awesome-domain.com", PubKey: "Pubkey2", Status: "Disconnected", LastStatusUpdate: now.Add(-30 * time.Minute)},
+				{FQDN: "peer-3.awesome-domain.com", PubKey: "Pubkey3", Status: "Connected", LastStatusUpdate: now.Add(-time.Hour)},
+			},
+		},
+	}
+
+	snapshotFile := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, saveSnapshot(snapshotFile, previous, now.Add(-time.Minute)))
+	snapshot, err := loadSnapshot(snapshotFile)
+	require.NoError(t, err)
+
+	output, err := compareWithSnapshot(snapshot, previous, now)
+	require.NoError(t, err)
+	assert.Equal(t, "No peer changes since the snapshot taken at 2024-01-01 11:59:00\n", output)
+
+	current := statusOutputOverview{
+		Peers: peersStateOutput{
+			Details: []peerStateDetailOutput{
+				{FQDN: "peer-1.awesome-domain.com", PubKey: "Pubkey1", Status: "Disconnected", LastStatusUpdate: now.Add(-time.Hour)},
+				{FQDN: "peer-2.awesome-domain.com", PubKey: "Pubkey2", Status: "Connected", LastStatusUpdate: now.Add(-10 * time.Minute)},
+				{FQDN: "peer-3.awesome-domain.com", PubKey: "Pubkey3", Status: "Connected", LastStatusUpdate: now.Add(-time.Hour)},
+			},
+		},
+	}
+
+	output, err = compareWithSnapshot(snapshot, current, now)
+	assert.Equal(t, "[DISCONNECTED] peer-1.awesome-domain.com (was connected for 3h)\n"+
+		"[CONNECTED] peer-2.awesome-domain.com (was disconnected for 20m)\n", output)
+	var exitErr *ExitCodeError
+	require.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, peersDisconnectedExitCode, exitErr.Code)
+
+	current.Peers.Details = current.Peers.Details[1:]
+	_, err = compareWithSnapshot(snapshot, current, now)
+	require.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, peersConnectedExitCode, exitErr.Code)
+}
+
+func TestDiffSinceSnapshot(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	snapshotFile := filepath.Join(t.TempDir(), "snapshot.json")
+
+	snapshot, err := loadSnapshotIfExists(snapshotFile)
+	require.NoError(t, err)
+	output, err := diffSinceSnapshot(snapshot, overview, snapshotFile)
+	require.NoError(t, err)
+	assert.Equal(t, "No snapshot at "+snapshotFile+" yet, saved the current status as the baseline\n", output)
+
+	previous := statusOutputOverview{
+		Peers: peersStateOutput{
+			Details: []peerStateDetailOutput{
+				{FQDN: "peer-1.awesome-domain.com", PubKey: "Pubkey1", Status: "Connected", ConnType: "P2P", Latency: 10 * time.Millisecond},
+				{FQDN: "peer-2.awesome-domain.com", PubKey: "Pubkey2", Status: "Connected", ConnType: "P2P", Latency: 10 * time.Millisecond},
+				{FQDN: "peer-3.awesome-domain.com", PubKey: "Pubkey3", Status: "Connected", ConnType: "Relayed", Latency: 30 * time.Millisecond},
+				{FQDN: "peer-4.awesome-domain.com", PubKey: "Pubkey4", Status: "Idle"},
+			},
+		},
+	}
+	require.NoError(t, saveSnapshot(snapshotFile, previous, now.Add(-time.Minute)))
+	snapshot, err = loadSnapshotIfExists(snapshotFile)
+	require.NoError(t, err)
+
+	output, err = diffSinceSnapshot(snapshot, previous, snapshotFile)
+	require.NoError(t, err)
+	assert.Equal(t, "No peer changes since the snapshot taken at 2024-01-01 11:59:00\n", output)
+
+	improved := statusOutputOverview{
+		Peers: peersStateOutput{
+			Details: []peerStateDetailOutput{
+				{FQDN: "peer-1.awesome-domain.com", PubKey: "Pubkey1", Status: "Connected", ConnType: "P2P", Latency: 15 * time.Millisecond},
+				{FQDN: "peer-2.awesome-domain.com", PubKey: "Pubkey2", Status: "Connected", ConnType: "P2P", Latency: 10 * time.Millisecond},
+				{FQDN: "peer-3.awesome-domain.com", PubKey: "Pubkey3", Status: "Connected", ConnType: "P2P", Latency: 5 * time.Millisecond},
+				{FQDN: "peer-4.awesome-domain.com", PubKey: "Pubkey4", Status: "Connected", ConnType: "P2P"},
+				{FQDN: "peer-5.awesome-domain.com", PubKey: "Pubkey5", Status: "Idle"},
+			},
+		},
+	}
+	output, err = diffSinceSnapshot(snapshot, improved, snapshotFile)
+	require.NoError(t, err)
+	assert.Equal(t, "[CONNECTION TYPE] peer-3.awesome-domain.com: Relayed -> P2P\n"+
+		"[LATENCY] peer-3.awesome-domain.com: 30ms -> 5ms\n"+
+		"[CONNECTED] peer-4.awesome-domain.com (P2P)\n"+
+		"[NEW] peer-5.awesome-domain.com (idle)\n", output)
+
+	degraded := statusOutputOverview{
+		Peers: peersStateOutput{
+			Details: []peerStateDetailOutput{
+				{FQDN: "peer-1.awesome-domain.com", PubKey: "Pubkey1", Status: "Connected", ConnType: "Relayed", Latency: 25 * time.Millisecond},
+				{FQDN: "peer-2.awesome-domain.com", PubKey: "Pubkey2", Status: "Idle"},
+			},
+		},
+	}
+	output, err = diffSinceSnapshot(snapshot, degraded, snapshotFile)
+	assert.Equal(t, "[CONNECTION TYPE] peer-1.awesome-domain.com: P2P -> Relayed\n"+
+		"[LATENCY] peer-1.awesome-domain.com: 10ms -> 25ms\n"+
+		"[DISCONNECTED] peer-2.awesome-domain.com\n"+
+		"[LOST] peer-3.awesome-domain.com\n"+
+		"[LOST] peer-4.awesome-domain.com\n", output)
+	assert.EqualError(t, err, "5 peer degradations since the snapshot taken at 2024-01-01 11:59:00")
+}
+
+func TestFormatShortDuration(t *testing.T) {
+	assert.Equal(t, "3h", formatShortDuration(3*time.Hour))
+	assert.Equal(t, "20m", formatShortDuration(20*time.Minute+10*time.Second))
+	assert.Equal(t, "1d2h5m", formatShortDuration(26*time.Hour+5*time.Minute))
+	assert.Equal(t, "0m", formatShortDuration(0))
+}
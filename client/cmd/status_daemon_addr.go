@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const daemonAddrProbeTimeout = 500 * time.Millisecond
+
+// autodetectDaemonAddr returns the first of the common daemon addresses that accepts connections
+func autodetectDaemonAddr() (string, error) {
+	candidates := []string{
+		"unix:///run/netbird.sock",
+		"unix:///var/run/netbird.sock",
+		"unix://" + filepath.Join(os.TempDir(), "netbird.sock"),
+	}
+	if flag := rootCmd.PersistentFlags().Lookup("daemon-addr"); flag != nil {
+		candidates = append(candidates, flag.DefValue)
+	}
+
+	return firstReachableDaemonAddr(candidates, daemonAddrProbeTimeout)
+}
+
+func firstReachableDaemonAddr(candidates []string, timeout time.Duration) (string, error) {
+	tried := make(map[string]struct{}, len(candidates))
+	for _, addr := range candidates {
+		if _, ok := tried[addr]; ok {
+			continue
+		}
+		tried[addr] = struct{}{}
+
+		network, address, found := strings.Cut(addr, "://")
+		if !found {
+			continue
+		}
+
+		conn, err := net.DialTimeout(network, address, timeout)
+		if err != nil {
+			continue
+		}
+		_ = conn.Close()
+		return addr, nil
+	}
+
+	return "", fmt.Errorf("no running daemon found, tried: %s", strings.Join(candidates, ", "))
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFirstReachableDaemonAddr(t *testing.T) {
+	dir := t.TempDir()
+	socket := filepath.Join(dir, "netbird.sock")
+
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	candidates := []string{
+		"unix://" + filepath.Join(dir, "missing.sock"),
+		"invalid-address",
+		"unix://" + socket,
+	}
+
+	addr, err := firstReachableDaemonAddr(candidates, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "unix://"+socket, addr)
+
+	_, err = firstReachableDaemonAddr(candidates[:2], time.Second)
+	assert.Error(t, err)
+}
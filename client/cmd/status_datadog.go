@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+const datadogGauge = "gauge"
+
+// datadogSeries is a metric series as accepted by the DataDog metrics API
+type datadogSeries struct {
+	Metric string       `json:"metric"`
+	Type   string       `json:"type"`
+	Points [][2]float64 `json:"points"`
+	Tags   []string     `json:"tags"`
+	Host   string       `json:"host,omitempty"`
+}
+
+type datadogPayload struct {
+	Series []datadogSeries `json:"series"`
+}
+
+// parseToDatadog renders the connectivity and latency of every peer and the peers summary as DataDog gauge series
+func parseToDatadog(overview statusOutputOverview, now time.Time) (string, error) {
+	timestamp := float64(now.Unix())
+	gauge := func(metric string, value float64, tags ...string) datadogSeries {
+		return datadogSeries{
+			Metric: metric,
+			Type:   datadogGauge,
+			Points: [][2]float64{{timestamp, value}},
+			Tags:   append([]string{}, tags...),
+			Host:   overview.FQDN,
+		}
+	}
+
+	payload := datadogPayload{Series: make([]datadogSeries, 0, 2*len(overview.Peers.Details)+2)}
+	for _, peerState := range overview.Peers.Details {
+		tags := []string{"fqdn:" + peerState.FQDN, "conn_type:" + peerState.ConnType}
+		connected := float64(boolToInt(peerState.Status == peer.StatusConnected.String()))
+		payload.Series = append(payload.Series,
+			gauge("netbird.peer.connected", connected, tags...),
+			gauge("netbird.peer.latency_ms", float64(peerState.Latency.Milliseconds()), tags...),
+		)
+	}
+
+	payload.Series = append(payload.Series,
+		gauge("netbird.peers.connected", float64(overview.Peers.Connected)),
+		gauge("netbird.peers.total", float64(overview.Peers.Total)),
+	)
+
+	jsonBytes, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("json marshal failed")
+	}
+	return string(jsonBytes) + "\n", nil
+}
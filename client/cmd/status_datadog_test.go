@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsingToDatadog(t *testing.T) {
+	datadogOverview := overview
+	datadogOverview.Peers = peersStateOutput{
+		Total:     2,
+		Connected: 1,
+		Details: []peerStateDetailOutput{
+			{
+				FQDN:     "peer-1.awesome-domain.com",
+				Status:   "Connected",
+				ConnType: "P2P",
+				Latency:  12 * time.Millisecond,
+			},
+			{
+				FQDN:     "peer-2.awesome-domain.com",
+				Status:   "Disconnected",
+				ConnType: "-",
+			},
+		},
+	}
+
+	expected := `{"series":[` +
+		`{"metric":"netbird.peer.connected","type":"gauge","points":[[1704067200,1]],"tags":["fqdn:peer-1.awesome-domain.com","conn_type:P2P"],"host":"some-localhost.awesome-domain.com"},` +
+		`{"metric":"netbird.peer.latency_ms","type":"gauge","points":[[1704067200,12]],"tags":["fqdn:peer-1.awesome-domain.com","conn_type:P2P"],"host":"some-localhost.awesome-domain.com"},` +
+		`{"metric":"netbird.peer.connected","type":"gauge","points":[[1704067200,0]],"tags":["fqdn:peer-2.awesome-domain.com","conn_type:-"],"host":"some-localhost.awesome-domain.com"},` +
+		`{"metric":"netbird.peer.latency_ms","type":"gauge","points":[[1704067200,0]],"tags":["fqdn:peer-2.awesome-domain.com","conn_type:-"],"host":"some-localhost.awesome-domain.com"},` +
+		`{"metric":"netbird.peers.connected","type":"gauge","points":[[1704067200,1]],"tags":[],"host":"some-localhost.awesome-domain.com"},` +
+		`{"metric":"netbird.peers.total","type":"gauge","points":[[1704067200,2]],"tags":[],"host":"some-localhost.awesome-domain.com"}]}` + "\n"
+
+	datadog, err := parseToDatadog(datadogOverview, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, expected, datadog)
+}
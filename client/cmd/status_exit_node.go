@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+// exitNodeRoutes are the default routes of the exit nodes, management routes all traffic through a peer advertising them
+var exitNodeRoutes = map[string]struct{}{
+	"0.0.0.0/0": {},
+	"::/0":      {},
+}
+
+// hasExitNodeRoute reports whether any of the routes is a default route
+func hasExitNodeRoute(routes []string) bool {
+	for _, route := range routes {
+		if _, ok := exitNodeRoutes[route]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// exitNodeConfiguredWithoutConnection reports whether management pushed a default route to this peer, i.e., it is
+// configured to use an exit node, but none of the peers advertising one is connected
+func exitNodeConfiguredWithoutConnection(peers []*proto.PeerState) bool {
+	configured := false
+	for _, peerState := range peers {
+		if !hasExitNodeRoute(peerState.GetAdvertisedRoutes()) {
+			continue
+		}
+		if peerState.GetConnStatus() == peer.StatusConnected.String() {
+			return false
+		}
+		configured = true
+	}
+	return configured
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+func TestPeersExitNode(t *testing.T) {
+	t.Cleanup(func() {
+		peersExitNodeFlag = false
+		detailFlag = false
+	})
+
+	peersExitNodeFlag = true
+	require.NoError(t, parseFilters())
+	assert.True(t, detailFlag)
+
+	exitNodeResp := &proto.StatusResponse{
+		FullStatus: &proto.FullStatus{
+			Peers: []*proto.PeerState{
+				{
+					IP:               "100.64.0.5",
+					Fqdn:             "exit-1.awesome-domain.com",
+					ConnStatus:       "Connected",
+					AdvertisedRoutes: []string{"0.0.0.0/0"},
+					Routes:           []string{"0.0.0.0/0"},
+				},
+				{
+					IP:               "100.64.0.6",
+					Fqdn:             "exit-2.awesome-domain.com",
+					ConnStatus:       "Connected",
+					AdvertisedRoutes: []string{"::/0", "10.0.0.0/8"},
+				},
+				{
+					IP:               "100.64.0.7",
+					Fqdn:             "router.awesome-domain.com",
+					ConnStatus:       "Connected",
+					AdvertisedRoutes: []string{"10.0.0.0/8"},
+				},
+			},
+			ManagementState: &proto.ManagementState{},
+			SignalState:     &proto.SignalState{},
+			LocalPeerState:  &proto.LocalPeerState{},
+		},
+	}
+
+	exitNodeOverview := convertToStatusOutputOverview(exitNodeResp)
+	require.Len(t, exitNodeOverview.Peers.Details, 2)
+	assert.True(t, exitNodeOverview.Peers.Details[0].ActiveExitNode)
+	assert.False(t, exitNodeOverview.Peers.Details[1].ActiveExitNode)
+
+	detail := parsePeers(exitNodeOverview.Peers, false, false)
+	assert.Contains(t, detail, " exit-1.awesome-domain.com [ACTIVE]:\n")
+	assert.Contains(t, detail, " exit-2.awesome-domain.com:\n")
+
+	peers := exitNodeResp.FullStatus.Peers
+	assert.False(t, exitNodeConfiguredWithoutConnection(peers))
+	peers[0].ConnStatus = "Idle"
+	peers[1].ConnStatus = "Connecting"
+	assert.True(t, exitNodeConfiguredWithoutConnection(peers))
+	assert.False(t, exitNodeConfiguredWithoutConnection(peers[2:]), "no peer advertises a default route")
+}
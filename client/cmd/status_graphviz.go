@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+// parseToGraphviz renders the local peer and its peers as an undirected DOT graph. Edges are colored by connection type:
+// green for P2P, orange for relayed and gray for disconnected peers
+func parseToGraphviz(overview statusOutputOverview) string {
+	localNode := dotQuote(overview.FQDN)
+
+	var graph strings.Builder
+	graph.WriteString("graph netbird {\n")
+	graph.WriteString("  node [shape=box];\n")
+	graph.WriteString(fmt.Sprintf("  %s [label=%s, style=filled, fillcolor=lightblue, penwidth=2];\n",
+		localNode, dotQuote(overview.FQDN+`\n`+overview.IP)))
+
+	for _, peerState := range overview.Peers.Details {
+		label := peerState.FQDN + `\n` + peerState.IP
+		if peerState.Latency > 0 {
+			label += `\n` + peerState.Latency.String()
+		}
+		node := dotQuote(peerState.FQDN)
+		graph.WriteString(fmt.Sprintf("  %s [label=%s];\n", node, dotQuote(label)))
+
+		edgeAttributes := "color=gray, style=dashed"
+		if peerState.Status == peer.StatusConnected.String() {
+			color := "green"
+			if peerState.ConnType == "Relayed" {
+				color = "orange"
+			}
+			edgeAttributes = fmt.Sprintf("color=%s, label=%s", color, dotQuote(peerState.ConnType))
+		}
+		graph.WriteString(fmt.Sprintf("  %s -- %s [%s];\n", localNode, node, edgeAttributes))
+	}
+
+	graph.WriteString("}\n")
+	return graph.String()
+}
+
+// dotQuote quotes a DOT identifier. Backslashes are kept so labels can use the \n line break escape
+func dotQuote(id string) string {
+	return `"` + strings.ReplaceAll(id, `"`, `\"`) + `"`
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsingToGraphviz(t *testing.T) {
+	graphOverview := overview
+	graphOverview.Peers.Details = append([]peerStateDetailOutput{}, overview.Peers.Details...)
+	graphOverview.Peers.Details[0].Latency = 12 * time.Millisecond
+	graphOverview.Peers.Details = append(graphOverview.Peers.Details, peerStateDetailOutput{
+		FQDN:   "peer-3.awesome-domain.com",
+		IP:     "192.168.178.103",
+		Status: "Disconnected",
+	})
+
+	expected := `graph netbird {
+  node [shape=box];
+  "some-localhost.awesome-domain.com" [label="some-localhost.awesome-domain.com\n192.168.178.100/16", style=filled, fillcolor=lightblue, penwidth=2];
+  "peer-1.awesome-domain.com" [label="peer-1.awesome-domain.com\n192.168.178.101\n12ms"];
+  "some-localhost.awesome-domain.com" -- "peer-1.awesome-domain.com" [color=green, label="P2P"];
+  "peer-2.awesome-domain.com" [label="peer-2.awesome-domain.com\n192.168.178.102"];
+  "some-localhost.awesome-domain.com" -- "peer-2.awesome-domain.com" [color=orange, label="Relayed"];
+  "peer-3.awesome-domain.com" [label="peer-3.awesome-domain.com\n192.168.178.103"];
+  "some-localhost.awesome-domain.com" -- "peer-3.awesome-domain.com" [color=gray, style=dashed];
+}
+`
+
+	assert.Equal(t, expected, parseToGraphviz(graphOverview))
+}
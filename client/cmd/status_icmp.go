@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+const (
+	icmpReachabilityTimeout = 500 * time.Millisecond
+	icmpUnreachableStatus   = "⚠ Connected but unreachable (ICMP)"
+	// peerCheckConcurrency is the maximum number of peers pinged or route tested at a time
+	peerCheckConcurrency = 16
+)
+
+type icmpCheckResult struct {
+	Peer      string  `json:"peer" yaml:"peer"`
+	IP        string  `json:"ip" yaml:"ip"`
+	Reachable bool    `json:"reachable" yaml:"reachable"`
+	RTTMs     float64 `json:"rttMs" yaml:"rttMs"`
+	Error     string  `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+type icmpCheckOutput struct {
+	Reachable int               `json:"reachable" yaml:"reachable"`
+	Total     int               `json:"total" yaml:"total"`
+	Results   []icmpCheckResult `json:"results" yaml:"results"`
+}
+
+type pingFunc func(ctx context.Context, addr netip.Addr) (time.Duration, error)
+
+// markICMPUnreachablePeers pings the connected peers with checkICMPAll and marks the peers that don't answer within
+// the timeout. It returns the number of marked peers
+func markICMPUnreachablePeers(ctx context.Context, peers *peersStateOutput, ping pingFunc, timeout time.Duration) int {
+	results := checkICMPAll(ctx, peers.Details, ping, timeout).Results
+	var unreachable int
+	for i := range peers.Details {
+		peerState := &peers.Details[i]
+		if peerState.Status != peer.StatusConnected.String() {
+			continue
+		}
+
+		// the results follow the order of the connected peers
+		result := results[0]
+		results = results[1:]
+		if !result.Reachable {
+			peerState.ICMPUnreachable = true
+			unreachable++
+		}
+	}
+	return unreachable
+}
+
+// runPeerChecks calls check for every index below n, at most peerCheckConcurrency at a time, and waits for all of
+// them. The pings and route tests the status command runs against the peers share it
+func runPeerChecks(n int, check func(i int)) {
+	var group errgroup.Group
+	group.SetLimit(peerCheckConcurrency)
+	for i := 0; i < n; i++ {
+		i := i
+		group.Go(func() error {
+			check(i)
+			return nil
+		})
+	}
+	_ = group.Wait()
+}
+
+func parseICMPCheckTimeout() error {
+	if icmpCheckTimeoutFlag <= 0 {
+		return fmt.Errorf("wrong ICMP check timeout, should be a positive duration, got: %s", icmpCheckTimeoutFlag)
+	}
+	return nil
+}
+
+// checkICMPAll pings the tunnel IP of every connected peer, at most peerCheckConcurrency at a time, and returns the
+// results in the order of the peers
+func checkICMPAll(ctx context.Context, peers []peerStateDetailOutput, ping pingFunc, timeout time.Duration) icmpCheckOutput {
+	var connected []peerStateDetailOutput
+	for _, peerState := range peers {
+		if peerState.Status == peer.StatusConnected.String() {
+			connected = append(connected, peerState)
+		}
+	}
+
+	output := icmpCheckOutput{Total: len(connected), Results: make([]icmpCheckResult, len(connected))}
+	runPeerChecks(len(connected), func(i int) {
+		result := &output.Results[i]
+		result.Peer = connected[i].FQDN
+		result.IP = connected[i].IP
+
+		addr, err := netip.ParseAddr(strings.Split(connected[i].IP, "/")[0])
+		if err != nil {
+			result.Error = fmt.Sprintf("invalid IP: %v", err)
+			return
+		}
+
+		pingCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		rtt, err := ping(pingCtx, addr)
+		if err != nil {
+			result.Error = err.Error()
+			return
+		}
+		result.Reachable = true
+		result.RTTMs = roundMs(float64(rtt) / float64(time.Millisecond))
+	})
+
+	for _, result := range output.Results {
+		if result.Reachable {
+			output.Reachable++
+		}
+	}
+	return output
+}
+
+func failedICMPCheckErr(output icmpCheckOutput) error {
+	if output.Reachable == output.Total {
+		return nil
+	}
+	return fmt.Errorf("%d of %d connected peers are unreachable over ICMP", output.Total-output.Reachable, output.Total)
+}
+
+func parseICMPCheck(output icmpCheckOutput) string {
+	var summary strings.Builder
+	for _, result := range output.Results {
+		if result.Reachable {
+			summary.WriteString(fmt.Sprintf("REACHABLE: %s (%s): %gms\n", result.Peer, result.IP, result.RTTMs))
+		} else {
+			summary.WriteString(fmt.Sprintf("UNREACHABLE: %s (%s): %s\n", result.Peer, result.IP, result.Error))
+		}
+	}
+	summary.WriteString(fmt.Sprintf("%d/%d peers ICMP-reachable\n", output.Reachable, output.Total))
+	return summary.String()
+}
+
+func parseICMPCheckToJSON(output icmpCheckOutput) (string, error) {
+	jsonBytes, err := json.Marshal(output)
+	if err != nil {
+		return "", fmt.Errorf("json marshal failed")
+	}
+	return string(jsonBytes), nil
+}
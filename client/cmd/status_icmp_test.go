@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkICMPUnreachablePeers(t *testing.T) {
+	peers := peersStateOutput{
+		Details: []peerStateDetailOutput{
+			{FQDN: "answering.netbird.cloud", IP: "100.64.0.1", Status: "Connected"},
+			{FQDN: "silent.netbird.cloud", IP: "100.64.0.2/32", Status: "Connected"},
+			{FQDN: "offline.netbird.cloud", IP: "100.64.0.3", Status: "Idle"},
+		},
+	}
+
+	var mu sync.Mutex
+	var pinged []netip.Addr
+	ping := func(ctx context.Context, addr netip.Addr) (time.Duration, error) {
+		_, ok := ctx.Deadline()
+		assert.True(t, ok, "every ping should have a deadline")
+		mu.Lock()
+		pinged = append(pinged, addr)
+		mu.Unlock()
+		if addr == netip.MustParseAddr("100.64.0.2") {
+			return 0, errors.New("i/o timeout")
+		}
+		return time.Millisecond, nil
+	}
+
+	unreachable := markICMPUnreachablePeers(context.Background(), &peers, ping, icmpReachabilityTimeout)
+
+	assert.Equal(t, 1, unreachable)
+	assert.Len(t, pinged, 2, "only connected peers should be pinged")
+	assert.False(t, peers.Details[0].ICMPUnreachable)
+	assert.True(t, peers.Details[1].ICMPUnreachable)
+	assert.False(t, peers.Details[2].ICMPUnreachable)
+
+	detail := parsePeers(peers, false, false)
+	assert.Equal(t, 1, strings.Count(detail, "Status: "+icmpUnreachableStatus))
+}
+
+func TestCheckICMPAll(t *testing.T) {
+	peers := []peerStateDetailOutput{
+		{FQDN: "answering.netbird.cloud", IP: "100.64.0.1", Status: "Connected"},
+		{FQDN: "silent.netbird.cloud", IP: "100.64.0.2/32", Status: "Connected"},
+		{FQDN: "offline.netbird.cloud", IP: "100.64.0.3", Status: "Idle"},
+	}
+	ping := func(_ context.Context, addr netip.Addr) (time.Duration, error) {
+		if addr == netip.MustParseAddr("100.64.0.2") {
+			return 0, errors.New("context deadline exceeded")
+		}
+		return 1500 * time.Microsecond, nil
+	}
+
+	output := checkICMPAll(context.Background(), peers, ping, icmpReachabilityTimeout)
+	assert.Equal(t, 1, output.Reachable)
+	assert.Equal(t, 2, output.Total)
+	assert.Equal(t, "REACHABLE: answering.netbird.cloud (100.64.0.1): 1.5ms\n"+
+		"UNREACHABLE: silent.netbird.cloud (100.64.0.2/32): context deadline exceeded\n"+
+		"1/2 peers ICMP-reachable\n", parseICMPCheck(output))
+	assert.EqualError(t, failedICMPCheckErr(output), "1 of 2 connected peers are unreachable over ICMP")
+
+	jsonString, err := parseICMPCheckToJSON(output)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"reachable":1,"total":2,"results":[`+
+		`{"peer":"answering.netbird.cloud","ip":"100.64.0.1","reachable":true,"rttMs":1.5},`+
+		`{"peer":"silent.netbird.cloud","ip":"100.64.0.2/32","reachable":false,"rttMs":0,"error":"context deadline exceeded"}]}`, jsonString)
+
+	output = checkICMPAll(context.Background(), peers[:1], ping, icmpReachabilityTimeout)
+	assert.NoError(t, failedICMPCheckErr(output))
+}
+
+func TestRunPeerChecks(t *testing.T) {
+	var mu sync.Mutex
+	var running, maxRunning int
+	checked := make([]bool, 3*peerCheckConcurrency)
+
+	runPeerChecks(len(checked), func(i int) {
+		mu.Lock()
+		running++
+		maxRunning = max(maxRunning, running)
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+		checked[i] = true
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+	})
+
+	assert.LessOrEqual(t, maxRunning, peerCheckConcurrency)
+	assert.NotContains(t, checked, false, "every index should be checked")
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+const junitPeersClassName = "netbird.peers"
+
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// parseToJUnit renders the peers connectivity as a JUnit test suite where every peer is a test case.
+// It returns the number of failed test cases along with the rendered document
+func parseToJUnit(overview statusOutputOverview, now time.Time) (string, int, error) {
+	timestamp := now.UTC().Format(time.RFC3339)
+	suite := junitTestSuite{
+		Name:      fmt.Sprintf("netbird %s %s", overview.FQDN, timestamp),
+		Tests:     len(overview.Peers.Details),
+		Timestamp: timestamp,
+		TestCases: make([]junitTestCase, 0, len(overview.Peers.Details)),
+	}
+
+	for _, peerState := range overview.Peers.Details {
+		testCase := junitTestCase{
+			Name:      peerState.FQDN,
+			ClassName: junitPeersClassName,
+		}
+
+		if peerState.Status != peer.StatusConnected.String() {
+			suite.Failures++
+			testCase.Failure = &junitFailure{
+				Message: fmt.Sprintf("peer is %s", peerState.Status),
+				Text: fmt.Sprintf("peer %s (%s) is %s, last status update: %s",
+					peerState.FQDN, peerState.IP, peerState.Status, peerState.LastStatusUpdate.Format(time.RFC3339)),
+			}
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	xmlBytes, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return "", 0, fmt.Errorf("junit marshal failed")
+	}
+
+	return xml.Header + string(xmlBytes) + "\n", suite.Failures, nil
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsingToJUnit(t *testing.T) {
+	junitOverview := overview
+	junitOverview.Peers = peersStateOutput{
+		Total:     2,
+		Connected: 1,
+		Details: []peerStateDetailOutput{
+			{
+				FQDN:   "peer-1.awesome-domain.com",
+				IP:     "192.168.178.101",
+				Status: "Connected",
+			},
+			{
+				FQDN:             "peer-2.awesome-domain.com",
+				IP:               "192.168.178.102",
+				Status:           "Disconnected",
+				LastStatusUpdate: time.Date(2001, 1, 1, 1, 1, 2, 0, time.UTC),
+			},
+		},
+	}
+
+	junit, failures, err := parseToJUnit(junitOverview, time.Date(2001, 1, 1, 2, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	expectedJUnit := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="netbird some-localhost.awesome-domain.com 2001-01-01T02:00:00Z" tests="2" failures="1" timestamp="2001-01-01T02:00:00Z">
+  <testcase name="peer-1.awesome-domain.com" classname="netbird.peers"></testcase>
+  <testcase name="peer-2.awesome-domain.com" classname="netbird.peers">
+    <failure message="peer is Disconnected">peer peer-2.awesome-domain.com (192.168.178.102) is Disconnected, last status update: 2001-01-01T01:01:02Z</failure>
+  </testcase>
+</testsuite>
+`
+
+	assert.Equal(t, 1, failures)
+	assert.Equal(t, expectedJUnit, junit)
+}
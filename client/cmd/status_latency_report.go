@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/netip"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+const (
+	defaultLatencySamples    = 5
+	maxLatencySamples        = 100
+	latencyReportPingTimeout = time.Second
+	histogramBarWidth        = 40
+	histogramBar             = "█"
+)
+
+// latencyHistogramBounds are the upper bounds of the histogram buckets in milliseconds, the last bucket is unbounded
+var latencyHistogramBounds = []float64{1, 2, 5, 10, 20, 50, 100, 200, 500}
+
+type latencyHistogramBucket struct {
+	Bucket string `json:"bucket" yaml:"bucket"`
+	Count  int    `json:"count" yaml:"count"`
+}
+
+type latencyDistributionOutput struct {
+	Samples   int                      `json:"samples" yaml:"samples"`
+	Lost      int                      `json:"lost" yaml:"lost"`
+	MeanMs    float64                  `json:"meanMs" yaml:"meanMs"`
+	StdDevMs  float64                  `json:"stdDevMs" yaml:"stdDevMs"`
+	MinMs     float64                  `json:"minMs" yaml:"minMs"`
+	MaxMs     float64                  `json:"maxMs" yaml:"maxMs"`
+	P25Ms     float64                  `json:"p25Ms" yaml:"p25Ms"`
+	P50Ms     float64                  `json:"p50Ms" yaml:"p50Ms"`
+	P75Ms     float64                  `json:"p75Ms" yaml:"p75Ms"`
+	P90Ms     float64                  `json:"p90Ms" yaml:"p90Ms"`
+	P95Ms     float64                  `json:"p95Ms" yaml:"p95Ms"`
+	P99Ms     float64                  `json:"p99Ms" yaml:"p99Ms"`
+	Histogram []latencyHistogramBucket `json:"histogram" yaml:"histogram"`
+}
+
+type latencyReportPeer struct {
+	FQDN                string                    `json:"fqdn" yaml:"fqdn"`
+	IP                  string                    `json:"netbirdIp" yaml:"netbirdIp"`
+	LatencyDistribution latencyDistributionOutput `json:"latencyDistribution" yaml:"latencyDistribution"`
+}
+
+type latencyReportOutput struct {
+	SamplesPerPeer int                       `json:"samplesPerPeer" yaml:"samplesPerPeer"`
+	Peers          []latencyReportPeer       `json:"peers" yaml:"peers"`
+	All            latencyDistributionOutput `json:"all" yaml:"all"`
+}
+
+func parseLatencySamples() error {
+	if latencySamplesFlag < 1 || latencySamplesFlag > maxLatencySamples {
+		return fmt.Errorf("wrong latency samples, should be between 1 and %d, got: %d", maxLatencySamples, latencySamplesFlag)
+	}
+	return nil
+}
+
+// buildLatencyReport pings every connected peer the given number of times, at most peerCheckConcurrency peers at
+// a time, and computes the latency distribution of every peer and of all the samples together
+func buildLatencyReport(ctx context.Context, peers []peerStateDetailOutput, ping pingFunc, samples int, timeout time.Duration) latencyReportOutput {
+	report := latencyReportOutput{SamplesPerPeer: samples}
+	var all []time.Duration
+	var allLost int
+
+	var connected []peerStateDetailOutput
+	for _, peerState := range peers {
+		if peerState.Status == peer.StatusConnected.String() {
+			connected = append(connected, peerState)
+		}
+	}
+
+	var mu sync.Mutex
+	runPeerChecks(len(connected), func(i int) {
+		addr, err := netip.ParseAddr(strings.Split(connected[i].IP, "/")[0])
+		if err != nil {
+			return
+		}
+
+		rtts, lost := collectLatencySamples(ctx, addr, ping, samples, timeout)
+
+		mu.Lock()
+		defer mu.Unlock()
+		report.Peers = append(report.Peers, latencyReportPeer{
+			FQDN:                connected[i].FQDN,
+			IP:                  connected[i].IP,
+			LatencyDistribution: computeLatencyDistribution(rtts, lost),
+		})
+		all = append(all, rtts...)
+		allLost += lost
+	})
+
+	sort.Slice(report.Peers, func(i, j int) bool {
+		return report.Peers[i].FQDN < report.Peers[j].FQDN
+	})
+	report.All = computeLatencyDistribution(all, allLost)
+	return report
+}
+
+// collectLatencySamples pings the address one time after the other, it returns the round-trip times of the answered
+// pings and the number of lost ones
+func collectLatencySamples(ctx context.Context, addr netip.Addr, ping pingFunc, samples int, timeout time.Duration) ([]time.Duration, int) {
+	rtts := make([]time.Duration, 0, samples)
+	var lost int
+	for i := 0; i < samples; i++ {
+		pingCtx, cancel := context.WithTimeout(ctx, timeout)
+		rtt, err := ping(pingCtx, addr)
+		cancel()
+		if err != nil {
+			lost++
+			continue
+		}
+		rtts = append(rtts, rtt)
+	}
+	return rtts, lost
+}
+
+func computeLatencyDistribution(rtts []time.Duration, lost int) latencyDistributionOutput {
+	distribution := latencyDistributionOutput{
+		Samples:   len(rtts),
+		Lost:      lost,
+		Histogram: latencyHistogram(rtts),
+	}
+	if len(rtts) == 0 {
+		return distribution
+	}
+
+	values := make([]float64, 0, len(rtts))
+	var sum float64
+	for _, rtt := range rtts {
+		ms := float64(rtt) / float64(time.Millisecond)
+		values = append(values, ms)
+		sum += ms
+	}
+	sort.Float64s(values)
+
+	mean := sum / float64(len(values))
+	var squares float64
+	for _, value := range values {
+		squares += (value - mean) * (value - mean)
+	}
+
+	distribution.MeanMs = roundMs(mean)
+	distribution.StdDevMs = roundMs(math.Sqrt(squares / float64(len(values))))
+	distribution.MinMs = roundMs(values[0])
+	distribution.MaxMs = roundMs(values[len(values)-1])
+	distribution.P25Ms = percentile(values, 25)
+	distribution.P50Ms = percentile(values, 50)
+	distribution.P75Ms = percentile(values, 75)
+	distribution.P90Ms = percentile(values, 90)
+	distribution.P95Ms = percentile(values, 95)
+	distribution.P99Ms = percentile(values, 99)
+	return distribution
+}
+
+// percentile interpolates linearly between the closest ranks of the sorted values
+func percentile(sorted []float64, p float64) float64 {
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return roundMs(sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower)))
+}
+
+// roundMs rounds milliseconds to microseconds
+func roundMs(ms float64) float64 {
+	return math.Round(ms*1000) / 1000
+}
+
+func latencyHistogram(rtts []time.Duration) []latencyHistogramBucket {
+	histogram := make([]latencyHistogramBucket, 0, len(latencyHistogramBounds)+1)
+	lowerBound := 0.0
+	for _, upperBound := range latencyHistogramBounds {
+		histogram = append(histogram, latencyHistogramBucket{Bucket: fmt.Sprintf("%g-%gms", lowerBound, upperBound)})
+		lowerBound = upperBound
+	}
+	histogram = append(histogram, latencyHistogramBucket{Bucket: fmt.Sprintf(">=%gms", lowerBound)})
+
+	for _, rtt := range rtts {
+		ms := float64(rtt) / float64(time.Millisecond)
+		bucket := sort.Search(len(latencyHistogramBounds), func(i int) bool {
+			return ms < latencyHistogramBounds[i]
+		})
+		histogram[bucket].Count++
+	}
+	return histogram
+}
+
+func parseLatencyReport(report latencyReportOutput) string {
+	var summary strings.Builder
+	fmt.Fprintf(&summary, "Latency report (%d samples per peer):\n", report.SamplesPerPeer)
+	for _, reportPeer := range report.Peers {
+		fmt.Fprintf(&summary, " %s: %s\n", reportPeer.FQDN, parseLatencyDistribution(reportPeer.LatencyDistribution))
+	}
+	if len(report.Peers) == 0 {
+		summary.WriteString(" no connected peers\n")
+	}
+	fmt.Fprintf(&summary, "All peers: %s\n", parseLatencyDistribution(report.All))
+
+	summary.WriteString("Histogram:\n")
+	var maxCount, labelWidth int
+	for _, bucket := range report.All.Histogram {
+		maxCount = max(maxCount, bucket.Count)
+		labelWidth = max(labelWidth, len(bucket.Bucket))
+	}
+	for _, bucket := range report.All.Histogram {
+		bar := 0
+		if maxCount > 0 {
+			bar = int(math.Round(float64(bucket.Count) / float64(maxCount) * histogramBarWidth))
+		}
+		fmt.Fprintf(&summary, " %*s | %s%s | %d\n", labelWidth, bucket.Bucket,
+			strings.Repeat(histogramBar, bar), strings.Repeat(" ", histogramBarWidth-bar), bucket.Count)
+	}
+	return summary.String()
+}
+
+func parseLatencyDistribution(distribution latencyDistributionOutput) string {
+	total := distribution.Samples + distribution.Lost
+	if distribution.Samples == 0 {
+		return fmt.Sprintf("all %d pings lost", total)
+	}
+	return fmt.Sprintf("mean %gms, stddev %gms, min %gms, max %gms, p25 %gms, p50 %gms, p75 %gms, p90 %gms, p95 %gms, p99 %gms, lost %d/%d",
+		distribution.MeanMs, distribution.StdDevMs, distribution.MinMs, distribution.MaxMs,
+		distribution.P25Ms, distribution.P50Ms, distribution.P75Ms, distribution.P90Ms, distribution.P95Ms, distribution.P99Ms,
+		distribution.Lost, total)
+}
+
+func parseLatencyReportToJSON(report latencyReportOutput) (string, error) {
+	jsonBytes, err := json.Marshal(report)
+	if err != nil {
+		return "", fmt.Errorf("json marshal failed")
+	}
+	return string(jsonBytes), nil
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeLatencyDistribution(t *testing.T) {
+	rtts := []time.Duration{4 * time.Millisecond, time.Millisecond, 3 * time.Millisecond, 2 * time.Millisecond, 600 * time.Millisecond}
+
+	distribution := computeLatencyDistribution(rtts, 1)
+	assert.Equal(t, 5, distribution.Samples)
+	assert.Equal(t, 1, distribution.Lost)
+	assert.Equal(t, 122.0, distribution.MeanMs)
+	assert.Equal(t, 239.002, distribution.StdDevMs)
+	assert.Equal(t, 1.0, distribution.MinMs)
+	assert.Equal(t, 600.0, distribution.MaxMs)
+	assert.Equal(t, 2.0, distribution.P25Ms)
+	assert.Equal(t, 3.0, distribution.P50Ms)
+	assert.Equal(t, 4.0, distribution.P75Ms)
+	assert.Equal(t, 361.6, distribution.P90Ms)
+	assert.Equal(t, 480.8, distribution.P95Ms)
+	assert.Equal(t, 576.16, distribution.P99Ms)
+
+	counts := map[string]int{}
+	for _, bucket := range distribution.Histogram {
+		counts[bucket.Bucket] = bucket.Count
+	}
+	assert.Equal(t, map[string]int{
+		"0-1ms": 0, "1-2ms": 1, "2-5ms": 3, "5-10ms": 0, "10-20ms": 0, "20-50ms": 0,
+		"50-100ms": 0, "100-200ms": 0, "200-500ms": 0, ">=500ms": 1,
+	}, counts)
+
+	lost := computeLatencyDistribution(nil, 5)
+	assert.Equal(t, 0, lost.Samples)
+	assert.Equal(t, "all 5 pings lost", parseLatencyDistribution(lost))
+}
+
+func TestBuildLatencyReport(t *testing.T) {
+	peers := []peerStateDetailOutput{
+		{FQDN: "peer-2.awesome-domain.com", IP: "100.64.0.2", Status: "Connected"},
+		{FQDN: "peer-1.awesome-domain.com", IP: "100.64.0.1", Status: "Connected"},
+		{FQDN: "peer-3.awesome-domain.com", IP: "100.64.0.3", Status: "Idle"},
+	}
+	ping := func(_ context.Context, addr netip.Addr) (time.Duration, error) {
+		if addr == netip.MustParseAddr("100.64.0.2") {
+			return 0, errors.New("timeout")
+		}
+		return 2 * time.Millisecond, nil
+	}
+
+	report := buildLatencyReport(context.Background(), peers, ping, 3, latencyReportPingTimeout)
+	require.Len(t, report.Peers, 2)
+	assert.Equal(t, "peer-1.awesome-domain.com", report.Peers[0].FQDN)
+	assert.Equal(t, 3, report.Peers[0].LatencyDistribution.Samples)
+	assert.Equal(t, 3, report.Peers[1].LatencyDistribution.Lost)
+	assert.Equal(t, 3, report.All.Samples)
+	assert.Equal(t, 3, report.All.Lost)
+
+	output := parseLatencyReport(report)
+	assert.Contains(t, output, "Latency report (3 samples per peer):\n")
+	assert.Contains(t, output, " peer-1.awesome-domain.com: mean 2ms, stddev 0ms, min 2ms, max 2ms, p25 2ms, p50 2ms, p75 2ms, p90 2ms, p95 2ms, p99 2ms, lost 0/3\n")
+	assert.Contains(t, output, " peer-2.awesome-domain.com: all 3 pings lost\n")
+	assert.Contains(t, output, "     2-5ms | ████████████████████████████████████████ | 3\n")
+	assert.Contains(t, output, "     0-1ms |                                          | 0\n")
+
+	jsonString, err := parseLatencyReportToJSON(report)
+	require.NoError(t, err)
+	assert.Contains(t, jsonString, `"latencyDistribution":{"samples":3,"lost":0,"meanMs":2,"stdDevMs":0,"minMs":2,"maxMs":2,"p25Ms":2,`)
+}
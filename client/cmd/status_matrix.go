@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+type connectionMatrixNode struct {
+	ID     string `json:"id"`
+	FQDN   string `json:"fqdn"`
+	IP     string `json:"ip"`
+	Status string `json:"status"`
+	Local  bool   `json:"local"`
+}
+
+type connectionMatrixEdge struct {
+	Source    string `json:"source"`
+	Target    string `json:"target"`
+	Type      string `json:"type"`
+	LatencyMs int64  `json:"latency_ms"`
+}
+
+type connectionMatrix struct {
+	Nodes []connectionMatrixNode `json:"nodes"`
+	Edges []connectionMatrixEdge `json:"edges"`
+}
+
+// parseToConnectionMatrixJSON renders the connection graph as nodes and edges consumable by graph visualization libraries.
+// Every connection is listed once with the lexicographically lower FQDN as source
+func parseToConnectionMatrixJSON(overview statusOutputOverview) (string, error) {
+	matrix := connectionMatrix{
+		Nodes: []connectionMatrixNode{
+			{
+				ID:     overview.FQDN,
+				FQDN:   overview.FQDN,
+				IP:     overview.IP,
+				Status: peer.StatusConnected.String(),
+				Local:  true,
+			},
+		},
+		Edges: []connectionMatrixEdge{},
+	}
+
+	for _, peerState := range overview.Peers.Details {
+		matrix.Nodes = append(matrix.Nodes, connectionMatrixNode{
+			ID:     peerState.FQDN,
+			FQDN:   peerState.FQDN,
+			IP:     peerState.IP,
+			Status: peerState.Status,
+		})
+
+		if peerState.Status != peer.StatusConnected.String() {
+			continue
+		}
+
+		edgeType := "p2p"
+		if peerState.ConnType == "Relayed" {
+			edgeType = "relay"
+		}
+
+		source, target := overview.FQDN, peerState.FQDN
+		if target < source {
+			source, target = target, source
+		}
+
+		matrix.Edges = append(matrix.Edges, connectionMatrixEdge{
+			Source:    source,
+			Target:    target,
+			Type:      edgeType,
+			LatencyMs: peerState.Latency.Milliseconds(),
+		})
+	}
+
+	jsonBytes, err := json.Marshal(matrix)
+	if err != nil {
+		return "", fmt.Errorf("json marshal failed")
+	}
+	return string(jsonBytes) + "\n", nil
+}
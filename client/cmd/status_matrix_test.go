@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsingToConnectionMatrixJSON(t *testing.T) {
+	matrixOverview := overview
+	matrixOverview.Peers.Details = append([]peerStateDetailOutput{}, overview.Peers.Details...)
+	matrixOverview.Peers.Details[1].Latency = 35 * time.Millisecond
+	matrixOverview.Peers.Details = append(matrixOverview.Peers.Details, peerStateDetailOutput{
+		FQDN:   "peer-3.awesome-domain.com",
+		IP:     "192.168.178.103",
+		Status: "Disconnected",
+	})
+
+	expected := `{"nodes":[` +
+		`{"id":"some-localhost.awesome-domain.com","fqdn":"some-localhost.awesome-domain.com","ip":"192.168.178.100/16","status":"Connected","local":true},` +
+		`{"id":"peer-1.awesome-domain.com","fqdn":"peer-1.awesome-domain.com","ip":"192.168.178.101","status":"Connected","local":false},` +
+		`{"id":"peer-2.awesome-domain.com","fqdn":"peer-2.awesome-domain.com","ip":"192.168.178.102","status":"Connected","local":false},` +
+		`{"id":"peer-3.awesome-domain.com","fqdn":"peer-3.awesome-domain.com","ip":"192.168.178.103","status":"Disconnected","local":false}],` +
+		`"edges":[` +
+		`{"source":"peer-1.awesome-domain.com","target":"some-localhost.awesome-domain.com","type":"p2p","latency_ms":0},` +
+		`{"source":"peer-2.awesome-domain.com","target":"some-localhost.awesome-domain.com","type":"relay","latency_ms":35}]}` + "\n"
+
+	matrix, err := parseToConnectionMatrixJSON(matrixOverview)
+	require.NoError(t, err)
+	assert.Equal(t, expected, matrix)
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+// parseToMermaid renders the local peer and its peers as a Mermaid flowchart. P2P edges are solid, relayed edges are
+// dashed and the edges of disconnected peers are dotted and gray. The diagram is wrapped in a fenced code block when
+// it is written to a Markdown file
+func parseToMermaid(overview statusOutputOverview, outputFile string) string {
+	var graph strings.Builder
+	graph.WriteString("graph LR\n")
+	graph.WriteString(fmt.Sprintf("  local[%s]\n", mermaidLabel(overview.FQDN, overview.IP)))
+	graph.WriteString("  style local stroke-width:3px\n")
+
+	var linkStyles []string
+	for i, peerState := range overview.Peers.Details {
+		node := fmt.Sprintf("peer%d", i)
+		graph.WriteString(fmt.Sprintf("  %s[%s]\n", node, mermaidLabel(peerState.FQDN, peerState.IP)))
+
+		switch {
+		case peerState.Status != peer.StatusConnected.String():
+			graph.WriteString(fmt.Sprintf("  local -.-|%s| %s\n", mermaidQuote(peerState.Status), node))
+			linkStyles = append(linkStyles, fmt.Sprintf("  linkStyle %d stroke:gray,stroke-dasharray:2 4\n", i))
+		case peerState.ConnType == "Relayed":
+			graph.WriteString(fmt.Sprintf("  local -.-|%s| %s\n", mermaidQuote(peerState.ConnType), node))
+			linkStyles = append(linkStyles, fmt.Sprintf("  linkStyle %d stroke-dasharray:8 4\n", i))
+		default:
+			graph.WriteString(fmt.Sprintf("  local ---|%s| %s\n", mermaidQuote(peerState.ConnType), node))
+		}
+	}
+	for _, linkStyle := range linkStyles {
+		graph.WriteString(linkStyle)
+	}
+
+	switch strings.ToLower(filepath.Ext(outputFile)) {
+	case ".md", ".markdown":
+		return "```mermaid\n" + graph.String() + "```\n"
+	default:
+		return graph.String()
+	}
+}
+
+func mermaidLabel(fqdn, ip string) string {
+	return mermaidQuote(fqdn + "<br/>" + ip)
+}
+
+// mermaidQuote quotes a Mermaid label, quotes inside are written as the #quot; entity
+func mermaidQuote(label string) string {
+	return `"` + strings.ReplaceAll(label, `"`, "#quot;") + `"`
+}
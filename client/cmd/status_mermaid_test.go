@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsingToMermaid(t *testing.T) {
+	graphOverview := overview
+	graphOverview.Peers.Details = append([]peerStateDetailOutput{}, overview.Peers.Details...)
+	graphOverview.Peers.Details = append(graphOverview.Peers.Details, peerStateDetailOutput{
+		FQDN:   `peer-"3".awesome-domain.com`,
+		IP:     "192.168.178.103",
+		Status: "Idle",
+	})
+
+	expected := `graph LR
+  local["some-localhost.awesome-domain.com<br/>192.168.178.100/16"]
+  style local stroke-width:3px
+  peer0["peer-1.awesome-domain.com<br/>192.168.178.101"]
+  local ---|"P2P"| peer0
+  peer1["peer-2.awesome-domain.com<br/>192.168.178.102"]
+  local -.-|"Relayed"| peer1
+  peer2["peer-#quot;3#quot;.awesome-domain.com<br/>192.168.178.103"]
+  local -.-|"Idle"| peer2
+  linkStyle 1 stroke-dasharray:8 4
+  linkStyle 2 stroke:gray,stroke-dasharray:2 4
+`
+	assert.Equal(t, expected, parseToMermaid(graphOverview, ""))
+	assert.Equal(t, expected, parseToMermaid(graphOverview, "topology.mmd"))
+	assert.Equal(t, "```mermaid\n"+expected+"```\n", parseToMermaid(graphOverview, "docs/Topology.MD"))
+}
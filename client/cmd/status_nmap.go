@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/version"
+)
+
+const (
+	nmapDocType          = "<!DOCTYPE nmaprun>\n"
+	nmapXMLOutputVersion = "1.05"
+)
+
+// nmapRun is the subset of the Nmap XML output (nmap.dtd) needed to describe the NetBird peers as scanned hosts
+type nmapRun struct {
+	XMLName          xml.Name     `xml:"nmaprun"`
+	Scanner          string       `xml:"scanner,attr"`
+	Args             string       `xml:"args,attr"`
+	Start            int64        `xml:"start,attr"`
+	StartStr         string       `xml:"startstr,attr"`
+	Version          string       `xml:"version,attr"`
+	XMLOutputVersion string       `xml:"xmloutputversion,attr"`
+	Verbose          nmapLevel    `xml:"verbose"`
+	Debugging        nmapLevel    `xml:"debugging"`
+	Hosts            []nmapHost   `xml:"host"`
+	RunStats         nmapRunStats `xml:"runstats"`
+}
+
+type nmapLevel struct {
+	Level int `xml:"level,attr"`
+}
+
+type nmapHost struct {
+	Status    nmapStatus    `xml:"status"`
+	Address   nmapAddress   `xml:"address"`
+	Hostnames nmapHostnames `xml:"hostnames"`
+}
+
+type nmapStatus struct {
+	State     string `xml:"state,attr"`
+	Reason    string `xml:"reason,attr"`
+	ReasonTTL int    `xml:"reason_ttl,attr"`
+}
+
+type nmapAddress struct {
+	Addr     string `xml:"addr,attr"`
+	AddrType string `xml:"addrtype,attr"`
+}
+
+type nmapHostnames struct {
+	Hostnames []nmapHostname `xml:"hostname"`
+}
+
+type nmapHostname struct {
+	Name string `xml:"name,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type nmapRunStats struct {
+	Finished nmapFinished  `xml:"finished"`
+	Hosts    nmapHostStats `xml:"hosts"`
+}
+
+type nmapFinished struct {
+	Time    int64  `xml:"time,attr"`
+	TimeStr string `xml:"timestr,attr"`
+	Elapsed string `xml:"elapsed,attr"`
+	Summary string `xml:"summary,attr"`
+	Exit    string `xml:"exit,attr"`
+}
+
+type nmapHostStats struct {
+	Up    int `xml:"up,attr"`
+	Down  int `xml:"down,attr"`
+	Total int `xml:"total,attr"`
+}
+
+// parseToNmapXML renders the connected peers as the up hosts of an Nmap XML document
+func parseToNmapXML(overview statusOutputOverview, now time.Time) (string, error) {
+	timeStr := now.Format(time.ANSIC)
+	run := nmapRun{
+		Scanner:          "netbird",
+		Args:             "netbird status --format " + nmapXMLFormat,
+		Start:            now.Unix(),
+		StartStr:         timeStr,
+		Version:          version.NetbirdVersion(),
+		XMLOutputVersion: nmapXMLOutputVersion,
+	}
+
+	for _, peerState := range overview.Peers.Details {
+		if peerState.Status != peer.StatusConnected.String() {
+			continue
+		}
+
+		addr, _, _ := strings.Cut(peerState.IP, "/")
+		addrType := "ipv4"
+		if ip := net.ParseIP(addr); ip != nil && ip.To4() == nil {
+			addrType = "ipv6"
+		}
+
+		host := nmapHost{
+			Status:  nmapStatus{State: "up", Reason: "netbird-connected"},
+			Address: nmapAddress{Addr: addr, AddrType: addrType},
+		}
+		if peerState.FQDN != "" {
+			host.Hostnames.Hostnames = []nmapHostname{{Name: strings.TrimSuffix(peerState.FQDN, "."), Type: "user"}}
+		}
+		run.Hosts = append(run.Hosts, host)
+	}
+
+	run.RunStats = nmapRunStats{
+		Finished: nmapFinished{
+			Time:    now.Unix(),
+			TimeStr: timeStr,
+			Elapsed: "0.00",
+			Summary: fmt.Sprintf("NetBird status done at %s; %d IP addresses (%d hosts up)", timeStr, len(overview.Peers.Details), len(run.Hosts)),
+			Exit:    "success",
+		},
+		Hosts: nmapHostStats{
+			Up:    len(run.Hosts),
+			Down:  len(overview.Peers.Details) - len(run.Hosts),
+			Total: len(overview.Peers.Details),
+		},
+	}
+
+	xmlBytes, err := xml.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("nmap xml marshal failed")
+	}
+
+	return xml.Header + nmapDocType + string(xmlBytes) + "\n", nil
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/version"
+)
+
+func TestParsingToNmapXML(t *testing.T) {
+	nmapOverview := overview
+	nmapOverview.Peers = peersStateOutput{
+		Total:     3,
+		Connected: 2,
+		Details: []peerStateDetailOutput{
+			{FQDN: "peer-1.awesome-domain.com", IP: "192.168.178.101", Status: "Connected"},
+			{FQDN: "peer-2.awesome-domain.com", IP: "192.168.178.102", Status: "Disconnected"},
+			{FQDN: "peer-3.awesome-domain.com.", IP: "fd00::3", Status: "Connected"},
+		},
+	}
+
+	nmapXML, err := parseToNmapXML(nmapOverview, time.Date(2001, 1, 1, 2, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE nmaprun>
+<nmaprun scanner="netbird" args="netbird status --format nmap-xml" start="978314400" startstr="Mon Jan  1 02:00:00 2001" version="` + version.NetbirdVersion() + `" xmloutputversion="1.05">
+  <verbose level="0"></verbose>
+  <debugging level="0"></debugging>
+  <host>
+    <status state="up" reason="netbird-connected" reason_ttl="0"></status>
+    <address addr="192.168.178.101" addrtype="ipv4"></address>
+    <hostnames>
+      <hostname name="peer-1.awesome-domain.com" type="user"></hostname>
+    </hostnames>
+  </host>
+  <host>
+    <status state="up" reason="netbird-connected" reason_ttl="0"></status>
+    <address addr="fd00::3" addrtype="ipv6"></address>
+    <hostnames>
+      <hostname name="peer-3.awesome-domain.com" type="user"></hostname>
+    </hostnames>
+  </host>
+  <runstats>
+    <finished time="978314400" timestr="Mon Jan  1 02:00:00 2001" elapsed="0.00" summary="NetBird status done at Mon Jan  1 02:00:00 2001; 3 IP addresses (2 hosts up)" exit="success"></finished>
+    <hosts up="2" down="1" total="3"></hosts>
+  </runstats>
+</nmaprun>
+`
+	assert.Equal(t, expected, nmapXML)
+}
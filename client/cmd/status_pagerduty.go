@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+const (
+	pagerDutyEventsURL      = "https://events.pagerduty.com/v2/enqueue"
+	pagerDutyTrigger        = "trigger"
+	pagerDutyResolve        = "resolve"
+	pagerDutySeverityError  = "error"
+	pagerDutySeverityWarn   = "warning"
+	pagerDutySeverityInfo   = "info"
+	pagerDutyDedupKeyPrefix = "netbird-status-"
+)
+
+// pagerDutyEvent is an event as accepted by the PagerDuty Events API v2
+type pagerDutyEvent struct {
+	RoutingKey  string           `json:"routing_key"`
+	EventAction string           `json:"event_action"`
+	DedupKey    string           `json:"dedup_key"`
+	Payload     pagerDutyPayload `json:"payload"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string                 `json:"summary"`
+	Severity      string                 `json:"severity"`
+	Source        string                 `json:"source"`
+	Timestamp     string                 `json:"timestamp"`
+	CustomDetails pagerDutyCustomDetails `json:"custom_details"`
+}
+
+type pagerDutyCustomDetails struct {
+	ManagementConnected bool     `json:"management_connected"`
+	SignalConnected     bool     `json:"signal_connected"`
+	PeersConnected      int      `json:"peers_connected"`
+	PeersTotal          int      `json:"peers_total"`
+	DisconnectedPeers   []string `json:"disconnected_peers"`
+}
+
+// parseToPagerDuty renders a PagerDuty event that triggers an incident when any peer, management or signal is
+// disconnected and resolves it otherwise. The dedup key is derived from the local FQDN, so the resolve event
+// closes the incident opened by the trigger event of the same peer
+func parseToPagerDuty(overview statusOutputOverview, routingKey string, now time.Time) (string, error) {
+	details := pagerDutyCustomDetails{
+		ManagementConnected: overview.ManagementState.Connected,
+		SignalConnected:     overview.SignalState.Connected,
+		PeersConnected:      overview.Peers.Connected,
+		PeersTotal:          overview.Peers.Total,
+		DisconnectedPeers:   []string{},
+	}
+	for _, peerState := range overview.Peers.Details {
+		if peerState.Status != peer.StatusConnected.String() {
+			details.DisconnectedPeers = append(details.DisconnectedPeers, peerState.FQDN)
+		}
+	}
+
+	action, severity, summary := pagerDutyTrigger, pagerDutySeverityWarn, fmt.Sprintf("%d peers disconnected", len(details.DisconnectedPeers))
+	switch {
+	case !details.ManagementConnected || !details.SignalConnected:
+		severity = pagerDutySeverityError
+		summary = fmt.Sprintf("%s, management connected: %t, signal connected: %t", summary, details.ManagementConnected, details.SignalConnected)
+	case len(details.DisconnectedPeers) > 0 && len(details.DisconnectedPeers) == details.PeersTotal:
+		severity = pagerDutySeverityError
+	case len(details.DisconnectedPeers) == 0:
+		action, severity = pagerDutyResolve, pagerDutySeverityInfo
+		summary = fmt.Sprintf("%d peers connected", details.PeersConnected)
+	}
+
+	jsonBytes, err := json.Marshal(pagerDutyEvent{
+		RoutingKey:  routingKey,
+		EventAction: action,
+		DedupKey:    pagerDutyDedupKeyPrefix + overview.FQDN,
+		Payload: pagerDutyPayload{
+			Summary:       fmt.Sprintf("%s on %s", summary, overview.FQDN),
+			Severity:      severity,
+			Source:        overview.FQDN,
+			Timestamp:     now.UTC().Format(time.RFC3339),
+			CustomDetails: details,
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("json marshal failed")
+	}
+	return string(jsonBytes) + "\n", nil
+}
+
+// postToPagerDuty sends the event to the PagerDuty Events API, which answers accepted events with 202
+func postToPagerDuty(ctx context.Context, url, event string) error {
+	return postStatusOutput(ctx, "PagerDuty "+url, url, "application/json", nil, event)
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsingToPagerDuty(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	event, err := parseToPagerDuty(overview, "routing-key", now)
+	require.NoError(t, err)
+	expected := `{"routing_key":"routing-key","event_action":"resolve","dedup_key":"netbird-status-some-localhost.awesome-domain.com",` +
+		`"payload":{"summary":"2 peers connected on some-localhost.awesome-domain.com","severity":"info","source":"some-localhost.awesome-domain.com",` +
+		`"timestamp":"2024-01-01T00:00:00Z","custom_details":{"management_connected":true,"signal_connected":true,"peers_connected":2,"peers_total":2,"disconnected_peers":[]}}}` + "\n"
+	assert.Equal(t, expected, event)
+
+	disconnected := overview
+	disconnected.Peers = peersStateOutput{
+		Total:     2,
+		Connected: 1,
+		Details: []peerStateDetailOutput{
+			{FQDN: "peer-1.awesome-domain.com", Status: "Connected"},
+			{FQDN: "peer-2.awesome-domain.com", Status: "Idle"},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		signalConnected  bool
+		expectedSeverity string
+		expectedSummary  string
+	}{
+		{
+			name:             "some peers disconnected",
+			signalConnected:  true,
+			expectedSeverity: "warning",
+			expectedSummary:  "1 peers disconnected on some-localhost.awesome-domain.com",
+		},
+		{
+			name:             "signal disconnected",
+			expectedSeverity: "error",
+			expectedSummary:  "1 peers disconnected, management connected: true, signal connected: false on some-localhost.awesome-domain.com",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			disconnected.SignalState.Connected = tc.signalConnected
+
+			event, err := parseToPagerDuty(disconnected, "routing-key", now)
+			require.NoError(t, err)
+
+			var parsed pagerDutyEvent
+			require.NoError(t, json.Unmarshal([]byte(event), &parsed))
+			assert.Equal(t, "trigger", parsed.EventAction)
+			assert.Equal(t, tc.expectedSeverity, parsed.Payload.Severity)
+			assert.Equal(t, tc.expectedSummary, parsed.Payload.Summary)
+			assert.Equal(t, []string{"peer-2.awesome-domain.com"}, parsed.Payload.CustomDetails.DisconnectedPeers)
+		})
+	}
+}
+
+func TestPostToPagerDuty(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		if r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"status":"invalid event","message":"Event object is invalid"}`))
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	err := postToPagerDuty(context.Background(), server.URL, "{\"event_action\":\"trigger\"}\n")
+	require.NoError(t, err)
+	assert.Equal(t, "{\"event_action\":\"trigger\"}\n", received)
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"status":"invalid event"}`))
+	})
+	err = postToPagerDuty(context.Background(), server.URL, "{}\n")
+	assert.ErrorContains(t, err, "400 Bad Request: {\"status\":\"invalid event\"}")
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// statusPostTimeout bounds the requests sending the status output to external services
+const statusPostTimeout = 10 * time.Second
+
+// postStatusOutput posts the status output to an external service, named in the errors. Any answer other than 2xx is
+// an error carrying the beginning of the response body
+func postStatusOutput(ctx context.Context, service, url, contentType string, headers map[string]string, body string) error {
+	ctx, cancel := context.WithTimeout(ctx, statusPostTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBufferString(body))
+	if err != nil {
+		return fmt.Errorf("failed creating the %s request: %v", service, err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed posting to %s: %v", service, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s returned %s: %s", service, resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+const (
+	prometheusContentType  = "text/plain; version=0.0.4"
+	defaultPushgatewayURL  = "http://localhost:9091"
+	defaultPushgatewayJob  = "netbird"
+	pushgatewayBase64Label = "@base64"
+)
+
+var prometheusLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// prometheusLabelNameRegex matches the label names Prometheus accepts
+var prometheusLabelNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// prometheusPeerLabels are the labels of the peer metrics, they can't be overridden by --prometheus-push-gateway-labels
+var prometheusPeerLabels = map[string]struct{}{"fqdn": {}, "ip": {}, "conn_type": {}, "job": {}}
+
+// prometheusMetric holds the samples of a metric family as lines of the Prometheus text exposition format
+type prometheusMetric struct {
+	name    string
+	help    string
+	samples []string
+}
+
+func (m *prometheusMetric) add(value float64, labels ...string) {
+	var sample strings.Builder
+	sample.WriteString(m.name)
+	if len(labels) > 0 {
+		pairs := make([]string, 0, len(labels)/2)
+		for i := 0; i+1 < len(labels); i += 2 {
+			pairs = append(pairs, fmt.Sprintf(`%s="%s"`, labels[i], prometheusLabelEscaper.Replace(labels[i+1])))
+		}
+		sample.WriteString("{" + strings.Join(pairs, ",") + "}")
+	}
+	sample.WriteString(fmt.Sprintf(" %g", value))
+	m.samples = append(m.samples, sample.String())
+}
+
+// parsePushgatewayLabels validates the labels of --prometheus-push-gateway-labels, they are part of the grouping key
+// as well, so they can't conflict with --grouping-key
+func parsePushgatewayLabels() error {
+	for name, value := range pushgatewayLabels {
+		if !prometheusLabelNameRegex.MatchString(name) || strings.HasPrefix(name, "__") {
+			return fmt.Errorf("wrong Prometheus label name, should match %s without the reserved __ prefix, got: %s", prometheusLabelNameRegex, name)
+		}
+		if _, ok := prometheusPeerLabels[name]; ok {
+			return fmt.Errorf("wrong Prometheus label name, %s is set by netbird", name)
+		}
+		if groupingValue, ok := pushgatewayGrouping[name]; ok && groupingValue != value {
+			return fmt.Errorf("the Prometheus label %s=%s conflicts with the grouping key %s=%s", name, value, name, groupingValue)
+		}
+	}
+	return nil
+}
+
+// pushgatewayGroupingKey merges the grouping key with the labels added to every metric, the Pushgateway rejects
+// metrics whose labels differ from the grouping key
+func pushgatewayGroupingKey(groupingKey, labels map[string]string) map[string]string {
+	merged := make(map[string]string, len(groupingKey)+len(labels))
+	for label, value := range groupingKey {
+		merged[label] = value
+	}
+	for label, value := range labels {
+		merged[label] = value
+	}
+	return merged
+}
+
+// parseToPrometheus renders the connectivity, latency and transfer of every peer and the summary as Prometheus
+// gauges. The extra labels are added to every sample
+func parseToPrometheus(overview statusOutputOverview, extraLabels map[string]string) string {
+	names := make([]string, 0, len(extraLabels))
+	for name := range extraLabels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	commonLabels := make([]string, 0, 2*len(names))
+	for _, name := range names {
+		commonLabels = append(commonLabels, name, extraLabels[name])
+	}
+
+	peerConnected := &prometheusMetric{name: "netbird_peer_connected", help: "Whether the peer is connected (1) or not (0)"}
+	peerLatency := &prometheusMetric{name: "netbird_peer_latency_seconds", help: "Latency to the peer"}
+	peerSent := &prometheusMetric{name: "netbird_peer_sent_bytes", help: "Bytes sent to the peer"}
+	peerReceived := &prometheusMetric{name: "netbird_peer_received_bytes", help: "Bytes received from the peer"}
+
+	for _, peerState := range overview.Peers.Details {
+		labels := append([]string{"fqdn", peerState.FQDN, "ip", peerState.IP, "conn_type", peerState.ConnType}, commonLabels...)
+		peerConnected.add(float64(boolToInt(peerState.Status == peer.StatusConnected.String())), labels...)
+		peerLatency.add(peerState.Latency.Seconds(), labels...)
+		peerSent.add(float64(peerState.TransferSent), labels...)
+		peerReceived.add(float64(peerState.TransferReceived), labels...)
+	}
+
+	peersConnected := &prometheusMetric{name: "netbird_peers_connected", help: "Number of connected peers"}
+	peersConnected.add(float64(overview.Peers.Connected), commonLabels...)
+	peersTotal := &prometheusMetric{name: "netbird_peers_total", help: "Number of peers"}
+	peersTotal.add(float64(overview.Peers.Total), commonLabels...)
+	managementConnected := &prometheusMetric{name: "netbird_management_connected", help: "Whether the management server is connected (1) or not (0)"}
+	managementConnected.add(float64(boolToInt(overview.ManagementState.Connected)), commonLabels...)
+	signalConnected := &prometheusMetric{name: "netbird_signal_connected", help: "Whether the signal server is connected (1) or not (0)"}
+	signalConnected.add(float64(boolToInt(overview.SignalState.Connected)), commonLabels...)
+
+	var metrics strings.Builder
+	for _, metric := range []*prometheusMetric{peerConnected, peerLatency, peerSent, peerReceived, peersConnected, peersTotal, managementConnected, signalConnected} {
+		if len(metric.samples) == 0 {
+			continue
+		}
+		metrics.WriteString(fmt.Sprintf("# HELP %s %s\n", metric.name, metric.help))
+		metrics.WriteString(fmt.Sprintf("# TYPE %s gauge\n", metric.name))
+		for _, sample := range metric.samples {
+			metrics.WriteString(sample + "\n")
+		}
+	}
+	return metrics.String()
+}
+
+// pushgatewayURL builds the Pushgateway URL of the job and the grouping key. Values that can't be a path segment are
+// base64url encoded as the Pushgateway expects
+func pushgatewayURL(gatewayURL, job string, groupingKey map[string]string) string {
+	encode := func(label, value string) string {
+		if value == "" || strings.Contains(value, "/") {
+			encoded := base64.RawURLEncoding.EncodeToString([]byte(value))
+			if encoded == "" {
+				encoded = "="
+			}
+			return label + pushgatewayBase64Label + "/" + encoded
+		}
+		return label + "/" + url.PathEscape(value)
+	}
+
+	labels := make([]string, 0, len(groupingKey))
+	for label := range groupingKey {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	path := strings.TrimSuffix(gatewayURL, "/") + "/metrics/" + encode("job", job)
+	for _, label := range labels {
+		path += "/" + encode(label, groupingKey[label])
+	}
+	return path
+}
+
+// pushToPushgateway posts the metrics to the Pushgateway, replacing the metrics with the same names in the group
+func pushToPushgateway(ctx context.Context, gatewayURL, job string, groupingKey map[string]string, user, password, metrics string) error {
+	headers := map[string]string{}
+	if user != "" || password != "" {
+		headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+password))
+	}
+	return postStatusOutput(ctx, "Pushgateway "+gatewayURL, pushgatewayURL(gatewayURL, job, groupingKey), prometheusContentType, headers, metrics)
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsingToPrometheus(t *testing.T) {
+	promOverview := overview
+	promOverview.Peers = peersStateOutput{
+		Total:     2,
+		Connected: 1,
+		Details: []peerStateDetailOutput{
+			{FQDN: "peer-1.awesome-domain.com", IP: "192.168.178.101", Status: "Connected", ConnType: "P2P", Latency: 10 * time.Millisecond, TransferSent: 200, TransferReceived: 100},
+			{FQDN: "peer-\"2\".awesome-domain.com", IP: "192.168.178.102", Status: "Disconnected"},
+		},
+	}
+
+	metrics := parseToPrometheus(promOverview, nil)
+
+	assert.Contains(t, metrics, "# HELP netbird_peer_connected Whether the peer is connected (1) or not (0)\n"+
+		"# TYPE netbird_peer_connected gauge\n"+
+		"netbird_peer_connected{fqdn=\"peer-1.awesome-domain.com\",ip=\"192.168.178.101\",conn_type=\"P2P\"} 1\n"+
+		"netbird_peer_connected{fqdn=\"peer-\\\"2\\\".awesome-domain.com\",ip=\"192.168.178.102\",conn_type=\"\"} 0\n")
+	assert.Contains(t, metrics, "netbird_peer_latency_seconds{fqdn=\"peer-1.awesome-domain.com\",ip=\"192.168.178.101\",conn_type=\"P2P\"} 0.01\n")
+	assert.Contains(t, metrics, "netbird_peer_sent_bytes{fqdn=\"peer-1.awesome-domain.com\",ip=\"192.168.178.101\",conn_type=\"P2P\"} 200\n")
+	assert.Contains(t, metrics, "# TYPE netbird_peers_connected gauge\nnetbird_peers_connected 1\n")
+	assert.Contains(t, metrics, "netbird_peers_total 2\n")
+	assert.Contains(t, metrics, "netbird_management_connected 1\n")
+	assert.Contains(t, metrics, "netbird_signal_connected 1\n")
+}
+
+func TestPrometheusPushGatewayLabels(t *testing.T) {
+	t.Cleanup(func() {
+		pushgatewayLabels = map[string]string{}
+		pushgatewayGrouping = map[string]string{}
+	})
+
+	promOverview := overview
+	promOverview.Peers = peersStateOutput{
+		Total:     1,
+		Connected: 1,
+		Details: []peerStateDetailOutput{
+			{FQDN: "peer-1.awesome-domain.com", IP: "192.168.178.101", Status: "Connected", ConnType: "P2P"},
+		},
+	}
+
+	metrics := parseToPrometheus(promOverview, map[string]string{"region": "eu-west-1", "environment": "prod"})
+	assert.Contains(t, metrics, "netbird_peer_connected{fqdn=\"peer-1.awesome-domain.com\",ip=\"192.168.178.101\",conn_type=\"P2P\",environment=\"prod\",region=\"eu-west-1\"} 1\n")
+	assert.Contains(t, metrics, "netbird_peers_total{environment=\"prod\",region=\"eu-west-1\"} 1\n")
+
+	assert.Equal(t, "http://localhost:9091/metrics/job/netbird/environment/prod/instance/host-1",
+		pushgatewayURL("http://localhost:9091", "netbird", pushgatewayGroupingKey(map[string]string{"instance": "host-1"}, map[string]string{"environment": "prod"})))
+
+	pushgatewayLabels = map[string]string{"environment": "prod", "_region": "eu-west-1"}
+	pushgatewayGrouping = map[string]string{"environment": "prod"}
+	assert.NoError(t, parsePushgatewayLabels())
+
+	for _, labels := range []map[string]string{
+		{"1env": "prod"},
+		{"env-name": "prod"},
+		{"__name__": "prod"},
+		{"fqdn": "prod"},
+		{"environment": "staging"},
+	} {
+		pushgatewayLabels = labels
+		assert.Error(t, parsePushgatewayLabels(), labels)
+	}
+}
+
+func TestPushgatewayURL(t *testing.T) {
+	assert.Equal(t, "http://localhost:9091/metrics/job/netbird", pushgatewayURL("http://localhost:9091/", "netbird", nil))
+	assert.Equal(t, "http://localhost:9091/metrics/job/netbird/instance/host-1/path@base64/L3Zhci9sb2c",
+		pushgatewayURL("http://localhost:9091", "netbird", map[string]string{"path": "/var/log", "instance": "host-1"}))
+	assert.Equal(t, "http://localhost:9091/metrics/job/netbird/env@base64/=",
+		pushgatewayURL("http://localhost:9091", "netbird", map[string]string{"env": ""}))
+}
+
+func TestPushToPushgateway(t *testing.T) {
+	var received, path, contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		path = r.URL.Path
+		contentType = r.Header.Get("Content-Type")
+		if user, password, ok := r.BasicAuth(); !ok || user != "user" || password != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte("unauthorized"))
+		}
+	}))
+	defer server.Close()
+
+	err := pushToPushgateway(context.Background(), server.URL, "netbird", map[string]string{"instance": "host-1"}, "user", "pass", "netbird_peers_total 2\n")
+	require.NoError(t, err)
+	assert.Equal(t, "netbird_peers_total 2\n", received)
+	assert.Equal(t, "/metrics/job/netbird/instance/host-1", path)
+	assert.Equal(t, prometheusContentType, contentType)
+
+	err = pushToPushgateway(context.Background(), server.URL, "netbird", nil, "user", "wrong", "netbird_peers_total 2\n")
+	assert.ErrorContains(t, err, "401 Unauthorized: unauthorized")
+}
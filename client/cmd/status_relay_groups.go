@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/pion/stun/v2"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+const relayCandidateType = "relay"
+
+type relaySummaryOutput struct {
+	Relays       map[string]int `json:"relays" yaml:"relays"`
+	Direct       int            `json:"direct" yaml:"direct"`
+	NotConnected int            `json:"notConnected" yaml:"notConnected"`
+}
+
+// relayHostsByIP maps the addresses of the configured TURN servers to their host names
+func relayHostsByIP(relays relayStateOutput, lookup func(host string) ([]string, error)) map[string]string {
+	hosts := make(map[string]string)
+	for _, relayState := range relays.Details {
+		uri, err := stun.ParseURI(relayState.URI)
+		if err != nil || (uri.Scheme != stun.SchemeTypeTURN && uri.Scheme != stun.SchemeTypeTURNS) {
+			continue
+		}
+
+		if net.ParseIP(uri.Host) != nil {
+			hosts[uri.Host] = uri.Host
+			continue
+		}
+
+		addrs, err := lookup(uri.Host)
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			hosts[addr] = uri.Host
+		}
+	}
+	return hosts
+}
+
+// relayServerOf returns the relay server a connected peer goes through, empty for direct and not connected peers.
+// The relayed endpoint is allocated on the TURN server, so its address identifies the server
+func relayServerOf(peerState peerStateDetailOutput, relayHosts map[string]string) string {
+	if peerState.Status != peer.StatusConnected.String() {
+		return ""
+	}
+
+	var endpoint string
+	switch {
+	case peerState.IceCandidateType.Local == relayCandidateType:
+		endpoint = peerState.IceCandidateEndpoint.Local
+	case peerState.IceCandidateType.Remote == relayCandidateType:
+		endpoint = peerState.IceCandidateEndpoint.Remote
+	default:
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		host = endpoint
+	}
+	if name, ok := relayHosts[host]; ok {
+		return name
+	}
+	return host
+}
+
+// aggregateByRelay sets the relay server of every peer and summarizes the number of peers per relay
+func aggregateByRelay(overview *statusOutputOverview, relayHosts map[string]string) {
+	summary := &relaySummaryOutput{Relays: map[string]int{}}
+	for i, peerState := range overview.Peers.Details {
+		relayServer := relayServerOf(peerState, relayHosts)
+		overview.Peers.Details[i].RelayServer = relayServer
+
+		switch {
+		case relayServer != "":
+			summary.Relays[relayServer]++
+		case peerState.Status == peer.StatusConnected.String():
+			summary.Direct++
+		default:
+			summary.NotConnected++
+		}
+	}
+	overview.RelaySummary = summary
+}
+
+// parseRelayGroupedPeers renders a group of peers per relay server, followed by the direct and the not connected peers
+func parseRelayGroupedPeers(peers peersStateOutput, rosenpassEnabled, rosenpassPermissive bool) string {
+	relayPeers := make(map[string]string)
+	relayCounts := make(map[string]int)
+	var directString, notConnectedString string
+	for _, peerState := range peers.Details {
+		peerString := parsePeer(peerState, rosenpassEnabled, rosenpassPermissive)
+		switch {
+		case peerState.RelayServer != "":
+			relayPeers[peerState.RelayServer] += peerString
+			relayCounts[peerState.RelayServer]++
+		case peerState.Status == peer.StatusConnected.String():
+			directString += peerString
+		default:
+			notConnectedString += peerString
+		}
+	}
+
+	relays := make([]string, 0, len(relayPeers))
+	for relay := range relayPeers {
+		relays = append(relays, relay)
+	}
+	sort.Strings(relays)
+
+	var output string
+	for _, relay := range relays {
+		output += fmt.Sprintf("\n--- Relay: %s (%d peers) ---%s\n", relay, relayCounts[relay], relayPeers[relay])
+	}
+	if directString != "" {
+		output += fmt.Sprintf("\n--- Direct (P2P) ---%s\n", directString)
+	}
+	if notConnectedString != "" {
+		output += fmt.Sprintf("\n--- Not connected ---%s\n", notConnectedString)
+	}
+	return output
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelayHostsByIP(t *testing.T) {
+	relays := relayStateOutput{
+		Details: []relayStateOutputDetail{
+			{URI: "stun:stun.example.com:3478"},
+			{URI: "turn:turn.example.com:3478?transport=udp"},
+			{URI: "turns:10.0.0.1:5349"},
+			{URI: "turn:unresolvable.example.com:3478"},
+		},
+	}
+
+	lookup := func(host string) ([]string, error) {
+		if host == "turn.example.com" {
+			return []string{"203.0.113.10", "2001:db8::10"}, nil
+		}
+		return nil, fmt.Errorf("no such host")
+	}
+
+	expected := map[string]string{
+		"203.0.113.10": "turn.example.com",
+		"2001:db8::10": "turn.example.com",
+		"10.0.0.1":     "10.0.0.1",
+	}
+	assert.Equal(t, expected, relayHostsByIP(relays, lookup))
+}
+
+func TestAggregateByRelay(t *testing.T) {
+	relayHosts := map[string]string{"203.0.113.10": "turn.example.com"}
+	overview := statusOutputOverview{
+		Peers: peersStateOutput{
+			Details: []peerStateDetailOutput{
+				{
+					FQDN:                 "relayed-local.netbird.cloud",
+					Status:               "Connected",
+					IceCandidateType:     iceCandidateType{Local: "relay", Remote: "host"},
+					IceCandidateEndpoint: iceCandidateType{Local: "203.0.113.10:51000", Remote: "192.168.1.2:51820"},
+				},
+				{
+					FQDN:                 "relayed-remote.netbird.cloud",
+					Status:               "Connected",
+					IceCandidateType:     iceCandidateType{Local: "srflx", Remote: "relay"},
+					IceCandidateEndpoint: iceCandidateType{Local: "1.2.3.4:51820", Remote: "198.51.100.7:52000"},
+				},
+				{
+					FQDN:             "direct.netbird.cloud",
+					Status:           "Connected",
+					IceCandidateType: iceCandidateType{Local: "host", Remote: "host"},
+				},
+				{
+					FQDN:   "idle.netbird.cloud",
+					Status: "Idle",
+				},
+			},
+		},
+	}
+
+	aggregateByRelay(&overview, relayHosts)
+
+	assert.Equal(t, "turn.example.com", overview.Peers.Details[0].RelayServer)
+	assert.Equal(t, "198.51.100.7", overview.Peers.Details[1].RelayServer)
+	assert.Empty(t, overview.Peers.Details[2].RelayServer)
+	assert.Empty(t, overview.Peers.Details[3].RelayServer)
+	assert.Equal(t, &relaySummaryOutput{
+		Relays:       map[string]int{"turn.example.com": 1, "198.51.100.7": 1},
+		Direct:       1,
+		NotConnected: 1,
+	}, overview.RelaySummary)
+
+	output := parseRelayGroupedPeers(overview.Peers, false, false)
+	assert.Regexp(t, `(?s)^\n--- Relay: 198\.51\.100\.7 \(1 peers\) ---\n relayed-remote.*`+
+		`--- Relay: turn\.example\.com \(1 peers\) ---\n relayed-local.*`+
+		`--- Direct \(P2P\) ---\n direct.*`+
+		`--- Not connected ---\n idle`, output)
+}
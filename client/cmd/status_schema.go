@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/netbirdio/netbird/version"
+)
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// jsonSchema is the subset of the JSON Schema vocabulary needed to describe the status output
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	ID                   string                 `json:"$id,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 interface{}            `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+}
+
+// parseToJSONSchema renders the JSON Schema of the --json status output, versioned with the CLI version
+func parseToJSONSchema() (string, error) {
+	schema := reflectJSONSchema(reflect.TypeOf(statusOutputOverview{}))
+	schema.Schema = jsonSchemaDraft
+	schema.ID = fmt.Sprintf("https://netbird.io/schemas/status/%s.json", version.NetbirdVersion())
+	schema.Title = "NetBird status"
+	schema.Description = "Output of netbird status --json"
+
+	jsonBytes, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("json marshal failed")
+	}
+	return string(jsonBytes) + "\n", nil
+}
+
+// reflectJSONSchema describes how encoding/json marshals values of the given type
+func reflectJSONSchema(t reflect.Type) *jsonSchema {
+	switch t {
+	case timeType:
+		return &jsonSchema{Type: "string", Format: "date-time"}
+	case durationType:
+		return &jsonSchema{Type: "integer", Description: "duration in nanoseconds"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := reflectJSONSchema(t.Elem())
+		if schemaType, ok := schema.Type.(string); ok {
+			schema.Type = []interface{}{schemaType, "null"}
+		}
+		return schema
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &jsonSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}
+	case reflect.String:
+		return &jsonSchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &jsonSchema{Type: []interface{}{"array", "null"}, Items: reflectJSONSchema(t.Elem())}
+	case reflect.Map:
+		return &jsonSchema{Type: []interface{}{"object", "null"}, AdditionalProperties: reflectJSONSchema(t.Elem())}
+	case reflect.Struct:
+		schema := &jsonSchema{Type: "object", Properties: map[string]*jsonSchema{}, AdditionalProperties: false}
+		addStructProperties(schema, t)
+		return schema
+	default:
+		return &jsonSchema{}
+	}
+}
+
+// addStructProperties adds the exported fields of the struct to the schema, inlining embedded structs as encoding/json does
+func addStructProperties(schema *jsonSchema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			addStructProperties(schema, field.Type)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = reflectJSONSchema(field.Type)
+		if !strings.Contains(options, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/netbirdio/netbird/version"
+)
+
+func TestParsingToJSONSchema(t *testing.T) {
+	schemaString, err := parseToJSONSchema()
+	require.NoError(t, err)
+
+	var schema jsonSchema
+	require.NoError(t, json.Unmarshal([]byte(schemaString), &schema))
+
+	assert.Equal(t, jsonSchemaDraft, schema.Schema)
+	assert.Contains(t, schema.ID, version.NetbirdVersion())
+	assert.Equal(t, "object", schema.Type)
+	assert.Contains(t, schema.Required, "peers")
+	assert.NotContains(t, schema.Required, "osInfo")
+
+	details := schema.Properties["peers"].Properties["details"]
+	require.NotNil(t, details)
+	require.NotNil(t, details.Items)
+	assert.Equal(t, "date-time", details.Items.Properties["lastStatusUpdate"].Format)
+	assert.Equal(t, "integer", details.Items.Properties["transferSent"].Type)
+	assert.Equal(t, []interface{}{"object", "null"}, schema.Properties["osInfo"].Type)
+}
+
+func TestJSONSchemaCoversJSONOutput(t *testing.T) {
+	schemaString, err := parseToJSONSchema()
+	require.NoError(t, err)
+
+	var schema jsonSchema
+	require.NoError(t, json.Unmarshal([]byte(schemaString), &schema))
+
+	jsonString, err := parseToJSON(overview)
+	require.NoError(t, err)
+
+	var output map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(jsonString), &output))
+
+	for key := range output {
+		assert.Contains(t, schema.Properties, key)
+	}
+	for _, key := range schema.Required {
+		assert.Contains(t, output, key)
+	}
+}
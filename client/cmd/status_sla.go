@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+type latencySLAResult struct {
+	Peer         string `json:"peer" yaml:"peer"`
+	MaxLatencyMs int64  `json:"maxLatencyMs" yaml:"maxLatencyMs"`
+	LatencyMs    int64  `json:"latencyMs" yaml:"latencyMs"`
+	Pass         bool   `json:"pass" yaml:"pass"`
+	Message      string `json:"message" yaml:"message"`
+}
+
+type latencySLAOutput struct {
+	Results []latencySLAResult `json:"results" yaml:"results"`
+}
+
+func parseLatencySLAs() error {
+	for name, maxLatency := range latencySLAFlag {
+		if maxLatency <= 0 {
+			return fmt.Errorf("wrong latency SLA of %s, should be a positive number of milliseconds, got: %d", name, maxLatency)
+		}
+	}
+	return nil
+}
+
+// checkLatencySLAs compares the latency of every named peer with its maximum in milliseconds. Peers are matched by
+// FQDN or hostname, unknown and disconnected peers and peers without latency measurement fail
+func checkLatencySLAs(peers []peerStateDetailOutput, slas map[string]int64) []latencySLAResult {
+	names := make([]string, 0, len(slas))
+	for name := range slas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	results := make([]latencySLAResult, 0, len(names))
+	for _, name := range names {
+		result := latencySLAResult{Peer: name, MaxLatencyMs: slas[name]}
+
+		selected, err := selectPeersByName(peers, []string{name})
+		switch {
+		case err != nil:
+			result.Message = "peer not found"
+		case selected[0].Status != peer.StatusConnected.String():
+			result.Message = fmt.Sprintf("peer is %s", strings.ToLower(selected[0].Status))
+		case selected[0].Latency <= 0:
+			result.Message = "no latency measured"
+		default:
+			result.LatencyMs = selected[0].Latency.Milliseconds()
+			result.Pass = result.LatencyMs <= result.MaxLatencyMs
+			operator := "<="
+			if !result.Pass {
+				operator = ">"
+			}
+			result.Message = fmt.Sprintf("%dms %s %dms SLA", result.LatencyMs, operator, result.MaxLatencyMs)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func failedLatencySLAErr(results []latencySLAResult) error {
+	var failed int
+	for _, result := range results {
+		if !result.Pass {
+			failed++
+		}
+	}
+	if failed == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d latency SLAs failed", failed, len(results))
+}
+
+func parseLatencySLAResults(results []latencySLAResult) string {
+	var output strings.Builder
+	for _, result := range results {
+		verdict := "PASS"
+		if !result.Pass {
+			verdict = "FAIL"
+		}
+		output.WriteString(fmt.Sprintf("%s: %s: %s\n", verdict, result.Peer, result.Message))
+	}
+	return output.String()
+}
+
+func parseLatencySLAResultsToJSON(results []latencySLAResult) (string, error) {
+	jsonBytes, err := json.Marshal(latencySLAOutput{Results: results})
+	if err != nil {
+		return "", fmt.Errorf("json marshal failed")
+	}
+	return string(jsonBytes), nil
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckLatencySLAs(t *testing.T) {
+	peers := []peerStateDetailOutput{
+		{FQDN: "db.awesome-domain.com", Status: "Connected", Latency: 47 * time.Millisecond},
+		{FQDN: "api.awesome-domain.com", Status: "Connected", Latency: 12 * time.Millisecond},
+		{FQDN: "cache.awesome-domain.com", Status: "Connected"},
+		{FQDN: "backup.awesome-domain.com", Status: "Idle"},
+	}
+
+	results := checkLatencySLAs(peers, map[string]int64{
+		"db.awesome-domain.com": 10,
+		"api":                   50,
+		"cache":                 10,
+		"backup":                10,
+		"unknown":               10,
+	})
+	require.Len(t, results, 5)
+
+	assert.Equal(t, "PASS: api: 12ms <= 50ms SLA\n"+
+		"FAIL: backup: peer is idle\n"+
+		"FAIL: cache: no latency measured\n"+
+		"FAIL: db.awesome-domain.com: 47ms > 10ms SLA\n"+
+		"FAIL: unknown: peer not found\n", parseLatencySLAResults(results))
+	assert.EqualError(t, failedLatencySLAErr(results), "4 of 5 latency SLAs failed")
+	assert.NoError(t, failedLatencySLAErr(results[:1]))
+
+	jsonString, err := parseLatencySLAResultsToJSON(results[:1])
+	require.NoError(t, err)
+	assert.Equal(t, `{"results":[{"peer":"api","maxLatencyMs":50,"latencyMs":12,"pass":true,"message":"12ms \u003c= 50ms SLA"}]}`, jsonString)
+}
+
+func TestParseLatencySLAs(t *testing.T) {
+	t.Cleanup(func() {
+		latencySLAFlag = map[string]int64{}
+	})
+
+	latencySLAFlag = map[string]int64{"db": 10}
+	assert.NoError(t, parseLatencySLAs())
+
+	latencySLAFlag = map[string]int64{"db": 0}
+	assert.Error(t, parseLatencySLAs())
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+const (
+	// slackMaxBlocks is the maximum number of blocks Slack accepts in a message
+	slackMaxBlocks         = 50
+	slackConnectedEmoji    = ":white_check_mark:"
+	slackDisconnectedEmoji = ":warning:"
+)
+
+// slackMessage is a Block Kit message as accepted by Slack incoming webhooks, the text is the notification fallback
+type slackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// parseToSlack renders a Block Kit message with a header, a section per peer and a context with the local peer and
+// the time. When the peers don't fit in slackMaxBlocks, the last section summarizes the remaining ones
+func parseToSlack(overview statusOutputOverview, now time.Time) (string, error) {
+	summary := fmt.Sprintf("%d/%d peers connected", overview.Peers.Connected, overview.Peers.Total)
+	blocks := []slackBlock{{Type: "header", Text: &slackText{Type: "plain_text", Text: summary}}}
+
+	peerBlocks := slackMaxBlocks - 2
+	for i, peerState := range overview.Peers.Details {
+		remaining := len(overview.Peers.Details) - i
+		if i == peerBlocks-1 && remaining > 1 {
+			blocks = append(blocks, slackSection(slackRemainingPeers(overview.Peers.Details[i:])))
+			break
+		}
+
+		connection := fmt.Sprintf("%s %s", slackDisconnectedEmoji, strings.ToLower(peerState.Status))
+		if peerState.Status == peer.StatusConnected.String() {
+			connection = fmt.Sprintf("%s %s", slackConnectedEmoji, peerState.ConnType)
+			if peerState.Latency > 0 {
+				connection += ", " + peerState.Latency.String()
+			}
+		}
+		blocks = append(blocks, slackSection(fmt.Sprintf("*%s*\n`%s` %s", slackEscape(peerState.FQDN), slackEscape(peerState.IP), connection)))
+	}
+
+	blocks = append(blocks, slackBlock{
+		Type: "context",
+		Elements: []slackText{{
+			Type: "mrkdwn",
+			Text: fmt.Sprintf("%s · <!date^%d^{date_short_pretty} {time}|%s>", slackEscape(overview.FQDN), now.Unix(), now.UTC().Format(time.RFC3339)),
+		}},
+	})
+
+	jsonBytes, err := json.Marshal(slackMessage{
+		Text:   fmt.Sprintf("%s on %s", summary, overview.FQDN),
+		Blocks: blocks,
+	})
+	if err != nil {
+		return "", fmt.Errorf("json marshal failed")
+	}
+	return string(jsonBytes) + "\n", nil
+}
+
+func slackSection(text string) slackBlock {
+	return slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: text}}
+}
+
+func slackRemainingPeers(peers []peerStateDetailOutput) string {
+	var disconnected int
+	for _, peerState := range peers {
+		if peerState.Status != peer.StatusConnected.String() {
+			disconnected++
+		}
+	}
+	summary := fmt.Sprintf("... %d more peers", len(peers))
+	if disconnected > 0 {
+		summary += fmt.Sprintf(", %s %d not connected", slackDisconnectedEmoji, disconnected)
+	}
+	return summary
+}
+
+// slackEscape escapes the characters Slack reserves for its mrkdwn control sequences
+func slackEscape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+// postToSlack sends the message to a Slack incoming webhook, which answers accepted messages with 200. The webhook
+// URL is a secret, it is left out of the errors
+func postToSlack(ctx context.Context, url, message string) error {
+	return postStatusOutput(ctx, "Slack webhook", url, "application/json", nil, message)
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsingToSlack(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	slackOverview := overview
+	slackOverview.Peers = peersStateOutput{
+		Total:     2,
+		Connected: 1,
+		Details: []peerStateDetailOutput{
+			{FQDN: "peer-1.awesome-domain.com", IP: "192.168.178.101", Status: "Connected", ConnType: "P2P", Latency: 5 * time.Millisecond},
+			{FQDN: "peer-<2>.awesome-domain.com", IP: "192.168.178.102", Status: "Idle"},
+		},
+	}
+
+	expected := `{"text":"1/2 peers connected on some-localhost.awesome-domain.com","blocks":[` +
+		`{"type":"header","text":{"type":"plain_text","text":"1/2 peers connected"}},` +
+		`{"type":"section","text":{"type":"mrkdwn","text":"*peer-1.awesome-domain.com*\n` + "`192.168.178.101`" + ` :white_check_mark: P2P, 5ms"}},` +
+		`{"type":"section","text":{"type":"mrkdwn","text":"*peer-\u0026lt;2\u0026gt;.awesome-domain.com*\n` + "`192.168.178.102`" + ` :warning: idle"}},` +
+		`{"type":"context","elements":[{"type":"mrkdwn","text":"some-localhost.awesome-domain.com · \u003c!date^1704067200^{date_short_pretty} {time}|2024-01-01T00:00:00Z\u003e"}]}]}` + "\n"
+
+	message, err := parseToSlack(slackOverview, now)
+	require.NoError(t, err)
+	assert.Equal(t, expected, message)
+}
+
+func TestParsingToSlackBlockLimit(t *testing.T) {
+	slackOverview := overview
+	slackOverview.Peers = peersStateOutput{}
+	for i := 0; i < 60; i++ {
+		status := "Connected"
+		if i%2 == 0 {
+			status = "Idle"
+		}
+		slackOverview.Peers.Details = append(slackOverview.Peers.Details, peerStateDetailOutput{
+			FQDN:   fmt.Sprintf("peer-%d.awesome-domain.com", i),
+			IP:     fmt.Sprintf("100.64.0.%d", i),
+			Status: status,
+		})
+	}
+
+	message, err := parseToSlack(slackOverview, time.Now())
+	require.NoError(t, err)
+
+	var parsed slackMessage
+	require.NoError(t, json.Unmarshal([]byte(message), &parsed))
+	require.Len(t, parsed.Blocks, slackMaxBlocks)
+	assert.Equal(t, "... 13 more peers, :warning: 6 not connected", parsed.Blocks[slackMaxBlocks-2].Text.Text)
+	assert.Equal(t, "context", parsed.Blocks[slackMaxBlocks-1].Type)
+
+	slackOverview.Peers.Details = slackOverview.Peers.Details[:slackMaxBlocks-2]
+	message, err = parseToSlack(slackOverview, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(message), &parsed))
+	require.Len(t, parsed.Blocks, slackMaxBlocks)
+	assert.Contains(t, parsed.Blocks[slackMaxBlocks-2].Text.Text, "*peer-47.awesome-domain.com*")
+}
+
+func TestPostToSlack(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	err := postToSlack(context.Background(), server.URL, "{\"text\":\"1/2 peers connected\"}\n")
+	require.NoError(t, err)
+	assert.Equal(t, "{\"text\":\"1/2 peers connected\"}\n", received)
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("invalid_blocks"))
+	})
+	err = postToSlack(context.Background(), server.URL, "{}\n")
+	assert.ErrorContains(t, err, "400 Bad Request: invalid_blocks")
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	splunkHECSource     = "netbird"
+	splunkHECSourceType = "netbird:peer"
+)
+
+type splunkHECEvent struct {
+	Time       int64                 `json:"time"`
+	Host       string                `json:"host"`
+	Source     string                `json:"source"`
+	SourceType string                `json:"sourcetype"`
+	Event      peerStateDetailOutput `json:"event"`
+}
+
+// parseToSplunkHEC renders one Splunk HTTP Event Collector event per peer. The events are newline separated,
+// which is the batch format accepted by the HEC event endpoint
+func parseToSplunkHEC(overview statusOutputOverview, now time.Time) (string, error) {
+	var events strings.Builder
+	for _, peerState := range overview.Peers.Details {
+		jsonBytes, err := json.Marshal(splunkHECEvent{
+			Time:       now.Unix(),
+			Host:       overview.FQDN,
+			Source:     splunkHECSource,
+			SourceType: splunkHECSourceType,
+			Event:      peerState,
+		})
+		if err != nil {
+			return "", fmt.Errorf("json marshal failed")
+		}
+		events.Write(jsonBytes)
+		events.WriteString("\n")
+	}
+	return events.String(), nil
+}
+
+// postToSplunkHEC sends the events to the HEC endpoint authenticating with the given token
+func postToSplunkHEC(ctx context.Context, url, token, events string) error {
+	headers := map[string]string{}
+	if token != "" {
+		headers["Authorization"] = "Splunk " + token
+	}
+	return postStatusOutput(ctx, "Splunk HEC "+url, url, "application/json", headers, events)
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsingToSplunkHEC(t *testing.T) {
+	hecOverview := overview
+	hecOverview.Peers = peersStateOutput{
+		Details: []peerStateDetailOutput{
+			{FQDN: "peer-1.awesome-domain.com", IP: "192.168.178.101", Status: "Connected"},
+			{FQDN: "peer-2.awesome-domain.com", IP: "192.168.178.102", Status: "Disconnected"},
+		},
+	}
+
+	events, err := parseToSplunkHEC(hecOverview, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(events, "\n"), "\n")
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], `{"time":1704067200,"host":"some-localhost.awesome-domain.com","source":"netbird","sourcetype":"netbird:peer","event":{"fqdn":"peer-1.awesome-domain.com","netbirdIp":"192.168.178.101"`), lines[0])
+	assert.Contains(t, lines[1], `"status":"Disconnected"`)
+}
+
+func TestPostToSplunkHEC(t *testing.T) {
+	var received, authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		authorization = r.Header.Get("Authorization")
+		if authorization != "Splunk valid-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"text":"Invalid token","code":4}`))
+		}
+	}))
+	defer server.Close()
+
+	err := postToSplunkHEC(context.Background(), server.URL, "valid-token", "{\"event\":{}}\n")
+	require.NoError(t, err)
+	assert.Equal(t, "{\"event\":{}}\n", received)
+
+	err = postToSplunkHEC(context.Background(), server.URL, "wrong-token", "{\"event\":{}}\n")
+	assert.ErrorContains(t, err, "403 Forbidden: {\"text\":\"Invalid token\",\"code\":4}")
+}
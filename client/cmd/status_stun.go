@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pion/stun/v2"
+
+	"github.com/netbirdio/netbird/client/internal/relay"
+)
+
+const stunCheckTimeout = 2 * time.Second
+
+type stunServerCheckOutput struct {
+	URI           string        `json:"uri" yaml:"uri"`
+	Reachable     bool          `json:"reachable" yaml:"reachable"`
+	ResponseTime  time.Duration `json:"responseTime" yaml:"responseTime"`
+	MappedAddress string        `json:"mappedAddress" yaml:"mappedAddress"`
+	Error         string        `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+type stunCheckOutput struct {
+	Servers      []stunServerCheckOutput `json:"servers" yaml:"servers"`
+	SymmetricNAT *bool                   `json:"symmetricNat" yaml:"symmetricNat"`
+}
+
+// runSTUNCheck sends a STUN binding request to every STUN server reported by the daemon
+func runSTUNCheck(ctx context.Context, relays relayStateOutput) (*stunCheckOutput, error) {
+	var uris []*stun.URI
+	for _, relayState := range relays.Details {
+		uri, err := stun.ParseURI(relayState.URI)
+		if err != nil || uri.Scheme != stun.SchemeTypeSTUN {
+			continue
+		}
+		uris = append(uris, uri)
+	}
+
+	results, err := relay.CheckSTUN(ctx, uris, stunCheckTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed running the STUN check: %v", err)
+	}
+
+	return mapSTUNCheck(results), nil
+}
+
+func mapSTUNCheck(results []relay.STUNCheckResult) *stunCheckOutput {
+	check := &stunCheckOutput{
+		Servers: make([]stunServerCheckOutput, 0, len(results)),
+	}
+
+	for _, result := range results {
+		server := stunServerCheckOutput{
+			URI:           result.URI.String(),
+			Reachable:     result.Err == nil,
+			ResponseTime:  result.ResponseTime,
+			MappedAddress: result.MappedAddr,
+		}
+		if result.Err != nil {
+			server.Error = result.Err.Error()
+		}
+		check.Servers = append(check.Servers, server)
+	}
+
+	if symmetric, ok := relay.IsSymmetricNAT(results); ok {
+		check.SymmetricNAT = &symmetric
+	}
+
+	return check
+}
+
+func parseSTUNCheck(check *stunCheckOutput) string {
+	summary := "STUN check:\n"
+	if len(check.Servers) == 0 {
+		summary += "  No STUN servers configured\n"
+	}
+
+	for _, server := range check.Servers {
+		if !server.Reachable {
+			summary += fmt.Sprintf("  [%s] is Unreachable, reason: %s\n", server.URI, server.Error)
+			continue
+		}
+		summary += fmt.Sprintf("  [%s] responded in %s, mapped address: %s\n",
+			server.URI, server.ResponseTime.Round(time.Millisecond), server.MappedAddress)
+	}
+
+	symmetricNAT := "unknown, at least two STUN servers need to respond"
+	if check.SymmetricNAT != nil {
+		symmetricNAT = fmt.Sprintf("%t", *check.SymmetricNAT)
+	}
+	summary += fmt.Sprintf("  Symmetric NAT: %s\n", symmetricNAT)
+
+	return summary
+}
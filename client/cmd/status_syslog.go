@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+const (
+	syslogVersion        = 1
+	syslogFacilityDaemon = 3
+	syslogSeverityError  = 3
+	syslogSeverityWarn   = 4
+	syslogSeverityInfo   = 6
+	syslogAppName        = "netbird"
+	syslogMsgID          = "STATUS"
+	syslogNilValue       = "-"
+	// syslogPeerSDIDFormat numbers the peer elements since an SD-ID can appear only once in a message, RFC 5424
+	// Section 6.3.2. It uses the private enterprise number reserved for documentation by RFC 5612
+	syslogPeerSDIDFormat = "peer%d@32473"
+)
+
+var syslogParamEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+// parseToSyslog renders the status as a single RFC 5424 message with a numbered structured data element per peer. The
+// severity is informational when all peers are connected, warning when some are and error when none is
+func parseToSyslog(overview statusOutputOverview, now time.Time) string {
+	severity := syslogSeverityInfo
+	switch {
+	case overview.Peers.Total > 0 && overview.Peers.Connected == 0:
+		severity = syslogSeverityError
+	case overview.Peers.Connected < overview.Peers.Total:
+		severity = syslogSeverityWarn
+	}
+
+	hostname := overview.FQDN
+	if hostname == "" {
+		hostname = syslogNilValue
+	}
+
+	var structuredData strings.Builder
+	for i, peerState := range overview.Peers.Details {
+		structuredData.WriteString(fmt.Sprintf(`[%s fqdn="%s" ip="%s" connected="%t" latency_ms="%d"]`,
+			fmt.Sprintf(syslogPeerSDIDFormat, i+1),
+			syslogParamEscaper.Replace(peerState.FQDN),
+			syslogParamEscaper.Replace(peerState.IP),
+			peerState.Status == peer.StatusConnected.String(),
+			peerState.Latency.Milliseconds(),
+		))
+	}
+	if structuredData.Len() == 0 {
+		structuredData.WriteString(syslogNilValue)
+	}
+
+	return fmt.Sprintf("<%d>%d %s %s %s %s %s %s %d/%d peers connected\n",
+		syslogFacilityDaemon*8+severity,
+		syslogVersion,
+		now.Format(time.RFC3339),
+		hostname,
+		syslogAppName,
+		syslogNilValue,
+		syslogMsgID,
+		structuredData.String(),
+		overview.Peers.Connected,
+		overview.Peers.Total,
+	)
+}
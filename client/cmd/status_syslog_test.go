@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsingToSyslog(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	syslogOverview := overview
+	syslogOverview.Peers = peersStateOutput{
+		Total:     2,
+		Connected: 1,
+		Details: []peerStateDetailOutput{
+			{FQDN: "peer-1.awesome-domain.com", IP: "192.168.178.101", Status: "Connected", Latency: 5 * time.Millisecond},
+			{FQDN: `peer-"2"].awesome-domain.com`, IP: "192.168.178.102", Status: "Idle"},
+		},
+	}
+
+	expected := `<28>1 2024-01-01T00:00:00Z some-localhost.awesome-domain.com netbird - STATUS ` +
+		`[peer1@32473 fqdn="peer-1.awesome-domain.com" ip="192.168.178.101" connected="true" latency_ms="5"]` +
+		`[peer2@32473 fqdn="peer-\"2\"\].awesome-domain.com" ip="192.168.178.102" connected="false" latency_ms="0"] 1/2 peers connected` + "\n"
+	assert.Equal(t, expected, parseToSyslog(syslogOverview, now))
+
+	syslogOverview.Peers.Connected = 0
+	assert.Regexp(t, `^<27>1 `, parseToSyslog(syslogOverview, now), "no connected peer is an error")
+
+	syslogOverview.Peers = peersStateOutput{}
+	assert.Equal(t, "<30>1 2024-01-01T00:00:00Z some-localhost.awesome-domain.com netbird - STATUS - 0/0 peers connected\n", parseToSyslog(syslogOverview, now))
+}
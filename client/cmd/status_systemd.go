@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+// systemdStatusMaxLength is the length systemd keeps of the STATUS= of sd_notify
+const systemdStatusMaxLength = 2048
+
+// parseToSystemdStatus renders a status line followed by an indented line per peer, to be passed to
+// systemd-notify --status=. Peers that don't fit in systemdStatusMaxLength are summarized in a last line. It returns
+// whether the daemon is ready, i.e., management and signal are connected
+func parseToSystemdStatus(overview statusOutputOverview) (string, bool) {
+	ready := overview.ManagementState.Connected && overview.SignalState.Connected
+	state := "ready"
+	if !ready {
+		state = "not ready"
+	}
+	header := fmt.Sprintf("NetBird %s: management %s, signal %s, %d/%d peers connected\n", state,
+		systemdConnected(overview.ManagementState.Connected), systemdConnected(overview.SignalState.Connected),
+		overview.Peers.Connected, overview.Peers.Total)
+
+	lines := make([]string, 0, len(overview.Peers.Details))
+	for _, peerState := range overview.Peers.Details {
+		line := fmt.Sprintf("  %s (%s): %s", peerState.FQDN, peerState.IP, strings.ToLower(peerState.Status))
+		if peerState.Status == peer.StatusConnected.String() {
+			line += ", " + peerState.ConnType
+			if peerState.Latency > 0 {
+				line += ", " + peerState.Latency.String()
+			}
+		}
+		lines = append(lines, line+"\n")
+	}
+
+	status := header
+	for i, line := range lines {
+		remaining := len(lines) - i
+		more := ""
+		if remaining > 1 {
+			more = fmt.Sprintf("  ... %d more peers\n", remaining-1)
+		}
+		if len(status)+len(line)+len(more) > systemdStatusMaxLength {
+			status += fmt.Sprintf("  ... %d more peers\n", remaining)
+			break
+		}
+		status += line
+	}
+	return status, ready
+}
+
+func systemdConnected(connected bool) string {
+	if connected {
+		return "connected"
+	}
+	return "disconnected"
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsingToSystemdStatus(t *testing.T) {
+	systemdOverview := overview
+	systemdOverview.Peers = peersStateOutput{
+		Total:     2,
+		Connected: 1,
+		Details: []peerStateDetailOutput{
+			{FQDN: "peer-1.awesome-domain.com", IP: "192.168.178.101", Status: "Connected", ConnType: "P2P", Latency: 5 * time.Millisecond},
+			{FQDN: "peer-2.awesome-domain.com", IP: "192.168.178.102", Status: "Idle"},
+		},
+	}
+
+	status, ready := parseToSystemdStatus(systemdOverview)
+	assert.True(t, ready)
+	assert.Equal(t, "NetBird ready: management connected, signal connected, 1/2 peers connected\n"+
+		"  peer-1.awesome-domain.com (192.168.178.101): connected, P2P, 5ms\n"+
+		"  peer-2.awesome-domain.com (192.168.178.102): idle\n", status)
+
+	systemdOverview.SignalState.Connected = false
+	status, ready = parseToSystemdStatus(systemdOverview)
+	assert.False(t, ready)
+	assert.True(t, strings.HasPrefix(status, "NetBird not ready: management connected, signal disconnected, 1/2 peers connected\n"), status)
+}
+
+func TestParsingToSystemdStatusTruncated(t *testing.T) {
+	systemdOverview := overview
+	systemdOverview.Peers = peersStateOutput{}
+	for i := 0; i < 100; i++ {
+		systemdOverview.Peers.Details = append(systemdOverview.Peers.Details, peerStateDetailOutput{
+			FQDN:   fmt.Sprintf("peer-%d.awesome-domain.com", i),
+			IP:     fmt.Sprintf("100.64.0.%d", i),
+			Status: "Idle",
+		})
+	}
+
+	status, _ := parseToSystemdStatus(systemdOverview)
+	assert.LessOrEqual(t, len(status), systemdStatusMaxLength)
+	lines := strings.Split(strings.TrimSuffix(status, "\n"), "\n")
+	shown := len(lines) - 2
+	assert.Equal(t, fmt.Sprintf("  ... %d more peers", 100-shown), lines[len(lines)-1])
+	assert.Equal(t, fmt.Sprintf("  peer-%d.awesome-domain.com (100.64.0.%d): idle", shown-1, shown-1), lines[len(lines)-2])
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+// telegrafMetric is a metric point as read by the Telegraf exec input plugin with the json data format
+type telegrafMetric struct {
+	Name      string                 `json:"name"`
+	Tags      map[string]string      `json:"tags"`
+	Fields    map[string]interface{} `json:"fields"`
+	Timestamp int64                  `json:"timestamp"`
+}
+
+// parseToTelegrafJSON renders one metric point per peer and a summary point as a JSON array
+func parseToTelegrafJSON(overview statusOutputOverview, now time.Time) (string, error) {
+	timestamp := now.UnixNano()
+	metrics := make([]telegrafMetric, 0, len(overview.Peers.Details)+1)
+
+	for _, peerState := range overview.Peers.Details {
+		metrics = append(metrics, telegrafMetric{
+			Name: "netbird_peer",
+			Tags: map[string]string{
+				"fqdn":      peerState.FQDN,
+				"ip":        peerState.IP,
+				"conn_type": peerState.ConnType,
+			},
+			Fields: map[string]interface{}{
+				"connected":      boolToInt(peerState.Status == peer.StatusConnected.String()),
+				"latency_ms":     peerState.Latency.Milliseconds(),
+				"bytes_sent":     peerState.TransferSent,
+				"bytes_received": peerState.TransferReceived,
+			},
+			Timestamp: timestamp,
+		})
+	}
+
+	metrics = append(metrics, telegrafMetric{
+		Name: "netbird_summary",
+		Tags: map[string]string{
+			"fqdn": overview.FQDN,
+		},
+		Fields: map[string]interface{}{
+			"peers_total":          overview.Peers.Total,
+			"peers_connected":      overview.Peers.Connected,
+			"management_connected": boolToInt(overview.ManagementState.Connected),
+			"signal_connected":     boolToInt(overview.SignalState.Connected),
+			"relays_available":     overview.Relays.Available,
+		},
+		Timestamp: timestamp,
+	})
+
+	jsonBytes, err := json.Marshal(metrics)
+	if err != nil {
+		return "", fmt.Errorf("json marshal failed")
+	}
+	return string(jsonBytes) + "\n", nil
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsingToTelegrafJSON(t *testing.T) {
+	telegrafOverview := overview
+	telegrafOverview.Peers = peersStateOutput{
+		Total:     2,
+		Connected: 1,
+		Details: []peerStateDetailOutput{
+			{
+				FQDN:             "peer-1.awesome-domain.com",
+				IP:               "192.168.178.101",
+				Status:           "Connected",
+				ConnType:         "P2P",
+				Latency:          12 * time.Millisecond,
+				TransferSent:     1234,
+				TransferReceived: 4321,
+			},
+			{
+				FQDN:   "peer-2.awesome-domain.com",
+				IP:     "192.168.178.102",
+				Status: "Disconnected",
+			},
+		},
+	}
+
+	telegrafJSON, err := parseToTelegrafJSON(telegrafOverview, time.Unix(0, 1000))
+	require.NoError(t, err)
+
+	expectedJSON := `[` +
+		`{"name":"netbird_peer","tags":{"conn_type":"P2P","fqdn":"peer-1.awesome-domain.com","ip":"192.168.178.101"},` +
+		`"fields":{"bytes_received":4321,"bytes_sent":1234,"connected":1,"latency_ms":12},"timestamp":1000},` +
+		`{"name":"netbird_peer","tags":{"conn_type":"","fqdn":"peer-2.awesome-domain.com","ip":"192.168.178.102"},` +
+		`"fields":{"bytes_received":0,"bytes_sent":0,"connected":0,"latency_ms":0},"timestamp":1000},` +
+		`{"name":"netbird_summary","tags":{"fqdn":"some-localhost.awesome-domain.com"},` +
+		`"fields":{"management_connected":1,"peers_connected":1,"peers_total":2,"relays_available":1,"signal_connected":1},"timestamp":1000}` +
+		`]` + "\n"
+
+	assert.Equal(t, expectedJSON, telegrafJSON)
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+// terraformOutput is a value in the format of terraform output -json, the type is the JSON encoding of the
+// Terraform type constraint
+type terraformOutput struct {
+	Sensitive bool `json:"sensitive"`
+	Type      any  `json:"type"`
+	Value     any  `json:"value"`
+}
+
+// parseToTerraformOutput renders the peer IPs and whether each peer is connected as the outputs peer_ips and
+// peer_connected, both are objects keyed by the peer FQDN
+func parseToTerraformOutput(overview statusOutputOverview) (string, error) {
+	ips := make(map[string]string, len(overview.Peers.Details))
+	ipTypes := make(map[string]string, len(overview.Peers.Details))
+	connected := make(map[string]bool, len(overview.Peers.Details))
+	connectedTypes := make(map[string]string, len(overview.Peers.Details))
+	for _, peerState := range overview.Peers.Details {
+		ips[peerState.FQDN] = peerState.IP
+		ipTypes[peerState.FQDN] = "string"
+		connected[peerState.FQDN] = peerState.Status == peer.StatusConnected.String()
+		connectedTypes[peerState.FQDN] = "bool"
+	}
+
+	outputs := map[string]terraformOutput{
+		"peer_ips":       {Type: []any{"object", ipTypes}, Value: ips},
+		"peer_connected": {Type: []any{"object", connectedTypes}, Value: connected},
+	}
+
+	jsonBytes, err := json.MarshalIndent(outputs, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("json marshal failed")
+	}
+	return string(jsonBytes) + "\n", nil
+}
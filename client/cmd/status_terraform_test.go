@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsingToTerraformOutput(t *testing.T) {
+	terraformOverview := overview
+	terraformOverview.Peers = peersStateOutput{
+		Details: []peerStateDetailOutput{
+			{FQDN: "peer-1.awesome-domain.com", IP: "192.168.178.101", Status: "Connected"},
+			{FQDN: "peer-2.awesome-domain.com", IP: "192.168.178.102", Status: "Idle"},
+		},
+	}
+
+	expected := `{
+  "peer_connected": {
+    "sensitive": false,
+    "type": [
+      "object",
+      {
+        "peer-1.awesome-domain.com": "bool",
+        "peer-2.awesome-domain.com": "bool"
+      }
+    ],
+    "value": {
+      "peer-1.awesome-domain.com": true,
+      "peer-2.awesome-domain.com": false
+    }
+  },
+  "peer_ips": {
+    "sensitive": false,
+    "type": [
+      "object",
+      {
+        "peer-1.awesome-domain.com": "string",
+        "peer-2.awesome-domain.com": "string"
+      }
+    ],
+    "value": {
+      "peer-1.awesome-domain.com": "192.168.178.101",
+      "peer-2.awesome-domain.com": "192.168.178.102"
+    }
+  }
+}
+`
+	terraformString, err := parseToTerraformOutput(terraformOverview)
+	require.NoError(t, err)
+	assert.Equal(t, expected, terraformString)
+}
@@ -2,12 +2,17 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/netbirdio/netbird/client/proto"
@@ -554,6 +559,294 @@ Peers count: 2/2 Connected
 	assert.Equal(t, expectedString, shortVersion)
 }
 
+func TestParsingOSInfo(t *testing.T) {
+	osInfoOverview := overview
+	osInfoOverview.OSInfo = &osInfoOutput{
+		OS:         "ubuntu 22.04",
+		Kernel:     "6.5.0-14-generic",
+		Arch:       "x86_64",
+		TotalRAMMB: 15923,
+	}
+
+	shortVersion := parseGeneralSummary(osInfoOverview, false, false, false)
+	assert.Contains(t, shortVersion, "CLI version: development\nOS: ubuntu 22.04\nKernel: 6.5.0-14-generic\nArch: x86_64\nTotal RAM: 15923 MB\nManagement: Connected\n")
+
+	jsonString, err := parseToJSON(osInfoOverview)
+	require.NoError(t, err)
+	assert.Contains(t, jsonString, `"osInfo":{"os":"ubuntu 22.04","kernel":"6.5.0-14-generic","arch":"x86_64","totalRamMb":15923}`)
+}
+
+func TestParsingTransportStats(t *testing.T) {
+	peers := peersStateOutput{
+		Details: []peerStateDetailOutput{
+			{
+				FQDN:   "peer-1.awesome-domain.com",
+				IP:     "192.168.178.101",
+				Status: "Connected",
+				Transport: &transportStatsOutput{
+					Protocol:        "UDP",
+					LocalPort:       51820,
+					RemoteAddress:   "1.1.1.1:10000",
+					PacketsSent:     120,
+					PacketsReceived: 100,
+					Retransmits:     3,
+				},
+			},
+		},
+	}
+
+	detail := parsePeers(peers, false, false)
+	assert.Contains(t, detail, "  -- transport --\n  Protocol: UDP\n  Local port: 51820\n  Remote address: 1.1.1.1:10000\n  Packets (received/sent): 100/120\n  Retransmits: 3\n")
+
+	jsonBytes, err := json.Marshal(peers.Details[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(jsonBytes), `"transport":{"protocol":"UDP","localPort":51820,"remoteAddress":"1.1.1.1:10000","packetsSent":120,"packetsReceived":100,"retransmits":3}`)
+}
+
+func TestFormatUptime(t *testing.T) {
+	assert.Equal(t, "3d 2h 14m", formatUptime(3*24*time.Hour+2*time.Hour+14*time.Minute+30*time.Second))
+	assert.Equal(t, "2h 0m", formatUptime(2*time.Hour))
+	assert.Equal(t, "0m", formatUptime(30*time.Second))
+}
+
+func TestParsingDaemonUptime(t *testing.T) {
+	startedAt := time.Date(2001, 1, 1, 1, 1, 1, 0, time.UTC)
+	uptimeOverview := overview
+	uptimeOverview.DaemonStartedAt = &startedAt
+
+	shortVersion := parseGeneralSummary(uptimeOverview, false, false, false)
+	assert.Regexp(t, `Daemon version: 0\.14\.1\nDaemon uptime: \d+d \d+h \d+m\nCLI version: development\n`, shortVersion)
+
+	jsonString, err := parseToJSON(uptimeOverview)
+	require.NoError(t, err)
+	assert.Contains(t, jsonString, `"daemonStartedAt":"2001-01-01T01:01:01Z"`)
+}
+
+func TestParsingDaemonProcessStats(t *testing.T) {
+	statsOverview := overview
+	statsOverview.DaemonProcessStats = &daemonProcessStatsOutput{
+		RSSBytes:       42 * mebibyte,
+		HeapInUseBytes: 18*mebibyte + 512,
+		Goroutines:     127,
+	}
+
+	shortVersion := parseGeneralSummary(statsOverview, false, false, false)
+	assert.Contains(t, shortVersion, "Daemon version: 0.14.1\nDaemon memory: 42 MiB RSS, 18 MiB heap, 127 goroutines\nCLI version: development\n")
+
+	jsonString, err := parseToJSON(statsOverview)
+	require.NoError(t, err)
+	assert.Contains(t, jsonString, `"daemonProcessStats":{"rssBytes":44040192,"heapInUseBytes":18874880,"goroutines":127}`)
+}
+
+func TestJSONCompactAlias(t *testing.T) {
+	t.Cleanup(func() {
+		jsonFlag = false
+		statusCmd.PersistentFlags().Lookup("json-compact").Changed = false
+	})
+
+	require.NoError(t, statusCmd.PersistentFlags().Parse([]string{"--json-compact"}))
+	assert.True(t, jsonFlag, "--json-compact should set the same variable as --json")
+
+	jsonString, err := parseToJSON(overview)
+	require.NoError(t, err)
+	assert.NotContains(t, jsonString, "\n", "the json output should be a single line")
+}
+
+func TestTimeFormat(t *testing.T) {
+	t.Cleanup(func() {
+		timeFormatFlag = defaultTimeFormat
+	})
+
+	timestamp := time.Date(2001, 1, 1, 1, 1, 1, 0, time.UTC)
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{format: defaultTimeFormat, expected: "2001-01-01 01:01:01"},
+		{format: "rfc3339", expected: "2001-01-01T01:01:01Z"},
+		{format: "unix", expected: "978310861"},
+		{format: "02 Jan 15:04", expected: "01 Jan 01:01"},
+	}
+	for _, tc := range tests {
+		timeFormatFlag = tc.format
+		assert.Equal(t, tc.expected, formatTimestamp(timestamp), tc.format)
+	}
+
+	timeFormatFlag = "relative"
+	assert.Equal(t, "3h 0m ago", formatTimestamp(time.Now().Add(-3*time.Hour-time.Second)))
+
+	timeFormatFlag = "unix"
+	detail := parsePeer(overview.Peers.Details[0], false, false)
+	assert.Contains(t, detail, "  Last connection update: 978310861\n")
+
+	jsonString, err := parseToJSON(overview)
+	require.NoError(t, err)
+	assert.Contains(t, jsonString, `"lastStatusUpdate":"2001-01-01T01:01:01Z"`, "the json output ignores the time format")
+}
+
+func TestParsingDaemonPID(t *testing.T) {
+	pidOverview := overview
+	pidOverview.DaemonPID = 12345
+
+	shortVersion := parseGeneralSummary(pidOverview, false, false, false)
+	assert.Contains(t, shortVersion, "Daemon version: 0.14.1\nDaemon PID: 12345\nCLI version: development\n")
+
+	jsonString, err := parseToJSON(pidOverview)
+	require.NoError(t, err)
+	assert.Contains(t, jsonString, `"daemonPid":12345`)
+}
+
+func TestParsingDaemonConfigFile(t *testing.T) {
+	configOverview := overview
+	configFile := "/etc/netbird/config.json"
+	configOverview.DaemonConfigFile = &configFile
+
+	shortVersion := parseGeneralSummary(configOverview, false, false, false)
+	assert.Contains(t, shortVersion, "Daemon version: 0.14.1\nConfig file: /etc/netbird/config.json\nCLI version: development\n")
+
+	jsonString, err := parseToJSON(configOverview)
+	require.NoError(t, err)
+	assert.Contains(t, jsonString, `"daemonConfigFile":"/etc/netbird/config.json"`)
+
+	noFile := ""
+	configOverview.DaemonConfigFile = &noFile
+	assert.Contains(t, parseGeneralSummary(configOverview, false, false, false), "Config file: (defaults, no file)\n")
+}
+
+func TestParsingInterfaceStats(t *testing.T) {
+	statsOverview := overview
+	statsOverview.InterfaceStats = &interfaceStatsOutput{
+		Name:      "wt0",
+		Up:        true,
+		RxBytes:   2048,
+		TxBytes:   1536,
+		RxPackets: 20,
+		TxPackets: 15,
+		RxErrors:  1,
+	}
+
+	detail := parseGeneralSummary(statsOverview, true, true, true)
+	assert.True(t, strings.HasSuffix(detail, "Interface stats:\n"+
+		"  Name: wt0\n"+
+		"  State: UP\n"+
+		"  RX: 2.0 KiB, 20 packets, 1 errors\n"+
+		"  TX: 1.5 KiB, 15 packets, 0 errors\n"), detail)
+
+	jsonString, err := parseToJSON(statsOverview)
+	require.NoError(t, err)
+	assert.Contains(t, jsonString, `"interfaceStats":{"name":"wt0","up":true,"rxBytes":2048,"txBytes":1536,"rxPackets":20,"txPackets":15,"rxErrors":1,"txErrors":0}`)
+}
+
+func TestWrapLongLines(t *testing.T) {
+	text := "\n peer-1.awesome-domain.com:\n" +
+		"  Public key: Pubkey1Pubkey1Pubkey1Pubkey1Pubkey1Pubkey1Pub=\n" +
+		"  Routes: 10.1.0.0/24, 10.2.0.0/24, 10.3.0.0/24\n"
+
+	expected := "\n peer-1.awesome-domain.com:\n" +
+		"  Public key: Pubkey1Pubkey1Pubke\n" +
+		"    y1Pubkey1Pubkey1Pubkey1Pub=\n" +
+		"  Routes: 10.1.0.0/24,\n" +
+		"    10.2.0.0/24, 10.3.0.0/24\n"
+
+	assert.Equal(t, expected, wrapLongLines(text, 33))
+}
+
+func TestGroupByStatus(t *testing.T) {
+	groupByStatusFlag = true
+	t.Cleanup(func() {
+		groupByStatusFlag = false
+	})
+
+	peers := []peerStateDetailOutput{
+		{FQDN: "peer-1.awesome-domain.com", IP: "192.168.178.101", Status: "Disconnected"},
+		{FQDN: "peer-2.awesome-domain.com", IP: "192.168.178.102", Status: "Connected"},
+		{FQDN: "peer-3.awesome-domain.com", IP: "192.168.178.103", Status: "Connected"},
+	}
+
+	groupPeersByStatus(peers)
+
+	assert.Equal(t, "peer-2.awesome-domain.com", peers[0].FQDN)
+	assert.Equal(t, "peer-3.awesome-domain.com", peers[1].FQDN)
+	assert.Equal(t, "peer-1.awesome-domain.com", peers[2].FQDN)
+	assert.Equal(t, "Connected", peers[0].Group)
+	assert.Equal(t, "Disconnected", peers[2].Group)
+
+	detail := parsePeers(peersStateOutput{Details: peers}, false, false)
+	assert.True(t, strings.HasPrefix(detail, "\n--- Connected (2) ---\n peer-2.awesome-domain.com:\n"))
+	assert.Contains(t, detail, "\n\n--- Disconnected (1) ---\n peer-1.awesome-domain.com:\n")
+
+	jsonBytes, err := json.Marshal(peers[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(jsonBytes), `"group":"Connected"`)
+}
+
+func TestSaveSnapshot(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "snapshot.json")
+	snapshotTime := time.Date(2001, 1, 1, 1, 1, 1, 0, time.UTC)
+
+	err := saveSnapshot(file, overview, snapshotTime)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+
+	var snapshot map[string]interface{}
+	require.NoError(t, json.Unmarshal(content, &snapshot))
+	assert.Equal(t, "2001-01-01T01:01:01Z", snapshot["snapshotTime"])
+	assert.Equal(t, overview.FQDN, snapshot["fqdn"])
+	assert.Contains(t, snapshot, "peers")
+}
+
+func TestParsingPeersIPList(t *testing.T) {
+	assert.Equal(t, "192.168.178.101\n192.168.178.102\n", parsePeersIPList(overview.Peers))
+}
+
+func TestParsingPeersFQDNList(t *testing.T) {
+	peers := peersStateOutput{
+		Details: []peerStateDetailOutput{
+			{FQDN: "peer-b.awesome-domain.com.", IP: "192.168.178.101"},
+			{FQDN: "peer-a.awesome-domain.com", IP: "192.168.178.102"},
+			{IP: "192.168.178.103"},
+		},
+	}
+
+	assert.Equal(t, "peer-a.awesome-domain.com\npeer-b.awesome-domain.com\n", parsePeersFQDNList(peers))
+}
+
+func TestParsingPeersToJSONLines(t *testing.T) {
+	jsonLines, err := parsePeersToJSONLines(overview.Peers, false)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(jsonLines, "\n"), "\n")
+	require.Len(t, lines, len(overview.Peers.Details))
+	for i, line := range lines {
+		var peerState peerStateDetailOutput
+		require.NoError(t, json.Unmarshal([]byte(line), &peerState), line)
+		assert.Equal(t, overview.Peers.Details[i].FQDN, peerState.FQDN)
+	}
+
+	noNewline, err := parsePeersToJSONLines(overview.Peers, true)
+	require.NoError(t, err)
+	assert.Equal(t, strings.TrimSuffix(jsonLines, "\n"), noNewline)
+}
+
+func TestTruncateValue(t *testing.T) {
+	t.Cleanup(func() {
+		noTruncate = false
+		truncateAt = 0
+	})
+
+	pubKey := "Pubkey1Pubkey1Pubkey1Pubkey1Pubkey1Pubkey1Pu="
+	assert.Equal(t, pubKey, truncateValue(pubKey), "values are displayed in full by default")
+
+	truncateAt = 40
+	assert.Equal(t, "Pubkey1Pubkey1Pubkey1Pubkey1Pubkey1Pubk…", truncateValue(pubKey))
+	assert.Equal(t, "Pubkey1", truncateValue("Pubkey1"))
+
+	noTruncate = true
+	assert.Equal(t, pubKey, truncateValue(pubKey))
+}
+
 func TestParsingOfIP(t *testing.T) {
 	InterfaceIP := "192.168.178.123/16"
 
@@ -561,3 +854,651 @@ func TestParsingOfIP(t *testing.T) {
 
 	assert.Equal(t, "192.168.178.123\n", parsedIP)
 }
+
+func TestParsingFQDNOrIP(t *testing.T) {
+	assert.Equal(t, "some-localhost.awesome-domain.com\n", parseFQDNOrInterfaceIP(resp.GetFullStatus().GetLocalPeerState()))
+	assert.Equal(t, "192.168.178.123\n", parseFQDNOrInterfaceIP(&proto.LocalPeerState{IP: "192.168.178.123/16"}))
+	assert.Equal(t, "", parseFQDNOrInterfaceIP(nil))
+}
+
+func TestParsingTCPTransport(t *testing.T) {
+	peers := peersStateOutput{
+		Details: []peerStateDetailOutput{
+			{
+				FQDN:         "peer-1.awesome-domain.com",
+				IP:           "192.168.178.101",
+				Status:       "Connected",
+				TCPTransport: true,
+			},
+		},
+	}
+
+	assert.Contains(t, parsePeers(peers, false, false), "  Routes: -\n  Transport: TCP\n")
+	assert.NotContains(t, parsePeers(overview.Peers, false, false), "Transport: TCP")
+}
+
+func TestParsingSTUNCheck(t *testing.T) {
+	symmetric := false
+	stunOverview := overview
+	stunOverview.STUNCheck = &stunCheckOutput{
+		Servers: []stunServerCheckOutput{
+			{
+				URI:           "stun:my-awesome-stun.com:3478",
+				Reachable:     true,
+				ResponseTime:  23 * time.Millisecond,
+				MappedAddress: "1.2.3.4:5678",
+			},
+			{
+				URI:   "stun:my-other-stun.com:3478",
+				Error: "no response within 2s",
+			},
+		},
+		SymmetricNAT: &symmetric,
+	}
+
+	shortVersion := parseGeneralSummary(stunOverview, false, false, false)
+	assert.Contains(t, shortVersion, "Peers count: 2/2 Connected\n"+
+		"STUN check:\n"+
+		"  [stun:my-awesome-stun.com:3478] responded in 23ms, mapped address: 1.2.3.4:5678\n"+
+		"  [stun:my-other-stun.com:3478] is Unreachable, reason: no response within 2s\n"+
+		"  Symmetric NAT: false\n")
+
+	jsonString, err := parseToJSON(stunOverview)
+	require.NoError(t, err)
+	assert.Contains(t, jsonString, `"stunCheck":{"servers":[{"uri":"stun:my-awesome-stun.com:3478","reachable":true,"responseTime":23000000,"mappedAddress":"1.2.3.4:5678"}`)
+	assert.Contains(t, jsonString, `"symmetricNat":false}`)
+}
+
+func TestColorByLatency(t *testing.T) {
+	colorByLatencyFlag = true
+	t.Cleanup(func() {
+		colorByLatencyFlag = false
+	})
+
+	tests := []struct {
+		status   string
+		latency  time.Duration
+		expected int
+	}{
+		{status: "Connected", expected: colorGreen},
+		{status: "Connected", latency: 10 * time.Millisecond, expected: colorGreen},
+		{status: "Connected", latency: 50 * time.Millisecond, expected: colorYellow},
+		{status: "Connected", latency: 300 * time.Millisecond, expected: colorOrange},
+		{status: "Connected", latency: 600 * time.Millisecond, expected: colorRed},
+		{status: "Disconnected", expected: colorRed},
+	}
+
+	for _, tt := range tests {
+		peerState := peerStateDetailOutput{FQDN: "peer-1.awesome-domain.com", Status: tt.status, Latency: tt.latency}
+		assert.Equal(t, tt.expected, latencyColor(peerState), "%s peer with %s latency", tt.status, tt.latency)
+	}
+
+	detail := parsePeer(peerStateDetailOutput{FQDN: "peer-1.awesome-domain.com", Status: "Connected", Latency: 50 * time.Millisecond}, false, false)
+	assert.Contains(t, detail, "\n \033[38;5;226mpeer-1.awesome-domain.com\033[0m:\n")
+}
+
+func TestColorThresholdLatency(t *testing.T) {
+	latencyThresholdsFlag = []int{50, 200, 800}
+	t.Cleanup(func() {
+		latencyThresholdsFlag = defaultLatencyThresholds
+	})
+
+	require.NoError(t, parseLatencyThresholds())
+
+	connected := peerStateDetailOutput{Status: "Connected", Latency: 50 * time.Millisecond}
+	assert.Equal(t, colorGreen, latencyColor(connected))
+	connected.Latency = 600 * time.Millisecond
+	assert.Equal(t, colorOrange, latencyColor(connected), "600ms is normal on a satellite link")
+	connected.Latency = 900 * time.Millisecond
+	assert.Equal(t, colorRed, latencyColor(connected))
+
+	for _, invalid := range [][]int{{10, 100}, {10, 100, 500, 1000}, {100, 10, 500}, {10, 10, 500}, {-1, 10, 500}} {
+		latencyThresholdsFlag = invalid
+		assert.Error(t, parseLatencyThresholds(), "%v", invalid)
+	}
+}
+
+func TestColorThresholdLatencyFromEnv(t *testing.T) {
+	t.Setenv("NB_COLOR_THRESHOLD_LATENCY", "1")
+	t.Cleanup(func() {
+		latencyThresholdsFlag = defaultLatencyThresholds
+		colorByLatencyFlag = false
+		statusCmd.PersistentFlags().Lookup("color-threshold-latency").Changed = false
+	})
+
+	err := statusFunc(statusCmd, nil)
+	assert.ErrorContains(t, err, "wrong latency thresholds")
+	assert.Equal(t, []int{1}, latencyThresholdsFlag)
+	assert.True(t, colorByLatencyFlag, "thresholds from the environment imply --color-by-latency")
+}
+
+// latencyThresholdsClient keeps the latency color thresholds like the daemon config
+type latencyThresholdsClient struct {
+	proto.DaemonServiceClient
+	stored []int32
+}
+
+func (c *latencyThresholdsClient) GetConfig(_ context.Context, _ *proto.GetConfigRequest, _ ...grpc.CallOption) (*proto.GetConfigResponse, error) {
+	return &proto.GetConfigResponse{LatencyColorThresholds: c.stored}, nil
+}
+
+func (c *latencyThresholdsClient) SetLatencyColorThresholds(_ context.Context, in *proto.SetLatencyColorThresholdsRequest, _ ...grpc.CallOption) (*proto.SetLatencyColorThresholdsResponse, error) {
+	c.stored = in.GetThresholds()
+	return &proto.SetLatencyColorThresholdsResponse{}, nil
+}
+
+func TestSyncLatencyThresholds(t *testing.T) {
+	t.Cleanup(func() {
+		latencyThresholdsFlag = defaultLatencyThresholds
+	})
+	client := &latencyThresholdsClient{}
+
+	require.NoError(t, syncLatencyThresholds(context.Background(), client, false))
+	assert.Equal(t, defaultLatencyThresholds, latencyThresholdsFlag, "the defaults stay when none are stored")
+
+	latencyThresholdsFlag = []int{50, 200, 800}
+	require.NoError(t, syncLatencyThresholds(context.Background(), client, true))
+	assert.Equal(t, []int32{50, 200, 800}, client.stored)
+
+	latencyThresholdsFlag = defaultLatencyThresholds
+	require.NoError(t, syncLatencyThresholds(context.Background(), client, false))
+	assert.Equal(t, []int{50, 200, 800}, latencyThresholdsFlag, "the stored thresholds are the default of the next runs")
+}
+
+func TestIncludeManagedRoutesInPeers(t *testing.T) {
+	includePeerRoutes = true
+	t.Cleanup(func() {
+		includePeerRoutes = false
+	})
+
+	routesResp := &proto.StatusResponse{
+		FullStatus: &proto.FullStatus{
+			Peers: []*proto.PeerState{
+				{
+					IP:               "192.168.178.101",
+					Fqdn:             "peer-1.awesome-domain.com",
+					ConnStatus:       "Connected",
+					AdvertisedRoutes: []string{"10.2.0.0/16", "10.1.0.0/24"},
+				},
+				{
+					IP:         "192.168.178.102",
+					Fqdn:       "peer-2.awesome-domain.com",
+					ConnStatus: "Connected",
+				},
+			},
+			ManagementState: &proto.ManagementState{},
+			SignalState:     &proto.SignalState{},
+			LocalPeerState:  &proto.LocalPeerState{},
+		},
+	}
+
+	routesOverview := convertToStatusOutputOverview(routesResp)
+	detail := parsePeers(routesOverview.Peers, false, false)
+	assert.Contains(t, detail, "  Advertised routes: 10.1.0.0/24, 10.2.0.0/16\n")
+	assert.Contains(t, detail, "  Advertised routes: none\n")
+
+	jsonString, err := parseToJSON(routesOverview)
+	require.NoError(t, err)
+	assert.Contains(t, jsonString, `"advertisedRoutes":["10.1.0.0/24","10.2.0.0/16"]`)
+}
+
+func TestIncludeAllowedIPs(t *testing.T) {
+	includeAllowedIPs = true
+	t.Cleanup(func() {
+		includeAllowedIPs = false
+	})
+
+	allowedIPsResp := &proto.StatusResponse{
+		FullStatus: &proto.FullStatus{
+			Peers: []*proto.PeerState{
+				{
+					IP:         "100.64.0.5",
+					Fqdn:       "peer-1.awesome-domain.com",
+					ConnStatus: "Connected",
+					AllowedIPs: []string{"100.64.0.5/32", "10.0.0.0/8"},
+				},
+				{
+					IP:         "100.64.0.6",
+					Fqdn:       "peer-2.awesome-domain.com",
+					ConnStatus: "Idle",
+				},
+			},
+			ManagementState: &proto.ManagementState{},
+			SignalState:     &proto.SignalState{},
+			LocalPeerState:  &proto.LocalPeerState{},
+		},
+	}
+
+	allowedIPsOverview := convertToStatusOutputOverview(allowedIPsResp)
+	detail := parsePeers(allowedIPsOverview.Peers, false, false)
+	assert.Contains(t, detail, "  Allowed IPs: 100.64.0.5/32, 10.0.0.0/8\n")
+	assert.Contains(t, detail, "  Allowed IPs: none\n")
+
+	jsonString, err := parseToJSON(allowedIPsOverview)
+	require.NoError(t, err)
+	assert.Contains(t, jsonString, `"allowedIps":["100.64.0.5/32","10.0.0.0/8"]`)
+}
+
+func TestAllowedIPsOverride(t *testing.T) {
+	overrideResp := &proto.StatusResponse{
+		FullStatus: &proto.FullStatus{
+			Peers: []*proto.PeerState{
+				{
+					IP:                 "100.64.0.5",
+					Fqdn:               "peer-1.awesome-domain.com",
+					ConnStatus:         "Connected",
+					AllowedIPsOverride: []string{"10.0.0.0/8", "192.168.0.0/16"},
+				},
+				{
+					IP:         "100.64.0.6",
+					Fqdn:       "peer-2.awesome-domain.com",
+					ConnStatus: "Idle",
+				},
+			},
+			ManagementState: &proto.ManagementState{},
+			SignalState:     &proto.SignalState{},
+			LocalPeerState:  &proto.LocalPeerState{},
+		},
+	}
+
+	overrideOverview := convertToStatusOutputOverview(overrideResp)
+	detail := parsePeers(overrideOverview.Peers, false, false)
+	assert.Contains(t, detail, "  Allowed IPs override: 10.0.0.0/8, 192.168.0.0/16\n")
+	assert.Equal(t, 1, strings.Count(detail, "Allowed IPs override"), "peers without override aren't flagged")
+
+	jsonString, err := parseToJSON(overrideOverview)
+	require.NoError(t, err)
+	assert.Contains(t, jsonString, `"allowedIpsOverride":["10.0.0.0/8","192.168.0.0/16"]`)
+}
+
+func TestPeerOSInfo(t *testing.T) {
+	peerOSInfoFlag = true
+	t.Cleanup(func() {
+		peerOSInfoFlag = false
+	})
+
+	osInfoResp := &proto.StatusResponse{
+		FullStatus: &proto.FullStatus{
+			Peers: []*proto.PeerState{
+				{
+					IP:            "100.64.0.5",
+					Fqdn:          "peer-1.awesome-domain.com",
+					ConnStatus:    "Connected",
+					OsName:        "Ubuntu",
+					OsVersion:     "22.04 LTS",
+					KernelVersion: "Linux 5.15",
+				},
+				{
+					IP:         "100.64.0.6",
+					Fqdn:       "peer-2.awesome-domain.com",
+					ConnStatus: "Idle",
+				},
+			},
+			ManagementState: &proto.ManagementState{},
+			SignalState:     &proto.SignalState{},
+			LocalPeerState:  &proto.LocalPeerState{},
+		},
+	}
+
+	osInfoOverview := convertToStatusOutputOverview(osInfoResp)
+	detail := parsePeers(osInfoOverview.Peers, false, false)
+	assert.Contains(t, detail, "  OS: Ubuntu 22.04 LTS (Linux 5.15)\n")
+	assert.Contains(t, detail, "  OS: unknown\n")
+
+	jsonString, err := parseToJSON(osInfoOverview)
+	require.NoError(t, err)
+	assert.Contains(t, jsonString, `"osInfo":{"name":"Ubuntu","version":"22.04 LTS","kernelVersion":"Linux 5.15"}`)
+}
+
+func TestCipherSuites(t *testing.T) {
+	includeCipherSuites = true
+	t.Cleanup(func() {
+		includeCipherSuites = false
+	})
+
+	cipherResp := &proto.StatusResponse{
+		FullStatus: &proto.FullStatus{
+			Peers: []*proto.PeerState{
+				{
+					IP:          "100.64.0.5",
+					Fqdn:        "peer-1.awesome-domain.com",
+					ConnStatus:  "Connected",
+					CipherSuite: "ChaCha20-Poly1305/Curve25519+Rosenpass",
+				},
+				{
+					IP:          "100.64.0.6",
+					Fqdn:        "peer-2.awesome-domain.com",
+					ConnStatus:  "Connected",
+					CipherSuite: "ChaCha20-Poly1305/Curve25519",
+				},
+				{
+					IP:         "100.64.0.7",
+					Fqdn:       "peer-3.awesome-domain.com",
+					ConnStatus: "Idle",
+				},
+			},
+			ManagementState: &proto.ManagementState{},
+			SignalState:     &proto.SignalState{},
+			LocalPeerState:  &proto.LocalPeerState{},
+		},
+	}
+
+	cipherOverview := convertToStatusOutputOverview(cipherResp)
+	detail := parsePeers(cipherOverview.Peers, false, false)
+	assert.Contains(t, detail, "  Cipher: ChaCha20-Poly1305/Curve25519+Rosenpass\n")
+	assert.Contains(t, detail, "  Cipher: ChaCha20-Poly1305/Curve25519\n")
+	assert.Equal(t, 2, strings.Count(detail, "  Cipher: "))
+
+	assert.Equal(t, []string{"peer-2.awesome-domain.com"}, peersWithoutCipher(cipherOverview.Peers, "chacha20-poly1305/curve25519+rosenpass"))
+	assert.Equal(t, []string{"peer-1.awesome-domain.com"}, peersWithoutCipher(cipherOverview.Peers, "ChaCha20-Poly1305/Curve25519"))
+}
+
+func TestParsingToDetailWithoutSummary(t *testing.T) {
+	noSummaryFlag = true
+	t.Cleanup(func() {
+		noSummaryFlag = false
+	})
+
+	detail := parseToFullDetailSummary(overview)
+	assert.True(t, strings.HasPrefix(detail, " peer-1.awesome-domain.com:\n"), detail)
+	assert.Contains(t, detail, " peer-2.awesome-domain.com:\n")
+	assert.NotContains(t, detail, "Peers detail:")
+	assert.NotContains(t, detail, "Daemon version")
+}
+
+func TestPeersChangedSince(t *testing.T) {
+	t.Cleanup(func() {
+		peersChangedSinceArg = ""
+		peersChangedSince = time.Time{}
+		sinceVersionFlag = 0
+		detailFlag = false
+	})
+
+	peersChangedSinceArg = "2001-06-01T00:00:00Z"
+	require.NoError(t, parseFilters())
+
+	changedOverview := convertToStatusOutputOverview(resp)
+	require.Len(t, changedOverview.Peers.Details, 1)
+	assert.Equal(t, "peer-2.awesome-domain.com", changedOverview.Peers.Details[0].FQDN)
+
+	peersChangedSinceArg = "yesterday"
+	assert.Error(t, parseFilters())
+
+	peersChangedSinceArg = ""
+	peersChangedSince = time.Time{}
+	sinceVersionFlag = 3
+
+	versionedPeers := []*proto.PeerState{
+		{Fqdn: "peer-1.awesome-domain.com", Version: 3},
+		{Fqdn: "peer-2.awesome-domain.com", Version: 4},
+	}
+	assert.True(t, skipDetailByFilters(versionedPeers[0], false))
+	assert.False(t, skipDetailByFilters(versionedPeers[1], false))
+}
+
+func TestPeersDirectOnly(t *testing.T) {
+	t.Cleanup(func() {
+		peersDirectOnlyFlag = false
+		detailFlag = false
+	})
+
+	peersDirectOnlyFlag = true
+	require.NoError(t, parseFilters())
+	assert.True(t, detailFlag)
+
+	directOverview := convertToStatusOutputOverview(resp)
+	require.Len(t, directOverview.Peers.Details, 1)
+	assert.Equal(t, "peer-1.awesome-domain.com", directOverview.Peers.Details[0].FQDN)
+
+	assert.True(t, skipDetailByFilters(&proto.PeerState{Relayed: false}, false), "disconnected peers aren't direct")
+	assert.True(t, skipDetailByFilters(&proto.PeerState{Relayed: true}, true))
+	assert.False(t, skipDetailByFilters(&proto.PeerState{Relayed: false}, true))
+}
+
+func TestPeersByNetwork(t *testing.T) {
+	t.Cleanup(func() {
+		networksFilter = []string{}
+		networksFilterPrefix = nil
+		detailFlag = false
+	})
+
+	networksFilter = []string{"192.168.178.101/32", "10.0.0.0/8"}
+	require.NoError(t, parseFilters())
+	assert.True(t, detailFlag)
+
+	networkOverview := convertToStatusOutputOverview(resp)
+	require.Len(t, networkOverview.Peers.Details, 1)
+	assert.Equal(t, "peer-1.awesome-domain.com", networkOverview.Peers.Details[0].FQDN)
+	assert.False(t, skipDetailByFilters(&proto.PeerState{IP: "10.1.2.3"}, true))
+	assert.True(t, skipDetailByFilters(&proto.PeerState{IP: "10.1.2.3/8"}, true), "an unparsable IP is in no network")
+
+	networksFilter = []string{"192.168.178.0/24"}
+	require.NoError(t, parseFilters())
+	assert.Len(t, convertToStatusOutputOverview(resp).Peers.Details, 2)
+
+	networksFilter = []string{"192.168.178.0"}
+	assert.Error(t, parseFilters())
+}
+
+func TestPeersGateway(t *testing.T) {
+	t.Cleanup(func() {
+		peersGatewayFlag = false
+		detailFlag = false
+	})
+
+	peersGatewayFlag = true
+	require.NoError(t, parseFilters())
+	assert.True(t, detailFlag)
+
+	gatewayResp := &proto.StatusResponse{
+		FullStatus: &proto.FullStatus{
+			Peers: []*proto.PeerState{
+				{
+					IP:               "100.64.0.5",
+					Fqdn:             "router.awesome-domain.com",
+					ConnStatus:       "Connected",
+					AdvertisedRoutes: []string{"10.0.0.0/8", "192.168.0.0/24"},
+				},
+				{
+					IP:         "100.64.0.6",
+					Fqdn:       "peer-2.awesome-domain.com",
+					ConnStatus: "Connected",
+				},
+			},
+			ManagementState: &proto.ManagementState{},
+			SignalState:     &proto.SignalState{},
+			LocalPeerState:  &proto.LocalPeerState{},
+		},
+	}
+
+	gatewayOverview := convertToStatusOutputOverview(gatewayResp)
+	require.Len(t, gatewayOverview.Peers.Details, 1)
+	assert.Equal(t, "router.awesome-domain.com", gatewayOverview.Peers.Details[0].FQDN)
+	assert.Contains(t, parsePeers(gatewayOverview.Peers, false, false), "  Advertised routes: 10.0.0.0/8, 192.168.0.0/24\n")
+}
+
+func TestExcludePeers(t *testing.T) {
+	t.Cleanup(func() {
+		excludePeersFilter = []string{}
+		excludePeersMap = nil
+		detailFlag = false
+	})
+
+	excludePeersFilter = []string{"PEER-2"}
+	require.NoError(t, parseFilters())
+	assert.True(t, detailFlag)
+	assert.True(t, hasPeerFilters())
+
+	excludedOverview := convertToStatusOutputOverview(resp)
+	require.Len(t, excludedOverview.Peers.Details, 1)
+	assert.Equal(t, "peer-1.awesome-domain.com", excludedOverview.Peers.Details[0].FQDN)
+	assert.Contains(t, parseGeneralSummary(excludedOverview, false, false, false), "Peers count: 1/1 Connected\n")
+
+	excludePeersFilter = []string{"peer-1.awesome-domain.com", "192.168.178.102"}
+	require.NoError(t, parseFilters())
+	assert.Empty(t, convertToStatusOutputOverview(resp).Peers.Details)
+
+	excludePeersFilter = []string{"peer"}
+	require.NoError(t, parseFilters())
+	assert.Len(t, convertToStatusOutputOverview(resp).Peers.Details, 2, "a hostname prefix doesn't exclude")
+}
+
+func TestPeersByPublicKey(t *testing.T) {
+	peers := peersStateOutput{
+		Details: []peerStateDetailOutput{
+			{FQDN: "peer-1.awesome-domain.com", PubKey: "Pubkey1Pubkey1=", Status: "Connected"},
+			{FQDN: "peer-2.awesome-domain.com", PubKey: "Pubkey1Pubkey1Pubkey1=", Status: "Idle"},
+			{FQDN: "peer-3.awesome-domain.com", PubKey: "Pubkey3Pubkey3=", Status: "Connected"},
+		},
+	}
+
+	exact := peersByPublicKey(peers, "Pubkey1Pubkey1=")
+	require.Len(t, exact.Details, 1, "an exact match wins over the prefix matches")
+	assert.Equal(t, "peer-1.awesome-domain.com", exact.Details[0].FQDN)
+	assert.Equal(t, 1, exact.Connected)
+
+	prefix := peersByPublicKey(peers, "Pubkey3P")
+	require.Len(t, prefix.Details, 1)
+	assert.Equal(t, "peer-3.awesome-domain.com", prefix.Details[0].FQDN)
+
+	ambiguous := peersByPublicKey(peers, "Pubkey1P")
+	assert.Equal(t, 2, ambiguous.Total)
+	assert.Equal(t, 1, ambiguous.Connected)
+
+	assert.Equal(t, 0, peersByPublicKey(peers, "Pubkey3").Total, "prefixes need at least 8 characters")
+	assert.Equal(t, 0, peersByPublicKey(peers, "Unknown=").Total)
+}
+
+func TestPeersNotSeenSince(t *testing.T) {
+	t.Cleanup(func() {
+		notSeenSinceArg = ""
+		notSeenBefore = time.Time{}
+		detailFlag = false
+	})
+
+	notSeenSinceArg = "7d"
+	require.NoError(t, parseFilters())
+	assert.WithinDuration(t, time.Now().Add(-7*24*time.Hour), notSeenBefore, time.Minute)
+
+	stalePeer := &proto.PeerState{ConnStatusUpdate: timestamppb.New(time.Now().Add(-8 * 24 * time.Hour))}
+	recentPeer := &proto.PeerState{ConnStatusUpdate: timestamppb.New(time.Now().Add(-time.Hour))}
+	assert.False(t, skipDetailByFilters(stalePeer, true))
+	assert.True(t, skipDetailByFilters(recentPeer, false))
+
+	for _, value := range []string{"7days", "-1d", "week"} {
+		notSeenSinceArg = value
+		assert.Error(t, parseFilters(), value)
+	}
+}
+
+func TestPeersConnectedSince(t *testing.T) {
+	t.Cleanup(func() {
+		connectedSinceArg = ""
+		connectedBefore = time.Time{}
+		detailFlag = false
+	})
+
+	connectedSinceArg = "5m"
+	require.NoError(t, parseFilters())
+	assert.True(t, detailFlag)
+	assert.WithinDuration(t, time.Now().Add(-5*time.Minute), connectedBefore, time.Minute)
+
+	stablePeer := &proto.PeerState{ConnStatusUpdate: timestamppb.New(time.Now().Add(-time.Hour))}
+	newPeer := &proto.PeerState{ConnStatusUpdate: timestamppb.New(time.Now().Add(-time.Minute))}
+	assert.False(t, skipDetailByFilters(stablePeer, true))
+	assert.True(t, skipDetailByFilters(stablePeer, false), "a peer disconnected for an hour isn't a stable connection")
+	assert.True(t, skipDetailByFilters(newPeer, true))
+
+	connectedSinceArg = "2d"
+	require.NoError(t, parseFilters())
+	assert.WithinDuration(t, time.Now().Add(-48*time.Hour), connectedBefore, time.Minute)
+
+	for _, value := range []string{"5 minutes", "-5m", "-1d"} {
+		connectedSinceArg = value
+		assert.Error(t, parseFilters(), value)
+	}
+}
+
+func TestParseDayDuration(t *testing.T) {
+	duration, err := parseDayDuration("2d")
+	require.NoError(t, err)
+	assert.Equal(t, 48*time.Hour, duration)
+
+	duration, err = parseDayDuration("90m")
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, duration)
+}
+
+func TestPeersInGroupFilter(t *testing.T) {
+	t.Cleanup(func() {
+		peersInGroupFilter = []string{}
+		groupMembersMap = nil
+		detailFlag = false
+	})
+
+	peersInGroupFilter = []string{"databases", "web"}
+	require.NoError(t, parseFilters())
+	assert.True(t, detailFlag)
+
+	groupMembersMap = map[string]struct{}{"peer-2.awesome-domain.com": {}}
+	groupOverview := convertToStatusOutputOverview(resp)
+	require.Len(t, groupOverview.Peers.Details, 1)
+	assert.Equal(t, "peer-2.awesome-domain.com", groupOverview.Peers.Details[0].FQDN)
+
+	groupMembersMap = map[string]struct{}{}
+	assert.Empty(t, convertToStatusOutputOverview(resp).Peers.Details)
+}
+
+func TestHasPeerFilters(t *testing.T) {
+	t.Cleanup(func() {
+		ipsFilter = []string{}
+		statusFilter = ""
+	})
+
+	assert.False(t, hasPeerFilters())
+
+	ipsFilter = []string{"192.168.178.101"}
+	assert.True(t, hasPeerFilters())
+
+	ipsFilter = []string{}
+	statusFilter = "connected"
+	assert.True(t, hasPeerFilters())
+}
+
+func TestColorConnectedIP(t *testing.T) {
+	colorConnectedIPFlag = true
+	t.Cleanup(func() {
+		colorConnectedIPFlag = false
+	})
+
+	summary := parseGeneralSummary(overview, false, false, false)
+	assert.Contains(t, summary, "FQDN: \033[1;38;5;51msome-localhost.awesome-domain.com\033[0m\n")
+	assert.Contains(t, summary, "NetBird IP: \033[1;38;5;51m192.168.178.100/16\033[0m\n")
+}
+
+func TestTopPeersByTransfer(t *testing.T) {
+	peers := peersStateOutput{
+		Total:     4,
+		Connected: 3,
+		Details: []peerStateDetailOutput{
+			{FQDN: "quiet.netbird.cloud", Status: "Connected", TransferSent: 10, TransferReceived: 10},
+			{FQDN: "busy.netbird.cloud", Status: "Connected", TransferSent: 1000, TransferReceived: 5000},
+			{FQDN: "idle.netbird.cloud", Status: "Idle"},
+			{FQDN: "uploader.netbird.cloud", Status: "Connected", TransferSent: 3000},
+		},
+	}
+
+	top := topPeersByTransfer(peers, 2)
+	assert.Equal(t, 2, top.Total)
+	assert.Equal(t, 2, top.Connected)
+	require.Len(t, top.Details, 2)
+	assert.Equal(t, "busy.netbird.cloud", top.Details[0].FQDN)
+	assert.Equal(t, "uploader.netbird.cloud", top.Details[1].FQDN)
+	assert.Equal(t, "quiet.netbird.cloud", peers.Details[0].FQDN, "the input peers should not be reordered")
+
+	assert.Len(t, topPeersByTransfer(peers, 10).Details, 4)
+
+	jsonString, err := parsePeersToJSON(top.Details[:1])
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(jsonString, `[{"fqdn":"busy.netbird.cloud"`))
+}
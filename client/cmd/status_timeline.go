@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/status"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+const (
+	timelineWindow         = 24 * time.Hour
+	defaultTimelineBuckets = 48
+	maxTimelineBuckets     = 24 * 60
+	timelineConnected      = "▓"
+	timelineDisconnected   = "▒"
+)
+
+func getPeerEvents(ctx context.Context, client proto.DaemonServiceClient, fqdn string) ([]*proto.PeerEvent, error) {
+	resp, err := client.GetPeerEvents(ctx, &proto.GetPeerEventsRequest{Fqdn: fqdn})
+	if err != nil {
+		return nil, fmt.Errorf("get peer events failed: %v", status.Convert(err).Message())
+	}
+
+	return resp.GetEvents(), nil
+}
+
+func parseTimelineBuckets() error {
+	if timelineBucketsFlag < 1 || timelineBucketsFlag > maxTimelineBuckets {
+		return fmt.Errorf("wrong timeline buckets, should be between 1 and %d, got: %d", maxTimelineBuckets, timelineBucketsFlag)
+	}
+	return nil
+}
+
+// parsePeerTimeline renders the connection history of the last 24 hours as a row of buckets followed by a legend
+func parsePeerTimeline(fqdn string, events []*proto.PeerEvent, now time.Time, buckets int) string {
+	start := now.Add(-timelineWindow)
+	bucketSize := timelineWindow / time.Duration(buckets)
+
+	var timeline strings.Builder
+	for _, connected := range buildTimeline(events, start, bucketSize, buckets) {
+		if connected {
+			timeline.WriteString(timelineConnected)
+		} else {
+			timeline.WriteString(timelineDisconnected)
+		}
+	}
+
+	return fmt.Sprintf(
+		"%s\n"+
+			"%s\n"+
+			"Start: %s | End: %s | Scale: 1 bucket = %s (%s connected, %s disconnected)\n",
+		fqdn,
+		timeline.String(),
+		start.Format(time.RFC3339),
+		now.Format(time.RFC3339),
+		bucketSize,
+		timelineConnected,
+		timelineDisconnected,
+	)
+}
+
+// buildTimeline marks a bucket as connected when the peer was connected for at least half of it.
+// The peer is considered disconnected before its first recorded event
+func buildTimeline(events []*proto.PeerEvent, start time.Time, bucketSize time.Duration, buckets int) []bool {
+	connectedTime := make([]time.Duration, buckets)
+	end := start.Add(bucketSize * time.Duration(buckets))
+
+	for i, event := range events {
+		if event.GetConnStatus() != peer.StatusConnected.String() {
+			continue
+		}
+
+		from := event.GetTimestamp().AsTime()
+		to := end
+		if i+1 < len(events) {
+			to = events[i+1].GetTimestamp().AsTime()
+		}
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+
+		for from.Before(to) {
+			bucket := int(from.Sub(start) / bucketSize)
+			bucketEnd := start.Add(bucketSize * time.Duration(bucket+1))
+			if bucketEnd.After(to) {
+				bucketEnd = to
+			}
+			connectedTime[bucket] += bucketEnd.Sub(from)
+			from = bucketEnd
+		}
+	}
+
+	timeline := make([]bool, buckets)
+	for i, connected := range connectedTime {
+		timeline[i] = connected*2 >= bucketSize
+	}
+	return timeline
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+func TestParsePeerTimeline(t *testing.T) {
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	events := []*proto.PeerEvent{
+		{ConnStatus: "Connected", Timestamp: timestamppb.New(now.Add(-30 * time.Hour))},
+		{ConnStatus: "Disconnected", Timestamp: timestamppb.New(now.Add(-18 * time.Hour))},
+		{ConnStatus: "Connecting", Timestamp: timestamppb.New(now.Add(-13 * time.Hour))},
+		{ConnStatus: "Connected", Timestamp: timestamppb.New(now.Add(-11*time.Hour - 30*time.Minute))},
+	}
+
+	expected := "peer-1.awesome-domain.com\n" +
+		"▓▓▓▒▒▒▓▓▓▓▓▓\n" +
+		"Start: 2024-01-01T00:00:00Z | End: 2024-01-02T00:00:00Z | Scale: 1 bucket = 2h0m0s (▓ connected, ▒ disconnected)\n"
+
+	assert.Equal(t, expected, parsePeerTimeline("peer-1.awesome-domain.com", events, now, 12))
+}
+
+func TestBuildTimelineWithoutEvents(t *testing.T) {
+	timeline := buildTimeline(nil, time.Now().Add(-timelineWindow), time.Hour, 24)
+	assert.Len(t, timeline, 24)
+	assert.NotContains(t, timeline, true)
+}
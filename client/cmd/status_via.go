@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/status"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+const (
+	viaDirect  = "direct"
+	viaUnknown = "unknown"
+)
+
+type routeTestFunc func(ctx context.Context, req *proto.RouteTestRequest) (*proto.RouteTestResponse, error)
+
+// getPeerRoutes annotates every connected peer with the path the daemon would use to reach its NetBird IP
+func getPeerRoutes(ctx context.Context, client proto.DaemonServiceClient, peers *peersStateOutput) {
+	routeTest := func(ctx context.Context, req *proto.RouteTestRequest) (*proto.RouteTestResponse, error) {
+		return client.RouteTest(ctx, req)
+	}
+	annotatePeerRoutes(ctx, peers, routeTest, routeTestTimeoutFlag)
+}
+
+// annotatePeerRoutes runs a route test to the NetBird IP of every connected peer, at most peerCheckConcurrency at a
+// time. Peers are reached directly unless a route of another peer is the only path to their IP
+func annotatePeerRoutes(ctx context.Context, peers *peersStateOutput, routeTest routeTestFunc, timeout time.Duration) {
+	var connected []*peerStateDetailOutput
+	for i := range peers.Details {
+		if peers.Details[i].Status == peer.StatusConnected.String() {
+			connected = append(connected, &peers.Details[i])
+		}
+	}
+
+	runPeerChecks(len(connected), func(i int) {
+		peerState := connected[i]
+		testCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		resp, err := routeTest(testCtx, &proto.RouteTestRequest{
+			PeerFqdn:    peerState.FQDN,
+			Destination: strings.Split(peerState.IP, "/")[0],
+		})
+		if err != nil {
+			log.Debugf("route test to %s failed: %v", peerState.FQDN, status.Convert(err).Message())
+			peerState.Via = viaUnknown
+			return
+		}
+		peerState.Via = routeVia(resp)
+	})
+}
+
+func routeVia(resp *proto.RouteTestResponse) string {
+	if !resp.GetFound() || resp.GetServedByPeer() {
+		return viaDirect
+	}
+	return resp.GetGatewayFqdn()
+}
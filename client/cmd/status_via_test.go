@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+func TestAnnotatePeerRoutes(t *testing.T) {
+	peers := peersStateOutput{
+		Details: []peerStateDetailOutput{
+			{FQDN: "direct.netbird.cloud", IP: "100.64.0.1", Status: "Connected"},
+			{FQDN: "routed.netbird.cloud", IP: "100.64.0.2/32", Status: "Connected"},
+			{FQDN: "failing.netbird.cloud", IP: "100.64.0.3", Status: "Connected"},
+			{FQDN: "offline.netbird.cloud", IP: "100.64.0.4", Status: "Idle"},
+		},
+	}
+
+	var calls atomic.Int32
+	routeTest := func(ctx context.Context, req *proto.RouteTestRequest) (*proto.RouteTestResponse, error) {
+		calls.Add(1)
+		_, ok := ctx.Deadline()
+		assert.True(t, ok, "every route test should have a deadline")
+		switch req.GetDestination() {
+		case "100.64.0.2":
+			return &proto.RouteTestResponse{Found: true, RoutePrefix: "100.64.0.0/24", GatewayFqdn: "gateway.netbird.cloud"}, nil
+		case "100.64.0.3":
+			return nil, errors.New("context deadline exceeded")
+		default:
+			return &proto.RouteTestResponse{}, nil
+		}
+	}
+
+	annotatePeerRoutes(context.Background(), &peers, routeTest, time.Second)
+
+	assert.Equal(t, int32(3), calls.Load(), "only connected peers should be tested")
+	assert.Equal(t, "direct", peers.Details[0].Via)
+	assert.Equal(t, "gateway.netbird.cloud", peers.Details[1].Via)
+	assert.Equal(t, "unknown", peers.Details[2].Via)
+	assert.Empty(t, peers.Details[3].Via)
+
+	assert.Equal(t, "direct", routeVia(&proto.RouteTestResponse{Found: true, GatewayFqdn: "gateway.netbird.cloud", ServedByPeer: true}),
+		"a peer routing its own IP is reached directly")
+
+	detail := parsePeer(peers.Details[1], false, false)
+	assert.Contains(t, detail, "  Via: gateway.netbird.cloud\n")
+}
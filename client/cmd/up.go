@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"net"
 	"net/netip"
+	"os"
 	"runtime"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -28,8 +30,10 @@ const (
 )
 
 var (
-	foregroundMode bool
-	upCmd          = &cobra.Command{
+	foregroundMode     bool
+	generateConfigPath string
+	overwriteConfig    bool
+	upCmd              = &cobra.Command{
 		Use:   "up",
 		Short: "install, login and start Netbird client",
 		RunE:  upFunc,
@@ -40,6 +44,8 @@ func init() {
 	upCmd.PersistentFlags().BoolVarP(&foregroundMode, "foreground-mode", "F", false, "start service in foreground")
 	upCmd.PersistentFlags().StringVar(&interfaceName, interfaceNameFlag, iface.WgInterfaceDefault, "Wireguard interface name")
 	upCmd.PersistentFlags().Uint16Var(&wireguardPort, wireguardPortFlag, iface.DefaultWgPort, "Wireguard interface listening port")
+	upCmd.PersistentFlags().StringVar(&generateConfigPath, "generate-config", "", "write the effective configuration, the existing config updated with the given flags, to the given file and exit without connecting, e.g., --generate-config /etc/netbird/config.json")
+	upCmd.PersistentFlags().BoolVar(&overwriteConfig, "overwrite", false, "overwrite the --generate-config file if it already exists")
 }
 
 func upFunc(cmd *cobra.Command, args []string) error {
@@ -65,6 +71,10 @@ func upFunc(cmd *cobra.Command, args []string) error {
 		ctx = context.WithValue(ctx, system.DeviceNameCtxKey, hostName)
 	}
 
+	if generateConfigPath != "" {
+		return generateConfig(cmd)
+	}
+
 	if foregroundMode {
 		return runInForegroundMode(ctx, cmd)
 	}
@@ -77,11 +87,96 @@ func runInForegroundMode(ctx context.Context, cmd *cobra.Command) error {
 		return err
 	}
 
-	customDNSAddressConverted, err := parseCustomDNSAddress(cmd.Flag(dnsResolverAddress).Changed)
+	ic, err := configInputFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	if ic.DisableAutoConnect != nil {
+		if autoConnectDisabled {
+			cmd.Println("Autoconnect has been disabled. The client won't connect automatically when the service starts.")
+		}
+
+		if !autoConnectDisabled {
+			cmd.Println("Autoconnect has been enabled. The client will connect automatically when the service starts.")
+		}
+	}
+
+	config, err := internal.UpdateOrCreateConfig(ic)
+	if err != nil {
+		return fmt.Errorf("get config file: %v", err)
+	}
+
+	config, _ = internal.UpdateOldManagementURL(ctx, config, configPath)
+
+	err = foregroundLogin(ctx, cmd, config, setupKey)
+	if err != nil {
+		return fmt.Errorf("foreground login failed: %v", err)
+	}
+
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	SetupCloseHandler(ctx, cancel)
+	statusRecorder := peer.NewRecorder(config.ManagementURL.String())
+	statusRecorder.UpdateCustomDNSTTL(config.CustomDNSTTL)
+	return internal.RunClient(ctx, config, statusRecorder)
+}
+
+// generateConfig atomically writes the effective configuration to the --generate-config file
+func generateConfig(cmd *cobra.Command) error {
+	if _, err := os.Stat(generateConfigPath); err == nil && !overwriteConfig {
+		return fmt.Errorf("config file %s already exists, use --overwrite to replace it", generateConfigPath)
+	}
+
+	ic, err := configInputFromFlags(cmd)
 	if err != nil {
 		return err
 	}
 
+	config, err := internal.MergeConfig(ic)
+	if err != nil {
+		return fmt.Errorf("get config: %v", err)
+	}
+
+	if err := util.WriteJson(generateConfigPath, config); err != nil {
+		return fmt.Errorf("failed writing config to %s: %v", generateConfigPath, err)
+	}
+
+	cmd.Print(parseGeneratedConfigSummary(generateConfigPath, config))
+	return nil
+}
+
+func parseGeneratedConfigSummary(path string, config *internal.Config) string {
+	return fmt.Sprintf(
+		"Config written to %s\n"+
+			"  Management URL: %s\n"+
+			"  Admin URL: %s\n"+
+			"  Interface: %s\n"+
+			"  WireGuard port: %d\n"+
+			"  Quantum resistance: %t (permissive: %t)\n"+
+			"  Autoconnect disabled: %t\n"+
+			"  Force TCP: %t\n"+
+			"  Auto TCP: %t\n",
+		path,
+		config.ManagementURL,
+		config.AdminURL,
+		config.WgIface,
+		config.WgPort,
+		config.RosenpassEnabled,
+		config.RosenpassPermissive,
+		config.DisableAutoConnect,
+		config.ForceTCP,
+		config.AutoTCP,
+	)
+}
+
+// configInputFromFlags builds the config input from the up flags, only the changed flags override the existing config
+func configInputFromFlags(cmd *cobra.Command) (internal.ConfigInput, error) {
+	customDNSAddressConverted, err := parseCustomDNSAddress(cmd.Flag(dnsResolverAddress).Changed)
+	if err != nil {
+		return internal.ConfigInput{}, err
+	}
+
 	ic := internal.ConfigInput{
 		ManagementURL:    managementURL,
 		AdminURL:         adminURL,
@@ -104,7 +199,7 @@ func runInForegroundMode(ctx context.Context, cmd *cobra.Command) error {
 
 	if cmd.Flag(interfaceNameFlag).Changed {
 		if err := parseInterfaceName(interfaceName); err != nil {
-			return err
+			return internal.ConfigInput{}, err
 		}
 		ic.InterfaceName = &interfaceName
 	}
@@ -118,34 +213,33 @@ func runInForegroundMode(ctx context.Context, cmd *cobra.Command) error {
 		ic.PreSharedKey = &preSharedKey
 	}
 
-	if cmd.Flag(disableAutoConnectFlag).Changed {
-		ic.DisableAutoConnect = &autoConnectDisabled
-
-		if autoConnectDisabled {
-			cmd.Println("Autoconnect has been disabled. The client won't connect automatically when the service starts.")
+	if cmd.Flag(customDNSTTLFlag).Changed {
+		if err := validateCustomDNSTTL(customDNSTTL); err != nil {
+			return internal.ConfigInput{}, err
 		}
+		ic.CustomDNSTTL = &customDNSTTL
+	}
 
-		if !autoConnectDisabled {
-			cmd.Println("Autoconnect has been enabled. The client will connect automatically when the service starts.")
-		}
+	if cmd.Flag(forceTCPFlag).Changed {
+		ic.ForceTCP = &forceTCP
 	}
 
-	config, err := internal.UpdateOrCreateConfig(ic)
-	if err != nil {
-		return fmt.Errorf("get config file: %v", err)
+	if cmd.Flag(autoTCPFlag).Changed {
+		ic.AutoTCP = &autoTCP
 	}
 
-	config, _ = internal.UpdateOldManagementURL(ctx, config, configPath)
+	if cmd.Flag(disableAutoConnectFlag).Changed {
+		ic.DisableAutoConnect = &autoConnectDisabled
+	}
 
-	err = foregroundLogin(ctx, cmd, config, setupKey)
-	if err != nil {
-		return fmt.Errorf("foreground login failed: %v", err)
+	if cmd.Flag(peerAllowedIPsOverrideFlag).Changed {
+		ic.PeerAllowedIPsOverrides, err = internal.ParsePeerAllowedIPsOverrides(peerAllowedIPsOverrides)
+		if err != nil {
+			return internal.ConfigInput{}, err
+		}
 	}
 
-	var cancel context.CancelFunc
-	ctx, cancel = context.WithCancel(ctx)
-	SetupCloseHandler(ctx, cancel)
-	return internal.RunClient(ctx, config, peer.NewRecorder(config.ManagementURL.String()))
+	return ic, nil
 }
 
 func runInDaemonMode(ctx context.Context, cmd *cobra.Command) error {
@@ -177,6 +271,9 @@ func runInDaemonMode(ctx context.Context, cmd *cobra.Command) error {
 	}
 
 	if status.Status == string(internal.StatusConnected) {
+		if cmd.Flag(customDNSTTLFlag).Changed {
+			return setCustomDNSTTL(ctx, cmd, client)
+		}
 		cmd.Println("Already connected")
 		return nil
 	}
@@ -219,6 +316,29 @@ func runInDaemonMode(ctx context.Context, cmd *cobra.Command) error {
 		loginRequest.InterfaceName = &interfaceName
 	}
 
+	if cmd.Flag(customDNSTTLFlag).Changed {
+		if err := validateCustomDNSTTL(customDNSTTL); err != nil {
+			return err
+		}
+		ttl := int64(customDNSTTL.Seconds())
+		loginRequest.CustomDNSTTL = &ttl
+	}
+
+	if cmd.Flag(forceTCPFlag).Changed {
+		loginRequest.ForceTCP = &forceTCP
+	}
+
+	if cmd.Flag(autoTCPFlag).Changed {
+		loginRequest.AutoTCP = &autoTCP
+	}
+
+	if cmd.Flag(peerAllowedIPsOverrideFlag).Changed {
+		if _, err := internal.ParsePeerAllowedIPsOverrides(peerAllowedIPsOverrides); err != nil {
+			return err
+		}
+		loginRequest.PeerAllowedIPsOverrides = peerAllowedIPsOverrides
+	}
+
 	if cmd.Flag(wireguardPortFlag).Changed {
 		wp := int64(wireguardPort)
 		loginRequest.WireguardPort = &wp
@@ -355,6 +475,27 @@ func parseCustomDNSAddress(modified bool) ([]byte, error) {
 	return parsed, nil
 }
 
+// setCustomDNSTTL changes the TTL of a connected daemon, which applies it on the next DNS update without reconnecting
+func setCustomDNSTTL(ctx context.Context, cmd *cobra.Command, client proto.DaemonServiceClient) error {
+	if err := validateCustomDNSTTL(customDNSTTL); err != nil {
+		return err
+	}
+
+	_, err := client.SetCustomDNSTTL(ctx, &proto.SetCustomDNSTTLRequest{CustomDNSTTL: int64(customDNSTTL.Seconds())})
+	if err != nil {
+		return fmt.Errorf("failed setting the custom DNS TTL: %v", gstatus.Convert(err).Message())
+	}
+	cmd.Printf("Already connected, custom DNS TTL set to %s for the next DNS update\n", customDNSTTL)
+	return nil
+}
+
+func validateCustomDNSTTL(ttl time.Duration) error {
+	if ttl < internal.MinDNSTTL || ttl > internal.MaxDNSTTL {
+		return fmt.Errorf("%s is not a valid input for %s. it should be between 1s and 24h", ttl, customDNSTTLFlag)
+	}
+	return nil
+}
+
 func isValidAddrPort(input string) bool {
 	if input == "" {
 		return true
@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+)
+
+// ParsePeerAllowedIPsOverrides parses overrides in the form fqdn=cidr[,cidr...] into the extra CIDRs of each peer.
+// It returns an empty map for an empty override, which clears the configured overrides
+func ParsePeerAllowedIPsOverrides(overrides []string) (map[string][]string, error) {
+	parsed := make(map[string][]string)
+	for _, override := range overrides {
+		if override == "" {
+			continue
+		}
+
+		fqdn, cidrs, found := strings.Cut(override, "=")
+		fqdn = strings.TrimSuffix(strings.TrimSpace(fqdn), ".")
+		if !found || fqdn == "" || cidrs == "" {
+			return nil, fmt.Errorf("invalid allowed IPs override %s, should be in the form fqdn=cidr[,cidr...]", override)
+		}
+
+		for _, cidr := range strings.Split(cidrs, ",") {
+			prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+			if err != nil {
+				return nil, fmt.Errorf("invalid allowed IPs override %s: %v", override, err)
+			}
+			parsed[fqdn] = append(parsed[fqdn], prefix.Masked().String())
+		}
+	}
+	return parsed, nil
+}
+
+// peerAllowedIPsOverride returns the extra CIDRs configured for the peer. The override matches the full FQDN of the
+// peer or its leading labels, e.g., an override for peer-a matches peer-a.netbird.cloud
+func peerAllowedIPsOverride(overrides map[string][]string, fqdn string) []string {
+	if len(overrides) == 0 || fqdn == "" {
+		return nil
+	}
+
+	fqdn = strings.TrimSuffix(fqdn, ".")
+	if cidrs, ok := overrides[fqdn]; ok {
+		return cidrs
+	}
+
+	// prefer the longest match when several overrides match the leading labels
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+
+	for _, name := range names {
+		if strings.HasPrefix(fqdn, name+".") {
+			return overrides[name]
+		}
+	}
+	return nil
+}
+
+// extraAllowedIPs returns the override CIDRs that are not already in the allowed IPs pushed by management
+func extraAllowedIPs(allowedIPs, override []string) []string {
+	var extra []string
+	for _, cidr := range override {
+		exists := false
+		for _, allowedIP := range append(allowedIPs, extra...) {
+			if allowedIP == cidr {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			extra = append(extra, cidr)
+		}
+	}
+	return extra
+}
@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePeerAllowedIPsOverrides(t *testing.T) {
+	overrides, err := ParsePeerAllowedIPsOverrides([]string{
+		"peer-a.netbird.cloud=10.0.0.0/8, 192.168.1.1/16",
+		"peer-b.=172.16.0.0/12",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"peer-a.netbird.cloud": {"10.0.0.0/8", "192.168.0.0/16"},
+		"peer-b":               {"172.16.0.0/12"},
+	}, overrides)
+
+	overrides, err = ParsePeerAllowedIPsOverrides([]string{""})
+	require.NoError(t, err)
+	assert.NotNil(t, overrides, "an empty override clears the configured ones")
+	assert.Empty(t, overrides)
+
+	for _, invalid := range []string{"peer-a", "=10.0.0.0/8", "peer-a=", "peer-a=10.0.0.0", "peer-a=10.0.0.0/8,nope"} {
+		_, err = ParsePeerAllowedIPsOverrides([]string{invalid})
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestPeerAllowedIPsOverride(t *testing.T) {
+	overrides := map[string][]string{
+		"peer-a":                 {"10.0.0.0/8"},
+		"peer-a.office":          {"192.168.0.0/16"},
+		"peer-b.netbird.cloud":   {"172.16.0.0/12"},
+		"peer-b.netbird.cloud.x": {"172.17.0.0/16"},
+	}
+
+	assert.Equal(t, []string{"10.0.0.0/8"}, peerAllowedIPsOverride(overrides, "peer-a.netbird.cloud"))
+	assert.Equal(t, []string{"192.168.0.0/16"}, peerAllowedIPsOverride(overrides, "peer-a.office.netbird.cloud"), "the longest match wins")
+	assert.Equal(t, []string{"172.16.0.0/12"}, peerAllowedIPsOverride(overrides, "peer-b.netbird.cloud."))
+	assert.Nil(t, peerAllowedIPsOverride(overrides, "peer-ab.netbird.cloud"))
+	assert.Nil(t, peerAllowedIPsOverride(nil, "peer-a.netbird.cloud"))
+}
+
+func TestExtraAllowedIPs(t *testing.T) {
+	assert.Empty(t, extraAllowedIPs([]string{"100.64.0.10/32"}, nil))
+	assert.Empty(t, extraAllowedIPs([]string{"100.64.0.10/32", "10.0.0.0/8"}, []string{"10.0.0.0/8"}))
+	assert.Equal(t, []string{"10.0.0.0/8", "192.168.0.0/16"},
+		extraAllowedIPs([]string{"100.64.0.10/32"}, []string{"10.0.0.0/8", "192.168.0.0/16", "10.0.0.0/8"}))
+}
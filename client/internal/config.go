@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"reflect"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
@@ -28,6 +30,11 @@ const (
 	oldDefaultManagementURL = "https://api.wiretrustee.com:443"
 	// DefaultAdminURL points to NetBird's cloud management console
 	DefaultAdminURL = "https://app.netbird.io:443"
+	// DefaultDNSTTL is the TTL of the DNS records pushed to the local resolver when no custom TTL is configured
+	DefaultDNSTTL = 300 * time.Second
+	// MinDNSTTL and MaxDNSTTL bound the custom TTL of the DNS records pushed to the local resolver
+	MinDNSTTL = time.Second
+	MaxDNSTTL = 24 * time.Hour
 )
 
 var defaultInterfaceBlacklist = []string{iface.WgInterfaceDefault, "wt", "utun", "tun0", "zt", "ZeroTier", "wg", "ts",
@@ -47,6 +54,12 @@ type ConfigInput struct {
 	InterfaceName       *string
 	WireguardPort       *int
 	DisableAutoConnect  *bool
+	CustomDNSTTL        *time.Duration
+	ForceTCP            *bool
+	AutoTCP             *bool
+	// PeerAllowedIPsOverrides replaces the configured overrides when not nil, an empty map clears them
+	PeerAllowedIPsOverrides map[string][]string
+	LatencyColorThresholds  []int
 }
 
 // Config Configuration type
@@ -88,6 +101,21 @@ type Config struct {
 	// DisableAutoConnect determines whether the client should not start with the service
 	// it's set to false by default due to backwards compatibility
 	DisableAutoConnect bool
+
+	// CustomDNSTTL is the TTL of the DNS records pushed to the local resolver, DefaultDNSTTL when not set
+	CustomDNSTTL time.Duration
+
+	// ForceTCP restricts peer connections to TURN relays reachable over TCP
+	ForceTCP bool
+	// AutoTCP switches a peer connection to TURN relays over TCP when UDP connectivity checks keep failing
+	AutoTCP bool
+
+	// PeerAllowedIPsOverrides maps peer FQDNs to CIDRs added to the allowed IPs management pushes for the peer
+	PeerAllowedIPsOverrides map[string][]string `json:",omitempty"`
+
+	// LatencyColorThresholds are the green, yellow and orange upper bounds in milliseconds of the latency colors of the
+	// status command, the CLI defaults are used when not set
+	LatencyColorThresholds []int `json:",omitempty"`
 }
 
 // ReadConfig read config file and return with Config. If it is not exists create a new with default values
@@ -206,6 +234,33 @@ func createNewConfig(input ConfigInput) (*Config, error) {
 		config.ServerSSHAllowed = input.ServerSSHAllowed
 	}
 
+	config.CustomDNSTTL = DefaultDNSTTL
+	if input.CustomDNSTTL != nil {
+		if err := ValidateDNSTTL(*input.CustomDNSTTL); err != nil {
+			return nil, err
+		}
+		config.CustomDNSTTL = *input.CustomDNSTTL
+	}
+
+	if input.ForceTCP != nil {
+		config.ForceTCP = *input.ForceTCP
+	}
+
+	if input.AutoTCP != nil {
+		config.AutoTCP = *input.AutoTCP
+	}
+
+	if len(input.PeerAllowedIPsOverrides) > 0 {
+		config.PeerAllowedIPsOverrides = input.PeerAllowedIPsOverrides
+	}
+
+	if input.LatencyColorThresholds != nil {
+		if err := ValidateLatencyColorThresholds(input.LatencyColorThresholds); err != nil {
+			return nil, err
+		}
+		config.LatencyColorThresholds = input.LatencyColorThresholds
+	}
+
 	defaultAdminURL, err := parseURL("Admin URL", DefaultAdminURL)
 	if err != nil {
 		return nil, err
@@ -224,6 +279,27 @@ func createNewConfig(input ConfigInput) (*Config, error) {
 	return config, nil
 }
 
+// MergeConfig returns the existing config, or a new one when it doesn't exist, updated with the input without writing it out
+func MergeConfig(input ConfigInput) (*Config, error) {
+	if !configFileIsExists(input.ConfigPath) {
+		return createNewConfig(input)
+	}
+
+	config := &Config{}
+	if _, err := util.ReadJson(input.ConfigPath, config); err != nil {
+		return nil, err
+	}
+
+	if isPreSharedKeyHidden(input.PreSharedKey) {
+		input.PreSharedKey = nil
+	}
+
+	if _, err := applyConfigInput(config, input); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
 func update(input ConfigInput) (*Config, error) {
 	config := &Config{}
 
@@ -231,6 +307,23 @@ func update(input ConfigInput) (*Config, error) {
 		return nil, err
 	}
 
+	refresh, err := applyConfigInput(config, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if refresh {
+		// since we have new management URL, we need to update config file
+		if err := util.WriteJson(input.ConfigPath, config); err != nil {
+			return nil, err
+		}
+	}
+
+	return config, nil
+}
+
+// applyConfigInput updates the config with the input and reports whether anything changed
+func applyConfigInput(config *Config, input ConfigInput) (bool, error) {
 	refresh := false
 
 	if input.ManagementURL != "" && config.ManagementURL.String() != input.ManagementURL {
@@ -238,7 +331,7 @@ func update(input ConfigInput) (*Config, error) {
 			input.ManagementURL, config.ManagementURL)
 		newURL, err := parseURL("Management URL", input.ManagementURL)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 		config.ManagementURL = newURL
 		refresh = true
@@ -249,7 +342,7 @@ func update(input ConfigInput) (*Config, error) {
 			input.AdminURL, config.AdminURL)
 		newURL, err := parseURL("Admin Panel URL", input.AdminURL)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 		config.AdminURL = newURL
 		refresh = true
@@ -264,7 +357,7 @@ func update(input ConfigInput) (*Config, error) {
 	if config.SSHKey == "" {
 		pem, err := ssh.GeneratePrivateKey(ssh.ED25519)
 		if err != nil {
-			return nil, err
+			return false, err
 		}
 		config.SSHKey = string(pem)
 		refresh = true
@@ -320,14 +413,75 @@ func update(input ConfigInput) (*Config, error) {
 		refresh = true
 	}
 
-	if refresh {
-		// since we have new management URL, we need to update config file
-		if err := util.WriteJson(input.ConfigPath, config); err != nil {
-			return nil, err
+	if config.CustomDNSTTL == 0 {
+		config.CustomDNSTTL = DefaultDNSTTL
+		refresh = true
+	}
+
+	if input.CustomDNSTTL != nil && config.CustomDNSTTL != *input.CustomDNSTTL {
+		if err := ValidateDNSTTL(*input.CustomDNSTTL); err != nil {
+			return false, err
 		}
+		log.Infof("new custom DNS TTL provided, updated to %s (old value %s)",
+			*input.CustomDNSTTL, config.CustomDNSTTL)
+		config.CustomDNSTTL = *input.CustomDNSTTL
+		refresh = true
 	}
 
-	return config, nil
+	if input.ForceTCP != nil && config.ForceTCP != *input.ForceTCP {
+		log.Infof("switching force TCP to %t", *input.ForceTCP)
+		config.ForceTCP = *input.ForceTCP
+		refresh = true
+	}
+
+	if input.AutoTCP != nil && config.AutoTCP != *input.AutoTCP {
+		log.Infof("switching auto TCP to %t", *input.AutoTCP)
+		config.AutoTCP = *input.AutoTCP
+		refresh = true
+	}
+
+	if input.PeerAllowedIPsOverrides != nil && !reflect.DeepEqual(config.PeerAllowedIPsOverrides, input.PeerAllowedIPsOverrides) {
+		log.Infof("new peer allowed IPs overrides provided for %d peers", len(input.PeerAllowedIPsOverrides))
+		config.PeerAllowedIPsOverrides = input.PeerAllowedIPsOverrides
+		if len(input.PeerAllowedIPsOverrides) == 0 {
+			config.PeerAllowedIPsOverrides = nil
+		}
+		refresh = true
+	}
+
+	if input.LatencyColorThresholds != nil && !reflect.DeepEqual(config.LatencyColorThresholds, input.LatencyColorThresholds) {
+		if err := ValidateLatencyColorThresholds(input.LatencyColorThresholds); err != nil {
+			return false, err
+		}
+		log.Infof("new latency color thresholds provided, updated to %v (old value %v)",
+			input.LatencyColorThresholds, config.LatencyColorThresholds)
+		config.LatencyColorThresholds = input.LatencyColorThresholds
+		refresh = true
+	}
+
+	return refresh, nil
+}
+
+// ValidateDNSTTL checks the custom TTL of the DNS records is between MinDNSTTL and MaxDNSTTL
+func ValidateDNSTTL(ttl time.Duration) error {
+	if ttl < MinDNSTTL || ttl > MaxDNSTTL {
+		return fmt.Errorf("invalid custom DNS TTL %s, it should be between 1s and 24h", ttl)
+	}
+	return nil
+}
+
+// ValidateLatencyColorThresholds checks there are three strictly increasing non-negative latency color thresholds
+func ValidateLatencyColorThresholds(thresholds []int) error {
+	if len(thresholds) != 3 {
+		return fmt.Errorf("invalid latency color thresholds %v, there should be 3", thresholds)
+	}
+
+	for i, threshold := range thresholds {
+		if threshold < 0 || (i > 0 && threshold <= thresholds[i-1]) {
+			return fmt.Errorf("invalid latency color thresholds %v, they should be strictly increasing non-negative milliseconds", thresholds)
+		}
+	}
+	return nil
 }
 
 // parseURL parses and validates a service URL
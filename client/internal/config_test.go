@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -86,6 +87,66 @@ func TestGetConfig(t *testing.T) {
 	assert.Equal(t, readConf.(*Config).ManagementURL.String(), newManagementURL)
 }
 
+func TestCustomDNSTTL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	config, err := UpdateOrCreateConfig(ConfigInput{
+		ConfigPath: path,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultDNSTTL, config.CustomDNSTTL)
+
+	ttl := 30 * time.Second
+	config, err = UpdateOrCreateConfig(ConfigInput{
+		ConfigPath:   path,
+		CustomDNSTTL: &ttl,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, ttl, config.CustomDNSTTL)
+
+	readConf, err := ReadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, ttl, readConf.CustomDNSTTL)
+
+	for _, invalid := range []time.Duration{-time.Second, 0, 500 * time.Millisecond, 25 * time.Hour} {
+		ttl := invalid
+		_, err = UpdateOrCreateConfig(ConfigInput{
+			ConfigPath:   path,
+			CustomDNSTTL: &ttl,
+		})
+		assert.Error(t, err, "%s", invalid)
+	}
+}
+
+func TestPeerAllowedIPsOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	overrides := map[string][]string{"peer-a": {"10.0.0.0/8"}}
+	config, err := UpdateOrCreateConfig(ConfigInput{
+		ConfigPath:              path,
+		PeerAllowedIPsOverrides: overrides,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, overrides, config.PeerAllowedIPsOverrides)
+
+	config, err = UpdateOrCreateConfig(ConfigInput{
+		ConfigPath: path,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, overrides, config.PeerAllowedIPsOverrides, "a nil input keeps the overrides")
+
+	config, err = UpdateOrCreateConfig(ConfigInput{
+		ConfigPath:              path,
+		PeerAllowedIPsOverrides: map[string][]string{},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, config.PeerAllowedIPsOverrides)
+
+	readConf, err := ReadConfig(path)
+	require.NoError(t, err)
+	assert.Empty(t, readConf.PeerAllowedIPsOverrides)
+}
+
 func TestHiddenPreSharedKey(t *testing.T) {
 	hidden := "**********"
 	samplePreSharedKey := "mysecretpresharedkey"
@@ -123,6 +184,33 @@ func TestHiddenPreSharedKey(t *testing.T) {
 	}
 }
 
+func TestMergeConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	managementURL := "https://test.management.url:33071"
+
+	// case 1: no config, a new one is generated but not written
+	config, err := MergeConfig(ConfigInput{ConfigPath: path, ManagementURL: managementURL})
+	require.NoError(t, err)
+	assert.Equal(t, managementURL, config.ManagementURL.String())
+	assert.NoFileExists(t, path)
+
+	// case 2: existing config, the input is applied but the file is left unchanged
+	existing, err := UpdateOrCreateConfig(ConfigInput{ConfigPath: path})
+	require.NoError(t, err)
+
+	wgPort := 51830
+	config, err = MergeConfig(ConfigInput{ConfigPath: path, ManagementURL: managementURL, WireguardPort: &wgPort})
+	require.NoError(t, err)
+	assert.Equal(t, existing.PrivateKey, config.PrivateKey)
+	assert.Equal(t, managementURL, config.ManagementURL.String())
+	assert.Equal(t, wgPort, config.WgPort)
+
+	stored, err := ReadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultManagementURL, stored.ManagementURL.String())
+	assert.Equal(t, existing.WgPort, stored.WgPort)
+}
+
 func TestUpdateOldManagementURL(t *testing.T) {
 	tests := []struct {
 		name                  string
@@ -238,8 +238,10 @@ func runClient(
 
 		log.Print("Netbird engine started, my IP is: ", peerConfig.Address)
 		state.Set(StatusConnected)
+		state.SetEngine(engine)
 
 		<-engineCtx.Done()
+		state.SetEngine(nil)
 		statusRecorder.ClientTeardown()
 
 		backOff.Reset()
@@ -286,6 +288,10 @@ func createEngineConfig(key wgtypes.Key, config *Config, peerConfig *mgmProto.Pe
 		RosenpassEnabled:     config.RosenpassEnabled,
 		RosenpassPermissive:  config.RosenpassPermissive,
 		ServerSSHAllowed:     util.ReturnBoolWithDefaultTrue(config.ServerSSHAllowed),
+		ForceTCP:             config.ForceTCP,
+		AutoTCP:              config.AutoTCP,
+
+		PeerAllowedIPsOverrides: config.PeerAllowedIPsOverrides,
 	}
 
 	if config.PreSharedKey != "" {
@@ -3,7 +3,9 @@ package dns
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/netip"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -197,6 +199,7 @@ func (s *DefaultServer) Stop() {
 	}
 
 	s.service.Stop()
+	s.statusRecorder.UpdateDNSListenAddress("")
 }
 
 // OnUpdatedHostDNSServer update the DNS servers addresses for root zones
@@ -322,10 +325,20 @@ func (s *DefaultServer) applyConfiguration(update nbdns.Config) error {
 	}
 
 	s.updateNSGroupStates(update.NameServerGroups)
+	s.updateListenAddress(update.ServiceEnable)
 
 	return nil
 }
 
+// updateListenAddress records the address of the local resolver, it is cleared when the service is disabled
+func (s *DefaultServer) updateListenAddress(serviceEnabled bool) {
+	var address string
+	if serviceEnabled {
+		address = net.JoinHostPort(s.service.RuntimeIP(), strconv.Itoa(s.service.RuntimePort()))
+	}
+	s.statusRecorder.UpdateDNSListenAddress(address)
+}
+
 func (s *DefaultServer) buildLocalHandlerUpdate(customZones []nbdns.CustomZone) ([]muxUpdate, map[string]nbdns.SimpleRecord, error) {
 	var muxUpdates []muxUpdate
 	localRecords := make(map[string]nbdns.SimpleRecord, 0)
@@ -22,6 +22,7 @@ import (
 	"github.com/netbirdio/netbird/client/internal/acl"
 	"github.com/netbirdio/netbird/client/internal/dns"
 	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/client/internal/ping"
 	"github.com/netbirdio/netbird/client/internal/relay"
 	"github.com/netbirdio/netbird/client/internal/rosenpass"
 	"github.com/netbirdio/netbird/client/internal/routemanager"
@@ -47,8 +48,14 @@ const (
 	PeerConnectionTimeoutMin = 30000 // ms
 )
 
+// peersLatencyInterval is how often the engine measures the latency to the connected peers
+const peersLatencyInterval = 15 * time.Second
+
 var ErrResetConnection = fmt.Errorf("reset connection")
 
+// ErrPeerNotConnected is returned for operations on the WireGuard session of a peer without connection
+var ErrPeerNotConnected = fmt.Errorf("peer is not connected")
+
 // EngineConfig is a config for the Engine
 type EngineConfig struct {
 	WgPort      int
@@ -83,6 +90,14 @@ type EngineConfig struct {
 	RosenpassPermissive bool
 
 	ServerSSHAllowed bool
+
+	// ForceTCP restricts peer connections to TURN relays reachable over TCP
+	ForceTCP bool
+	// AutoTCP switches a peer connection to TURN relays over TCP when UDP connectivity checks keep failing
+	AutoTCP bool
+
+	// PeerAllowedIPsOverrides maps peer FQDNs to CIDRs added to the allowed IPs pushed by management
+	PeerAllowedIPsOverrides map[string][]string
 }
 
 // Engine is a mechanism responsible for reacting on Signal and Management stream events and managing connections to the remote peers.
@@ -302,6 +317,7 @@ func (e *Engine) Start() error {
 	e.receiveSignalEvents()
 	e.receiveManagementEvents()
 	e.receiveProbeEvents()
+	go e.measurePeersLatency()
 
 	return nil
 }
@@ -315,7 +331,9 @@ func (e *Engine) modifyPeers(peersUpdate []*mgmProto.RemotePeerConfig) error {
 	for _, p := range peersUpdate {
 		peerPubKey := p.GetWgPubKey()
 		if peerConn, ok := e.peerConns[peerPubKey]; ok {
-			if peerConn.WgConfig().AllowedIps != strings.Join(p.AllowedIps, ",") {
+			wgConfig := peerConn.WgConfig()
+			if wgConfig.AllowedIps != strings.Join(p.GetAllowedIps(), ",") ||
+				strings.Join(wgConfig.ExtraAllowedIPs, ",") != strings.Join(e.peerExtraAllowedIPs(p), ",") {
 				modified = append(modified, p)
 				continue
 			}
@@ -323,6 +341,14 @@ func (e *Engine) modifyPeers(peersUpdate []*mgmProto.RemotePeerConfig) error {
 			if err != nil {
 				log.Warnf("error updating peer's %s fqdn in the status recorder, got error: %v", peerPubKey, err)
 			}
+			err = e.statusRecorder.UpdatePeerGroups(peerPubKey, p.GetGroups())
+			if err != nil {
+				log.Warnf("error updating peer's %s groups in the status recorder, got error: %v", peerPubKey, err)
+			}
+			err = e.statusRecorder.UpdatePeerOSInfo(peerPubKey, p.GetOsName(), p.GetOsVersion(), p.GetKernelVersion())
+			if err != nil {
+				log.Warnf("error updating peer's %s OS info in the status recorder, got error: %v", peerPubKey, err)
+			}
 		}
 	}
 
@@ -693,7 +719,7 @@ func (e *Engine) updateNetworkMap(networkMap *mgmProto.NetworkMap) error {
 		protoDNSConfig = &mgmProto.DNSConfig{}
 	}
 
-	err = e.dnsServer.UpdateDNSServer(serial, toDNSConfig(protoDNSConfig))
+	err = e.dnsServer.UpdateDNSServer(serial, toDNSConfig(protoDNSConfig, e.statusRecorder.GetCustomDNSTTL()))
 	if err != nil {
 		log.Errorf("failed to update dns server, err: %v", err)
 	}
@@ -728,7 +754,7 @@ func toRoutes(protoRoutes []*mgmProto.Route) []*route.Route {
 	return routes
 }
 
-func toDNSConfig(protoDNSConfig *mgmProto.DNSConfig) nbdns.Config {
+func toDNSConfig(protoDNSConfig *mgmProto.DNSConfig, customTTL time.Duration) nbdns.Config {
 	dnsUpdate := nbdns.Config{
 		ServiceEnable:    protoDNSConfig.GetServiceEnable(),
 		CustomZones:      make([]nbdns.CustomZone, 0),
@@ -747,6 +773,9 @@ func toDNSConfig(protoDNSConfig *mgmProto.DNSConfig) nbdns.Config {
 				TTL:   int(record.GetTTL()),
 				RData: record.GetRData(),
 			}
+			if customTTL > 0 {
+				dnsRecord.TTL = int(customTTL.Seconds())
+			}
 			dnsZone.Records = append(dnsZone.Records, dnsRecord)
 		}
 		dnsUpdate.CustomZones = append(dnsUpdate.CustomZones, dnsZone)
@@ -781,6 +810,10 @@ func (e *Engine) updateOfflinePeers(offlinePeers []*mgmProto.RemotePeerConfig) {
 			FQDN:             offlinePeer.GetFqdn(),
 			ConnStatus:       peer.StatusDisconnected,
 			ConnStatusUpdate: time.Now(),
+			Groups:           offlinePeer.GetGroups(),
+			OSName:           offlinePeer.GetOsName(),
+			OSVersion:        offlinePeer.GetOsVersion(),
+			KernelVersion:    offlinePeer.GetKernelVersion(),
 		}
 	}
 	e.statusRecorder.ReplaceOfflinePeers(replacement)
@@ -802,7 +835,7 @@ func (e *Engine) addNewPeer(peerConfig *mgmProto.RemotePeerConfig) error {
 	peerKey := peerConfig.GetWgPubKey()
 	peerIPs := peerConfig.GetAllowedIps()
 	if _, ok := e.peerConns[peerKey]; !ok {
-		conn, err := e.createPeerConn(peerKey, strings.Join(peerIPs, ","))
+		conn, err := e.createPeerConn(peerKey, strings.Join(peerIPs, ","), e.peerExtraAllowedIPs(peerConfig))
 		if err != nil {
 			return err
 		}
@@ -813,11 +846,33 @@ func (e *Engine) addNewPeer(peerConfig *mgmProto.RemotePeerConfig) error {
 			log.Warnf("error adding peer %s to status recorder, got error: %v", peerKey, err)
 		}
 
+		err = e.statusRecorder.UpdatePeerGroups(peerKey, peerConfig.GetGroups())
+		if err != nil {
+			log.Warnf("error updating peer's %s groups in the status recorder, got error: %v", peerKey, err)
+		}
+
+		err = e.statusRecorder.UpdatePeerOSInfo(peerKey, peerConfig.GetOsName(), peerConfig.GetOsVersion(), peerConfig.GetKernelVersion())
+		if err != nil {
+			log.Warnf("error updating peer's %s OS info in the status recorder, got error: %v", peerKey, err)
+		}
+
+		err = e.statusRecorder.UpdatePeerAllowedIPsOverride(peerKey, peerAllowedIPsOverride(e.config.PeerAllowedIPsOverrides, peerConfig.GetFqdn()))
+		if err != nil {
+			log.Warnf("error updating peer's %s allowed IPs override in the status recorder, got error: %v", peerKey, err)
+		}
+
 		go e.connWorker(conn, peerKey)
 	}
 	return nil
 }
 
+// peerExtraAllowedIPs returns the CIDRs of the configured override the allowed IPs pushed by management lack. They are
+// added to the WireGuard peer once it is configured, as the routes are
+func (e *Engine) peerExtraAllowedIPs(peerConfig *mgmProto.RemotePeerConfig) []string {
+	override := peerAllowedIPsOverride(e.config.PeerAllowedIPsOverrides, peerConfig.GetFqdn())
+	return extraAllowedIPs(peerConfig.GetAllowedIps(), override)
+}
+
 func (e *Engine) connWorker(conn *peer.Conn, peerKey string) {
 	for {
 
@@ -862,18 +917,49 @@ func (e *Engine) peerExists(peerKey string) bool {
 	return ok
 }
 
-func (e *Engine) createPeerConn(pubKey string, allowedIPs string) (*peer.Conn, error) {
+// RekeyPeer drops the WireGuard session keys of a connected peer, the next packet to it starts the handshake of a
+// new session
+func (e *Engine) RekeyPeer(peerKey string) error {
+	e.syncMsgMux.Lock()
+	defer e.syncMsgMux.Unlock()
+
+	conn, ok := e.peerConns[peerKey]
+	if !ok || conn.Status() != peer.StatusConnected {
+		return ErrPeerNotConnected
+	}
+
+	return e.wgInterface.RekeyPeer(peerKey)
+}
+
+// LastHandshake returns the time of the last WireGuard handshake with the peer
+func (e *Engine) LastHandshake(peerKey string) (time.Time, error) {
+	e.syncMsgMux.Lock()
+	defer e.syncMsgMux.Unlock()
+
+	if _, ok := e.peerConns[peerKey]; !ok {
+		return time.Time{}, ErrPeerNotConnected
+	}
+
+	stats, err := e.wgInterface.GetStats(peerKey)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return stats.LastHandshake, nil
+}
+
+func (e *Engine) createPeerConn(pubKey string, allowedIPs string, extraAllowedIPs []string) (*peer.Conn, error) {
 	log.Debugf("creating peer connection %s", pubKey)
 	var stunTurn []*stun.URI
 	stunTurn = append(stunTurn, e.STUNs...)
 	stunTurn = append(stunTurn, e.TURNs...)
 
 	wgConfig := peer.WgConfig{
-		RemoteKey:    pubKey,
-		WgListenPort: e.config.WgPort,
-		WgInterface:  e.wgInterface,
-		AllowedIps:   allowedIPs,
-		PreSharedKey: e.config.PreSharedKey,
+		RemoteKey:       pubKey,
+		WgListenPort:    e.config.WgPort,
+		WgInterface:     e.wgInterface,
+		AllowedIps:      allowedIPs,
+		PreSharedKey:    e.config.PreSharedKey,
+		ExtraAllowedIPs: extraAllowedIPs,
 	}
 
 	if e.config.RosenpassEnabled && !e.config.RosenpassPermissive {
@@ -913,6 +999,8 @@ func (e *Engine) createPeerConn(pubKey string, allowedIPs string) (*peer.Conn, e
 		UserspaceBind:        e.wgInterface.IsUserspaceBind(),
 		RosenpassPubKey:      e.getRosenpassPubKey(),
 		RosenpassAddr:        e.getRosenpassAddr(),
+		ForceTCP:             e.config.ForceTCP,
+		AutoTCP:              e.config.AutoTCP,
 	}
 
 	peerConn, err := peer.NewConn(config, e.statusRecorder, e.wgProxyFactory, e.mobileDep.TunAdapter, e.mobileDep.IFaceDiscover)
@@ -1137,7 +1225,7 @@ func (e *Engine) readInitialSettings() ([]*route.Route, *nbdns.Config, error) {
 		return nil, nil, err
 	}
 	routes := toRoutes(netMap.GetRoutes())
-	dnsCfg := toDNSConfig(netMap.GetDNSConfig())
+	dnsCfg := toDNSConfig(netMap.GetDNSConfig(), e.statusRecorder.GetCustomDNSTTL())
 	return routes, &dnsCfg, nil
 }
 
@@ -1296,6 +1384,9 @@ func (e *Engine) receiveProbeEvents() {
 				if err := e.statusRecorder.UpdateWireGuardPeerState(key, wgStats); err != nil {
 					log.Debugf("failed to update wg stats for peer %s: %s", key, err)
 				}
+				if err := e.statusRecorder.UpdatePeerTransportStats(key, peer.GetTransportStats()); err != nil {
+					log.Debugf("failed to update transport stats for peer %s: %s", key, err)
+				}
 			}
 
 			return true
@@ -1303,6 +1394,60 @@ func (e *Engine) receiveProbeEvents() {
 	}
 }
 
+// measurePeersLatency records the latency to the connected peers every peersLatencyInterval until the engine stops.
+// The status reads the recorded values, so it never waits for the pings
+func (e *Engine) measurePeersLatency() {
+	ticker := time.NewTicker(peersLatencyInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+		case <-ticker.C:
+			e.updatePeersLatency()
+		}
+	}
+}
+
+// updatePeersLatency measures the round trip time to every connected peer over the tunnel in parallel
+func (e *Engine) updatePeersLatency() {
+	e.syncMsgMux.Lock()
+	peerAddrs := make(map[string]netip.Addr)
+	for key, conn := range e.peerConns {
+		if conn.Status() != peer.StatusConnected {
+			continue
+		}
+
+		addr, err := netip.ParseAddr(strings.Split(conn.GetConf().WgConfig.AllowedIps, "/")[0])
+		if err != nil {
+			continue
+		}
+		peerAddrs[key] = addr
+	}
+	e.syncMsgMux.Unlock()
+
+	ctx, cancel := context.WithTimeout(e.ctx, ping.DefaultTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for key, addr := range peerAddrs {
+		wg.Add(1)
+		go func(key string, addr netip.Addr) {
+			defer wg.Done()
+			latency, err := ping.Ping(ctx, addr)
+			if err != nil {
+				log.Debugf("failed to measure latency to peer %s: %s", key, err)
+			}
+			// latency is zero on failure, which resets the previous measurement
+			if err := e.statusRecorder.UpdatePeerLatency(key, latency); err != nil {
+				log.Debugf("failed to update latency for peer %s: %s", key, err)
+			}
+		}(key, addr)
+	}
+	wg.Wait()
+}
+
 func (e *Engine) probeSTUNs() []relay.ProbeResult {
 	return relay.ProbeAll(e.ctx, relay.ProbeSTUN, e.STUNs)
 }
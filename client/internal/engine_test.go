@@ -131,7 +131,6 @@ func TestEngine_SSH(t *testing.T) {
 		Serial:             6,
 		PeerConfig:         nil,
 		RemotePeers:        []*mgmtProto.RemotePeerConfig{peerWithSSH},
-		RemotePeersIsEmpty: false,
 	}
 
 	err = engine.updateNetworkMap(networkMap)
@@ -147,7 +146,6 @@ func TestEngine_SSH(t *testing.T) {
 		PeerConfig: &mgmtProto.PeerConfig{Address: "100.64.0.1/24",
 			SshConfig: &mgmtProto.SSHConfig{SshEnabled: true}},
 		RemotePeers:        []*mgmtProto.RemotePeerConfig{peerWithSSH},
-		RemotePeersIsEmpty: false,
 	}
 
 	err = engine.updateNetworkMap(networkMap)
@@ -163,7 +161,6 @@ func TestEngine_SSH(t *testing.T) {
 	networkMap = &mgmtProto.NetworkMap{
 		Serial:             8,
 		RemotePeers:        []*mgmtProto.RemotePeerConfig{},
-		RemotePeersIsEmpty: false,
 	}
 
 	err = engine.updateNetworkMap(networkMap)
@@ -181,7 +178,6 @@ func TestEngine_SSH(t *testing.T) {
 		PeerConfig: &mgmtProto.PeerConfig{Address: "100.64.0.1/24",
 			SshConfig: &mgmtProto.SSHConfig{SshEnabled: false}},
 		RemotePeers:        []*mgmtProto.RemotePeerConfig{peerWithSSH},
-		RemotePeersIsEmpty: false,
 	}
 
 	err = engine.updateNetworkMap(networkMap)
@@ -377,6 +373,69 @@ func TestEngine_UpdateNetworkMap(t *testing.T) {
 	}
 }
 
+func TestEngine_UpdateNetworkMapWithAllowedIPsOverride(t *testing.T) {
+	key, err := wgtypes.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	engine := NewEngine(ctx, cancel, &signal.MockClient{}, &mgmt.MockClient{}, &EngineConfig{
+		WgIfaceName:  "utun150",
+		WgAddr:       "100.64.0.1/24",
+		WgPrivateKey: key,
+		WgPort:       33100,
+		PeerAllowedIPsOverrides: map[string][]string{
+			"peer-a": {"100.64.0.10/32", "10.0.0.0/8"},
+		},
+	}, MobileDependency{}, peer.NewRecorder("https://mgm"))
+	newNet, err := stdnet.NewNet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine.wgInterface, err = iface.NewWGIFace("utun150", "100.64.0.1/24", engine.config.WgPort, key.String(), iface.DefaultMTU, newNet, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine.routeManager = routemanager.NewManager(ctx, key.PublicKey().String(), engine.wgInterface, engine.statusRecorder, nil)
+	engine.dnsServer = &dns.MockServer{
+		UpdateDNSServerFunc: func(serial uint64, update nbdns.Config) error { return nil },
+	}
+	conn, err := net.ListenUDP("udp4", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine.udpMux = bind.NewUniversalUDPMuxDefault(bind.UniversalUDPMuxParams{UDPConn: conn})
+
+	remotePeer := &mgmtProto.RemotePeerConfig{
+		WgPubKey:   "RRHf3Ma6z6mdLbriAJbqhX7+nM/B71lgw2+91q3LfhU=",
+		AllowedIps: []string{"100.64.0.10/32"},
+		Fqdn:       "peer-a.netbird.cloud",
+	}
+	err = engine.updateNetworkMap(&mgmtProto.NetworkMap{
+		Serial:      1,
+		RemotePeers: []*mgmtProto.RemotePeerConfig{remotePeer},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	peerConn, ok := engine.peerConns[remotePeer.GetWgPubKey()]
+	if !ok {
+		t.Fatalf("expecting peer %s to be created", remotePeer.GetWgPubKey())
+	}
+	wgConfig := peerConn.WgConfig()
+	if wgConfig.AllowedIps != "100.64.0.10/32" {
+		t.Errorf("expecting the WireGuard config to only hold the peer IP, got %s", wgConfig.AllowedIps)
+	}
+	if len(wgConfig.ExtraAllowedIPs) != 1 || wgConfig.ExtraAllowedIPs[0] != "10.0.0.0/8" {
+		t.Errorf("expecting the override 10.0.0.0/8 to be added to the peer, got %v", wgConfig.ExtraAllowedIPs)
+	}
+}
+
 func TestEngine_Sync(t *testing.T) {
 	key, err := wgtypes.GeneratePrivateKey()
 	if err != nil {
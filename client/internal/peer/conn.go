@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -28,14 +29,32 @@ const (
 	iceDisconnectedTimeoutDefault = 6 * time.Second
 
 	defaultWgKeepAlive = 25 * time.Second
+
+	// autoTCPFailureThreshold is the number of consecutive failed ICE attempts before AutoTCP switches to TCP relays
+	autoTCPFailureThreshold = 3
+	// autoTCPRetryInterval is how long AutoTCP keeps using TCP relays before UDP is tried again
+	autoTCPRetryInterval = 10 * time.Minute
 )
 
+// WireGuardCipherSuite is the fixed Noise_IKpsk2 suite of WireGuard, it has no cipher negotiation
+const WireGuardCipherSuite = "ChaCha20-Poly1305/Curve25519"
+
+// cipherSuite returns the cipher suite of a connection, Rosenpass adds its post-quantum key to the WireGuard preshared key
+func cipherSuite(rosenpassEnabled bool) string {
+	if rosenpassEnabled {
+		return WireGuardCipherSuite + "+Rosenpass"
+	}
+	return WireGuardCipherSuite
+}
+
 type WgConfig struct {
 	WgListenPort int
 	RemoteKey    string
 	WgInterface  *iface.WGIface
 	AllowedIps   string
 	PreSharedKey *wgtypes.Key
+	// ExtraAllowedIPs are added to the allowed IPs of the peer once it is configured, e.g., the local overrides
+	ExtraAllowedIPs []string
 }
 
 // ConnConfig is a peer Connection configuration
@@ -72,6 +91,12 @@ type ConnConfig struct {
 	RosenpassPubKey []byte
 	// RosenpassPubKey is this peer's RosenpassAddr server address (IP:port)
 	RosenpassAddr string
+
+	// ForceTCP restricts the connection to TURN relays reachable over TCP
+	ForceTCP bool
+	// AutoTCP switches the connection to TURN relays over TCP after autoTCPFailureThreshold failed ICE attempts and
+	// tries UDP again after autoTCPRetryInterval
+	AutoTCP bool
 }
 
 // OfferAnswer represents a session establishment offer or answer
@@ -133,6 +158,15 @@ type Conn struct {
 	adapter        iface.TunAdapter
 	iFaceDiscover  stdnet.ExternalIFaceDiscover
 	sentExtraSrflx bool
+
+	// iceFailures counts the consecutive ICE attempts that ended without a working candidate pair
+	iceFailures int
+	// iceConnected is set once the connectivity checks of the current ICE agent succeeded
+	iceConnected bool
+	// tcpFallback is set once AutoTCP detected that UDP is blocked
+	tcpFallback bool
+	// tcpFallbackSince is when AutoTCP switched to TCP relays
+	tcpFallbackSince time.Time
 }
 
 // meta holds meta information about a connection
@@ -185,6 +219,9 @@ func (conn *Conn) reCreateAgent() error {
 
 	failedTimeout := 6 * time.Second
 
+	conn.iceConnected = false
+	conn.retryUDP()
+
 	var err error
 	transportNet, err := conn.newStdNet()
 	if err != nil {
@@ -213,6 +250,15 @@ func (conn *Conn) reCreateAgent() error {
 		agentConfig.NetworkTypes = []ice.NetworkType{ice.NetworkTypeUDP4}
 	}
 
+	if conn.useTCP() {
+		tcpRelays := tcpRelayURIs(conn.config.StunTurn)
+		if len(tcpRelays) == 0 {
+			return fmt.Errorf("TCP transport requested for peer %s but no TURN relay over TCP is available", conn.config.Key)
+		}
+		agentConfig.Urls = tcpRelays
+		agentConfig.CandidateTypes = []ice.CandidateType{ice.CandidateTypeRelay}
+	}
+
 	conn.agent, err = ice.NewAgent(agentConfig)
 
 	if err != nil {
@@ -237,6 +283,67 @@ func (conn *Conn) reCreateAgent() error {
 	return nil
 }
 
+// useTCP returns true when the connection should only use TURN relays over TCP
+func (conn *Conn) useTCP() bool {
+	return conn.config.ForceTCP || conn.tcpFallback
+}
+
+// onICEConnected records that the connectivity checks succeeded
+func (conn *Conn) onICEConnected() {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	conn.iceConnected = true
+	conn.iceFailures = 0
+}
+
+// onICEFailed records a failed ICE attempt and enables the TCP fallback when AutoTCP is set
+// and the attempts kept failing over UDP. A connection that failed after its checks succeeded is not counted
+func (conn *Conn) onICEFailed() {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	if !conn.config.AutoTCP || conn.useTCP() || conn.iceConnected {
+		return
+	}
+
+	conn.iceFailures++
+	if conn.iceFailures < autoTCPFailureThreshold || len(tcpRelayURIs(conn.config.StunTurn)) == 0 {
+		return
+	}
+
+	log.Infof("no connectivity check with peer %s succeeded after %d attempts, UDP seems to be blocked, switching to TURN over TCP",
+		conn.config.Key, conn.iceFailures)
+	conn.tcpFallback = true
+	conn.tcpFallbackSince = time.Now()
+}
+
+// retryUDP clears the TCP fallback of AutoTCP after autoTCPRetryInterval so the next ICE attempt gathers UDP candidates
+// again. It must be called with the lock held
+func (conn *Conn) retryUDP() {
+	if !conn.tcpFallback || time.Since(conn.tcpFallbackSince) < autoTCPRetryInterval {
+		return
+	}
+
+	log.Infof("retrying UDP with peer %s after %s on TURN over TCP", conn.config.Key, autoTCPRetryInterval)
+	conn.tcpFallback = false
+	conn.iceFailures = 0
+}
+
+// tcpRelayURIs returns the TURN URIs reachable over TCP or TLS
+func tcpRelayURIs(uris []*stun.URI) []*stun.URI {
+	var relays []*stun.URI
+	for _, uri := range uris {
+		if uri.Proto != stun.ProtoTypeTCP {
+			continue
+		}
+		if uri.Scheme == stun.SchemeTypeTURN || uri.Scheme == stun.SchemeTypeTURNS {
+			relays = append(relays, uri)
+		}
+	}
+	return relays
+}
+
 func (conn *Conn) candidateTypes() []ice.CandidateType {
 	if hasICEForceRelayConn() {
 		return []ice.CandidateType{ice.CandidateTypeRelay}
@@ -372,6 +479,15 @@ func isRelayCandidate(candidate ice.Candidate) bool {
 	return candidate.Type() == ice.CandidateTypeRelay
 }
 
+// isTCPRelayCandidate returns true when the candidate is relayed by a TURN server reached over TCP or TLS
+func isTCPRelayCandidate(candidate ice.Candidate) bool {
+	relay, ok := candidate.(*ice.CandidateRelay)
+	if !ok {
+		return false
+	}
+	return relay.RelayProtocol() == "tcp" || relay.RelayProtocol() == "tls"
+}
+
 // configureConnection starts proxying traffic from/to local Wireguard and sets connection status to StatusConnected
 func (conn *Conn) configureConnection(remoteConn net.Conn, remoteWgPort int, remoteRosenpassPubKey []byte, remoteRosenpassAddr string) (net.Addr, error) {
 	conn.mu.Lock()
@@ -406,6 +522,13 @@ func (conn *Conn) configureConnection(remoteConn net.Conn, remoteWgPort int, rem
 		return nil, err
 	}
 
+	for _, allowedIP := range conn.config.WgConfig.ExtraAllowedIPs {
+		err = conn.config.WgConfig.WgInterface.AddAllowedIP(conn.config.WgConfig.RemoteKey, allowedIP)
+		if err != nil {
+			log.Warnf("failed adding allowed IP %s to peer %s: %v", allowedIP, conn.config.Key, err)
+		}
+	}
+
 	conn.status = StatusConnected
 	rosenpassEnabled := false
 	if remoteRosenpassPubKey != nil {
@@ -422,6 +545,7 @@ func (conn *Conn) configureConnection(remoteConn net.Conn, remoteWgPort int, rem
 		RemoteIceCandidateEndpoint: fmt.Sprintf("%s:%d", pair.Remote.Address(), pair.Local.Port()),
 		Direct:                     !isRelayCandidate(pair.Local),
 		RosenpassEnabled:           rosenpassEnabled,
+		CipherSuite:                cipherSuite(rosenpassEnabled),
 	}
 	if pair.Local.Type() == ice.CandidateTypeRelay || pair.Remote.Type() == ice.CandidateTypeRelay {
 		peerState.Relayed = true
@@ -444,6 +568,45 @@ func (conn *Conn) configureConnection(remoteConn net.Conn, remoteWgPort int, rem
 	return endpoint, nil
 }
 
+// GetTransportStats returns the transport layer statistics of the selected ICE candidate pair.
+// The zero value is returned when the connection has no selected pair
+func (conn *Conn) GetTransportStats() TransportStats {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	if conn.agent == nil || conn.status != StatusConnected {
+		return TransportStats{}
+	}
+
+	pair, err := conn.agent.GetSelectedCandidatePair()
+	if err != nil || pair == nil {
+		return TransportStats{}
+	}
+
+	protocol := "UDP"
+	if pair.Local.NetworkType().IsTCP() || isTCPRelayCandidate(pair.Local) {
+		protocol = "TCP"
+	}
+
+	stats := TransportStats{
+		Protocol:      protocol,
+		LocalPort:     pair.Local.Port(),
+		RemoteAddress: net.JoinHostPort(pair.Remote.Address(), strconv.Itoa(pair.Remote.Port())),
+	}
+
+	for _, pairStats := range conn.agent.GetCandidatePairsStats() {
+		if pairStats.LocalCandidateID != pair.Local.ID() || pairStats.RemoteCandidateID != pair.Remote.ID() {
+			continue
+		}
+		stats.PacketsSent = int64(pairStats.PacketsSent)
+		stats.PacketsReceived = int64(pairStats.PacketsReceived)
+		stats.Retransmits = int64(pairStats.RetransmissionsSent)
+		break
+	}
+
+	return stats
+}
+
 func (conn *Conn) punchRemoteWGPort(pair *ice.CandidatePair, remoteWgPort int) {
 	// wait local endpoint configuration
 	time.Sleep(time.Second)
@@ -601,6 +764,13 @@ func (conn *Conn) onICESelectedCandidatePair(c1 ice.Candidate, c2 ice.Candidate)
 // onICEConnectionStateChange registers callback of an ICE Agent to track connection state
 func (conn *Conn) onICEConnectionStateChange(state ice.ConnectionState) {
 	log.Debugf("peer %s ICE ConnectionState has changed to %s", conn.config.Key, state.String())
+	switch state {
+	case ice.ConnectionStateConnected:
+		conn.onICEConnected()
+	case ice.ConnectionStateFailed:
+		conn.onICEFailed()
+	}
+
 	if state == ice.ConnectionStateFailed || state == ice.ConnectionStateDisconnected {
 		conn.notifyDisconnected()
 	}
@@ -182,3 +182,51 @@ func TestConn_Close(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestConn_AutoTCP(t *testing.T) {
+	udpRelay, err := stun.ParseURI("turn:turn.netbird.io:3478?transport=udp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tcpRelay, err := stun.ParseURI("turn:turn.netbird.io:3478?transport=tcp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stunServer, err := stun.ParseURI("stun:stun.netbird.io:5555")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, tcpRelayURIs([]*stun.URI{stunServer, udpRelay, tcpRelay}), []*stun.URI{tcpRelay})
+
+	config := connConf
+	config.AutoTCP = true
+	config.StunTurn = []*stun.URI{stunServer, udpRelay, tcpRelay}
+
+	conn, err := NewConn(config, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < autoTCPFailureThreshold-1; i++ {
+		conn.onICEFailed()
+	}
+	assert.Equal(t, conn.useTCP(), false)
+
+	conn.onICEFailed()
+	assert.Equal(t, conn.useTCP(), true)
+
+	conn.retryUDP()
+	assert.Equal(t, conn.useTCP(), true, "UDP is only retried after autoTCPRetryInterval")
+
+	conn.tcpFallbackSince = time.Now().Add(-autoTCPRetryInterval)
+	conn.retryUDP()
+	assert.Equal(t, conn.useTCP(), false)
+	assert.Equal(t, conn.iceFailures, 0)
+
+	conn.onICEConnected()
+	for i := 0; i < autoTCPFailureThreshold; i++ {
+		conn.onICEFailed()
+	}
+	assert.Equal(t, conn.useTCP(), false, "a connection lost after the checks succeeded is not a blocked UDP")
+}
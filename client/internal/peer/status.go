@@ -12,6 +12,13 @@ import (
 	"github.com/netbirdio/netbird/iface"
 )
 
+const (
+	// peerEventsRetention is how long the connection status changes of a peer are kept
+	peerEventsRetention = 24 * time.Hour
+	// maxPeerEvents caps the history of a flapping peer
+	maxPeerEvents = 1000
+)
+
 // State contains the latest state of a peer
 type State struct {
 	IP                         string
@@ -30,6 +37,40 @@ type State struct {
 	BytesRx                    int64
 	RosenpassEnabled           bool
 	Routes                     map[string]struct{}
+	TransportStats             TransportStats
+	Latency                    time.Duration
+	// AdvertisedRoutes are the networks the peer advertises as a routing peer, whether selected locally or not
+	AdvertisedRoutes []string
+	// Version is the status version at which the peer was added or changed its connection status
+	Version uint64
+	// Groups are the names of the management groups the peer belongs to
+	Groups []string
+	// AllowedIPs are the allowed IPs of the peer as configured on the WireGuard interface
+	AllowedIPs []string
+	// AllowedIPsOverride are the CIDRs added to the allowed IPs pushed by management for the peer
+	AllowedIPsOverride []string
+	// OSName, OSVersion and KernelVersion describe the system of the peer as reported to management
+	OSName        string
+	OSVersion     string
+	KernelVersion string
+	// CipherSuite describes the cryptographic primitives of the current session, empty while not connected
+	CipherSuite string
+}
+
+// StatusEvent is a connection status change of a peer
+type StatusEvent struct {
+	ConnStatus ConnStatus
+	Timestamp  time.Time
+}
+
+// TransportStats contains the transport layer statistics of the selected ICE candidate pair of a peer
+type TransportStats struct {
+	Protocol        string
+	LocalPort       int
+	RemoteAddress   string
+	PacketsSent     int64
+	PacketsReceived int64
+	Retransmits     int64
 }
 
 // LocalPeerState contains the latest state of the local peer
@@ -80,6 +121,10 @@ type FullStatus struct {
 	RosenpassState  RosenpassState
 	Relays          []relay.ProbeResult
 	NSGroupStates   []NSGroupState
+	// DNSListenAddress is the ip:port the local DNS server listens on, empty when the DNS service is disabled
+	DNSListenAddress string
+	// Version increases every time a peer is added or changes its connection status
+	Version uint64
 }
 
 // Status holds a state of peers, signal, management connections and relays
@@ -100,6 +145,12 @@ type Status struct {
 	rosenpassEnabled    bool
 	rosenpassPermissive bool
 	nsGroupStates       []NSGroupState
+	dnsListenAddress    string
+	customDNSTTL        time.Duration
+	routeMetrics        map[string]int
+	peerEvents          map[string][]StatusEvent
+	advertisedRoutes    map[string][]string
+	version             uint64
 
 	// To reduce the number of notification invocation this bool will be true when need to call the notification
 	// Some Peer actions mostly used by in a batch when the network map has been synchronized. In these type of events
@@ -110,11 +161,14 @@ type Status struct {
 // NewRecorder returns a new Status instance
 func NewRecorder(mgmAddress string) *Status {
 	return &Status{
-		peers:        make(map[string]State),
-		changeNotify: make(map[string]chan struct{}),
-		offlinePeers: make([]State, 0),
-		notifier:     newNotifier(),
-		mgmAddress:   mgmAddress,
+		peers:            make(map[string]State),
+		changeNotify:     make(map[string]chan struct{}),
+		offlinePeers:     make([]State, 0),
+		routeMetrics:     make(map[string]int),
+		peerEvents:       make(map[string][]StatusEvent),
+		advertisedRoutes: make(map[string][]string),
+		notifier:         newNotifier(),
+		mgmAddress:       mgmAddress,
 	}
 }
 
@@ -138,10 +192,12 @@ func (d *Status) AddPeer(peerPubKey string, fqdn string) error {
 	if ok {
 		return errors.New("peer already exist")
 	}
+	d.version++
 	d.peers[peerPubKey] = State{
 		PubKey:     peerPubKey,
 		ConnStatus: StatusDisconnected,
 		FQDN:       fqdn,
+		Version:    d.version,
 	}
 	d.peerListChangedForNotification = true
 	return nil
@@ -170,6 +226,7 @@ func (d *Status) RemovePeer(peerPubKey string) error {
 	}
 
 	delete(d.peers, peerPubKey)
+	delete(d.peerEvents, peerPubKey)
 	d.peerListChangedForNotification = true
 	return nil
 }
@@ -204,6 +261,10 @@ func (d *Status) UpdatePeerState(receivedState State) error {
 		peerState.LocalIceCandidateEndpoint = receivedState.LocalIceCandidateEndpoint
 		peerState.RemoteIceCandidateEndpoint = receivedState.RemoteIceCandidateEndpoint
 		peerState.RosenpassEnabled = receivedState.RosenpassEnabled
+		peerState.CipherSuite = receivedState.CipherSuite
+		d.recordPeerEvent(receivedState.PubKey, receivedState.ConnStatus, receivedState.ConnStatusUpdate)
+		d.version++
+		peerState.Version = d.version
 	}
 
 	d.peers[receivedState.PubKey] = peerState
@@ -235,12 +296,107 @@ func (d *Status) UpdateWireGuardPeerState(pubKey string, wgStats iface.WGStats)
 	peerState.LastWireguardHandshake = wgStats.LastHandshake
 	peerState.BytesRx = wgStats.RxBytes
 	peerState.BytesTx = wgStats.TxBytes
+	peerState.AllowedIPs = wgStats.AllowedIPs
+
+	d.peers[pubKey] = peerState
+
+	return nil
+}
+
+// UpdatePeerTransportStats updates the transport layer statistics of the peer state
+func (d *Status) UpdatePeerTransportStats(pubKey string, stats TransportStats) error {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	peerState, ok := d.peers[pubKey]
+	if !ok {
+		return errors.New("peer doesn't exist")
+	}
+
+	peerState.TransportStats = stats
+
+	d.peers[pubKey] = peerState
+
+	return nil
+}
+
+// UpdatePeerLatency updates the measured round trip time of the peer state
+func (d *Status) UpdatePeerLatency(pubKey string, latency time.Duration) error {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	peerState, ok := d.peers[pubKey]
+	if !ok {
+		return errors.New("peer doesn't exist")
+	}
+
+	peerState.Latency = latency
 
 	d.peers[pubKey] = peerState
 
 	return nil
 }
 
+// UpdateRouteMetric stores the metric of the route chosen for the given network
+func (d *Status) UpdateRouteMetric(network string, metric int) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.routeMetrics[network] = metric
+}
+
+// DeleteRouteMetric removes the metric of the route chosen for the given network
+func (d *Status) DeleteRouteMetric(network string) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	delete(d.routeMetrics, network)
+}
+
+// GetRouteMetric returns the metric of the route chosen for the given network
+func (d *Status) GetRouteMetric(network string) (int, bool) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	metric, ok := d.routeMetrics[network]
+	return metric, ok
+}
+
+// UpdateAdvertisedRoutes replaces the networks advertised by every routing peer, keyed by the peer public key
+func (d *Status) UpdateAdvertisedRoutes(advertisedRoutes map[string][]string) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.advertisedRoutes = advertisedRoutes
+}
+
+// GetPeerEvents returns the recorded connection status changes of a peer, oldest first
+func (d *Status) GetPeerEvents(peerPubKey string) []StatusEvent {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	events := make([]StatusEvent, len(d.peerEvents[peerPubKey]))
+	copy(events, d.peerEvents[peerPubKey])
+	return events
+}
+
+// recordPeerEvent appends a status change to the peer history. Events older than peerEventsRetention are dropped,
+// except the latest of them which still tells the status at the start of the retention window
+func (d *Status) recordPeerEvent(peerPubKey string, status ConnStatus, timestamp time.Time) {
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	events := append(d.peerEvents[peerPubKey], StatusEvent{ConnStatus: status, Timestamp: timestamp})
+
+	cutoff := timestamp.Add(-peerEventsRetention)
+	first := 0
+	for first < len(events)-1 && !events[first+1].Timestamp.After(cutoff) {
+		first++
+	}
+	if len(events)-first > maxPeerEvents {
+		first = len(events) - maxPeerEvents
+	}
+
+	d.peerEvents[peerPubKey] = events[first:]
+}
+
 func shouldSkipNotify(received, curr State) bool {
 	switch {
 	case received.ConnStatus == StatusConnecting:
@@ -270,6 +426,56 @@ func (d *Status) UpdatePeerFQDN(peerPubKey, fqdn string) error {
 	return nil
 }
 
+// UpdatePeerGroups updates the management groups of the peer
+func (d *Status) UpdatePeerGroups(peerPubKey string, groups []string) error {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	peerState, ok := d.peers[peerPubKey]
+	if !ok {
+		return errors.New("peer doesn't exist")
+	}
+
+	peerState.Groups = groups
+	d.peers[peerPubKey] = peerState
+
+	return nil
+}
+
+// UpdatePeerOSInfo updates the OS and kernel of the peer
+func (d *Status) UpdatePeerOSInfo(peerPubKey, osName, osVersion, kernelVersion string) error {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	peerState, ok := d.peers[peerPubKey]
+	if !ok {
+		return errors.New("peer doesn't exist")
+	}
+
+	peerState.OSName = osName
+	peerState.OSVersion = osVersion
+	peerState.KernelVersion = kernelVersion
+	d.peers[peerPubKey] = peerState
+
+	return nil
+}
+
+// UpdatePeerAllowedIPsOverride updates the CIDRs added to the allowed IPs of the peer by the local config
+func (d *Status) UpdatePeerAllowedIPsOverride(peerPubKey string, override []string) error {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	peerState, ok := d.peers[peerPubKey]
+	if !ok {
+		return errors.New("peer doesn't exist")
+	}
+
+	peerState.AllowedIPsOverride = override
+	d.peers[peerPubKey] = peerState
+
+	return nil
+}
+
 // FinishPeerListModifications this event invoke the notification
 func (d *Status) FinishPeerListModifications() {
 	d.mux.Lock()
@@ -395,6 +601,27 @@ func (d *Status) UpdateDNSStates(dnsStates []NSGroupState) {
 	d.nsGroupStates = dnsStates
 }
 
+// UpdateCustomDNSTTL stores the configured TTL of the DNS records pushed to the local resolver
+func (d *Status) UpdateCustomDNSTTL(ttl time.Duration) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.customDNSTTL = ttl
+}
+
+// GetCustomDNSTTL returns the configured TTL of the DNS records pushed to the local resolver, zero when not set
+func (d *Status) GetCustomDNSTTL() time.Duration {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	return d.customDNSTTL
+}
+
+// UpdateDNSListenAddress stores the ip:port the local DNS server listens on
+func (d *Status) UpdateDNSListenAddress(address string) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+	d.dnsListenAddress = address
+}
+
 func (d *Status) GetRosenpassState() RosenpassState {
 	return RosenpassState{
 		d.rosenpassEnabled,
@@ -450,12 +677,14 @@ func (d *Status) GetFullStatus() FullStatus {
 	defer d.mux.Unlock()
 
 	fullStatus := FullStatus{
-		ManagementState: d.GetManagementState(),
-		SignalState:     d.GetSignalState(),
-		LocalPeerState:  d.localPeer,
-		Relays:          d.GetRelayStates(),
-		RosenpassState:  d.GetRosenpassState(),
-		NSGroupStates:   d.GetDNSStates(),
+		ManagementState:  d.GetManagementState(),
+		SignalState:      d.GetSignalState(),
+		LocalPeerState:   d.localPeer,
+		Relays:           d.GetRelayStates(),
+		RosenpassState:   d.GetRosenpassState(),
+		NSGroupStates:    d.GetDNSStates(),
+		DNSListenAddress: d.dnsListenAddress,
+		Version:          d.version,
 	}
 
 	for _, status := range d.peers {
@@ -464,6 +693,10 @@ func (d *Status) GetFullStatus() FullStatus {
 
 	fullStatus.Peers = append(fullStatus.Peers, d.offlinePeers...)
 
+	for i := range fullStatus.Peers {
+		fullStatus.Peers[i].AdvertisedRoutes = d.advertisedRoutes[fullStatus.Peers[i].PubKey]
+	}
+
 	return fullStatus
 }
 
@@ -3,6 +3,7 @@ package peer
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -236,3 +237,78 @@ func TestGetFullStatus(t *testing.T) {
 	assert.Equal(t, signalState, fullStatus.SignalState, "signal status should be equal")
 	assert.ElementsMatch(t, []State{peerState1, peerState2}, fullStatus.Peers, "peers states should match")
 }
+
+func TestGetPeerEvents(t *testing.T) {
+	key := "abc"
+	status := NewRecorder("https://mgm")
+	err := status.AddPeer(key, "abc.netbird")
+	assert.NoError(t, err, "shouldn't return error")
+
+	now := time.Now()
+	updates := []State{
+		{PubKey: key, ConnStatus: StatusConnected, ConnStatusUpdate: now.Add(-30 * time.Hour)},
+		{PubKey: key, ConnStatus: StatusDisconnected, ConnStatusUpdate: now.Add(-26 * time.Hour)},
+		{PubKey: key, ConnStatus: StatusConnected, ConnStatusUpdate: now.Add(-time.Hour)},
+		{PubKey: key, ConnStatus: StatusConnected, ConnStatusUpdate: now},
+	}
+	for _, update := range updates {
+		err = status.UpdatePeerState(update)
+		assert.NoError(t, err, "shouldn't return error")
+	}
+
+	expected := []StatusEvent{
+		{ConnStatus: StatusDisconnected, Timestamp: now.Add(-26 * time.Hour)},
+		{ConnStatus: StatusConnected, Timestamp: now.Add(-time.Hour)},
+	}
+	assert.Equal(t, expected, status.GetPeerEvents(key), "only the last event before the retention window should be kept")
+
+	err = status.RemovePeer(key)
+	assert.NoError(t, err, "shouldn't return error")
+	assert.Empty(t, status.GetPeerEvents(key), "events should be removed with the peer")
+}
+
+func TestStatusVersion(t *testing.T) {
+	key := "abc"
+	status := NewRecorder("https://mgm")
+	err := status.AddPeer(key, "abc.netbird")
+	assert.NoError(t, err, "shouldn't return error")
+	assert.Equal(t, uint64(1), status.GetFullStatus().Version, "adding a peer should increase the version")
+
+	err = status.UpdatePeerState(State{PubKey: key, ConnStatus: StatusConnected})
+	assert.NoError(t, err, "shouldn't return error")
+
+	err = status.UpdatePeerState(State{PubKey: key, ConnStatus: StatusConnected, IP: "10.10.10.10"})
+	assert.NoError(t, err, "shouldn't return error")
+
+	fullStatus := status.GetFullStatus()
+	assert.Equal(t, uint64(2), fullStatus.Version, "only connection status changes should increase the version")
+	assert.Equal(t, uint64(2), fullStatus.Peers[0].Version, "peer version should match the version of its last change")
+}
+
+func TestStatus_UpdatePeerGroups(t *testing.T) {
+	key := "abc"
+	status := NewRecorder("https://mgm")
+	assert.Error(t, status.UpdatePeerGroups(key, []string{"databases"}))
+
+	assert.NoError(t, status.AddPeer(key, "peer-a.netbird.cloud"))
+	assert.NoError(t, status.UpdatePeerGroups(key, []string{"All", "databases"}))
+
+	state, err := status.GetPeer(key)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"All", "databases"}, state.Groups)
+}
+
+func TestStatus_UpdatePeerOSInfo(t *testing.T) {
+	key := "abc"
+	status := NewRecorder("https://mgm")
+	assert.Error(t, status.UpdatePeerOSInfo(key, "Ubuntu", "22.04", "Linux 5.15.0"))
+
+	assert.NoError(t, status.AddPeer(key, "peer-a.netbird.cloud"))
+	assert.NoError(t, status.UpdatePeerOSInfo(key, "Ubuntu", "22.04", "Linux 5.15.0"))
+
+	state, err := status.GetPeer(key)
+	assert.NoError(t, err)
+	assert.Equal(t, "Ubuntu", state.OSName)
+	assert.Equal(t, "22.04", state.OSVersion)
+	assert.Equal(t, "Linux 5.15.0", state.KernelVersion)
+}
@@ -0,0 +1,120 @@
+// Package ping measures the round trip time to a host with ICMP echo requests
+package ping
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/netip"
+	"os"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+)
+
+// DefaultTimeout is used when the context has no deadline
+const DefaultTimeout = time.Second
+
+const protocolICMP = 1
+
+// Ping sends a single ICMP echo request to the given IPv4 address and returns the round trip time of the reply.
+// It uses a raw ICMP socket and falls back to an unprivileged datagram socket when raw sockets are not permitted
+func Ping(ctx context.Context, addr netip.Addr) (time.Duration, error) {
+	if !addr.Is4() {
+		return 0, fmt.Errorf("only IPv4 addresses are supported, got %s", addr)
+	}
+
+	conn, privileged, err := listen()
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(DefaultTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return 0, fmt.Errorf("set deadline: %w", err)
+	}
+
+	id := os.Getpid() & 0xffff
+	seq := rand.Intn(0xffff)
+	request := icmp.Message{
+		Type: ipv4.ICMPTypeEcho,
+		Body: &icmp.Echo{ID: id, Seq: seq, Data: []byte("netbird")},
+	}
+	payload, err := request.Marshal(nil)
+	if err != nil {
+		return 0, fmt.Errorf("marshal echo request: %w", err)
+	}
+
+	var dst net.Addr = &net.UDPAddr{IP: addr.AsSlice()}
+	if privileged {
+		dst = &net.IPAddr{IP: addr.AsSlice()}
+	}
+
+	start := time.Now()
+	if _, err := conn.WriteTo(payload, dst); err != nil {
+		return 0, fmt.Errorf("send echo request: %w", err)
+	}
+
+	buf := make([]byte, 1500)
+	for {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		n, peer, err := conn.ReadFrom(buf)
+		if err != nil {
+			return 0, fmt.Errorf("read echo reply: %w", err)
+		}
+
+		if !sameHost(peer, addr) {
+			continue
+		}
+
+		reply, err := icmp.ParseMessage(protocolICMP, buf[:n])
+		if err != nil || reply.Type != ipv4.ICMPTypeEchoReply {
+			continue
+		}
+
+		echo, ok := reply.Body.(*icmp.Echo)
+		// unprivileged sockets get the identifier rewritten by the kernel, so only the sequence is compared
+		if !ok || echo.Seq != seq || (privileged && echo.ID != id) {
+			continue
+		}
+
+		return time.Since(start), nil
+	}
+}
+
+func listen() (*icmp.PacketConn, bool, error) {
+	conn, err := icmp.ListenPacket("ip4:icmp", "0.0.0.0")
+	if err == nil {
+		return conn, true, nil
+	}
+
+	conn, udpErr := icmp.ListenPacket("udp4", "0.0.0.0")
+	if udpErr != nil {
+		return nil, false, fmt.Errorf("listen for icmp: %w", err)
+	}
+	return conn, false, nil
+}
+
+func sameHost(peer net.Addr, addr netip.Addr) bool {
+	var ip net.IP
+	switch a := peer.(type) {
+	case *net.IPAddr:
+		ip = a.IP
+	case *net.UDPAddr:
+		ip = a.IP
+	default:
+		return false
+	}
+
+	peerAddr, ok := netip.AddrFromSlice(ip)
+	return ok && peerAddr.Unmap() == addr
+}
@@ -0,0 +1,208 @@
+package relay
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/pion/stun/v2"
+)
+
+// NATType is the NAT behaviour classified by DetectNATType
+type NATType string
+
+const (
+	NATTypeUnknown        NATType = "unknown"
+	NATTypeFullCone       NATType = "full-cone"
+	NATTypeRestrictedCone NATType = "restricted-cone"
+	NATTypePortRestricted NATType = "port-restricted"
+	NATTypeSymmetric      NATType = "symmetric"
+	// NATTypeCone is a NAT keeping the same mapping for every destination whose filtering couldn't be tested,
+	// because the STUN server doesn't support RFC 5780
+	NATTypeCone NATType = "cone"
+)
+
+// CHANGE-REQUEST flags, RFC 5780 Section 7.2
+const (
+	changeIPFlag   = 0x04
+	changePortFlag = 0x02
+)
+
+// NATTypeResult holds the binding requests sent by DetectNATType and the resulting classification
+type NATTypeResult struct {
+	URI          *stun.URI
+	MappedAddr   string
+	ResponseTime time.Duration
+	// OtherAddr is the alternate address advertised by the server, empty when the server doesn't support RFC 5780
+	OtherAddr string
+	// SecondMappedAddr is the mapped address seen by the alternate address or by the second server
+	SecondMappedAddr string
+	// Hairpin reports whether a packet sent from another local port to the mapped address came back through the NAT
+	Hairpin bool
+	NATType NATType
+}
+
+// changeRequest is the CHANGE-REQUEST attribute asking the server to answer from its alternate IP and/or port
+type changeRequest uint32
+
+// AddTo adds the CHANGE-REQUEST attribute to the message
+func (c changeRequest) AddTo(m *stun.Message) error {
+	v := make([]byte, 4)
+	binary.BigEndian.PutUint32(v, uint32(c))
+	m.Add(stun.AttrChangeRequest, v)
+	return nil
+}
+
+// DetectNATType classifies the NAT in front of this host following RFC 3489 with the RFC 5780 attributes.
+// The mapping is compared between the first server and its alternate address, or the second server when the
+// first one doesn't advertise an alternate address. The filtering is tested only with RFC 5780 servers
+func DetectNATType(ctx context.Context, uris []*stun.URI, timeout time.Duration) (*NATTypeResult, error) {
+	if len(uris) == 0 {
+		return nil, errors.New("no STUN server")
+	}
+
+	conn, err := net.ListenPacket("udp4", ":0")
+	if err != nil {
+		return nil, fmt.Errorf("listen: %w", err)
+	}
+	defer conn.Close()
+
+	serverAddr, err := resolveSTUN(ctx, uris[0])
+	if err != nil {
+		return nil, err
+	}
+
+	response, responseTime, err := sendBindingRequest(ctx, conn, serverAddr, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("binding request to %s: %w", uris[0], err)
+	}
+	mapped, err := mappedAddress(response)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &NATTypeResult{
+		URI:          uris[0],
+		MappedAddr:   mapped.String(),
+		ResponseTime: responseTime,
+		NATType:      NATTypeUnknown,
+	}
+
+	otherAddr := otherAddress(response)
+	var secondAddr net.Addr
+	switch {
+	case otherAddr != nil:
+		result.OtherAddr = otherAddr.String()
+		secondAddr = otherAddr
+	case len(uris) > 1:
+		if addr, err := resolveSTUN(ctx, uris[1]); err == nil {
+			secondAddr = addr
+		}
+	}
+
+	if secondAddr != nil {
+		if response, _, err := sendBindingRequest(ctx, conn, secondAddr, timeout); err == nil {
+			if secondMapped, err := mappedAddress(response); err == nil {
+				result.SecondMappedAddr = secondMapped.String()
+			}
+		}
+	}
+
+	result.Hairpin = testHairpin(conn, mapped, timeout)
+
+	switch {
+	case result.SecondMappedAddr == "":
+		return result, nil
+	case result.SecondMappedAddr != result.MappedAddr:
+		result.NATType = NATTypeSymmetric
+		return result, nil
+	case otherAddr == nil:
+		result.NATType = NATTypeCone
+		return result, nil
+	}
+
+	result.NATType = testFiltering(ctx, serverAddr, timeout)
+	return result, nil
+}
+
+// testFiltering sends the change requests from a fresh socket which never sent anything to the alternate address,
+// otherwise the mapping test would have opened the NAT for the address the answers come from
+func testFiltering(ctx context.Context, serverAddr net.Addr, timeout time.Duration) NATType {
+	conn, err := net.ListenPacket("udp4", ":0")
+	if err != nil {
+		return NATTypeUnknown
+	}
+	defer conn.Close()
+
+	if _, _, err := sendBindingRequest(ctx, conn, serverAddr, timeout); err != nil {
+		return NATTypeUnknown
+	}
+
+	if _, _, err := sendBindingRequest(ctx, conn, serverAddr, timeout, changeRequest(changeIPFlag|changePortFlag)); err == nil {
+		return NATTypeFullCone
+	}
+	if _, _, err := sendBindingRequest(ctx, conn, serverAddr, timeout, changeRequest(changePortFlag)); err == nil {
+		return NATTypeRestrictedCone
+	}
+	return NATTypePortRestricted
+}
+
+// testHairpin sends a binding request from a second local socket to the mapped address of conn
+// and reports whether conn receives it
+func testHairpin(conn net.PacketConn, mapped *net.UDPAddr, timeout time.Duration) bool {
+	other, err := net.ListenPacket("udp4", ":0")
+	if err != nil {
+		return false
+	}
+	defer other.Close()
+
+	probe := stun.MustBuild(stun.TransactionID, stun.BindingRequest)
+	if err := conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return false
+	}
+	if _, err := other.WriteTo(probe.Raw, mapped); err != nil {
+		return false
+	}
+
+	buf := make([]byte, 1500)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return false
+		}
+
+		received := &stun.Message{Raw: append([]byte{}, buf[:n]...)}
+		if err := received.Decode(); err == nil && received.TransactionID == probe.TransactionID {
+			return true
+		}
+	}
+}
+
+// mappedAddress returns the XOR-MAPPED-ADDRESS of the response, or the MAPPED-ADDRESS of RFC 3489 servers
+func mappedAddress(response *stun.Message) (*net.UDPAddr, error) {
+	var xorAddr stun.XORMappedAddress
+	if err := xorAddr.GetFrom(response); err == nil {
+		return &net.UDPAddr{IP: xorAddr.IP, Port: xorAddr.Port}, nil
+	}
+
+	var addr stun.MappedAddress
+	if err := addr.GetFrom(response); err != nil {
+		return nil, fmt.Errorf("get mapped addr: %w", err)
+	}
+	return &net.UDPAddr{IP: addr.IP, Port: addr.Port}, nil
+}
+
+// otherAddress returns the OTHER-ADDRESS of the response, or the CHANGED-ADDRESS of RFC 3489 servers
+func otherAddress(response *stun.Message) *net.UDPAddr {
+	var addr stun.MappedAddress
+	if err := addr.GetFromAs(response, stun.AttrOtherAddress); err == nil {
+		return &net.UDPAddr{IP: addr.IP, Port: addr.Port}
+	}
+	if err := addr.GetFromAs(response, stun.AttrChangedAddress); err == nil {
+		return &net.UDPAddr{IP: addr.IP, Port: addr.Port}
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package relay
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pion/stun/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// natFiltering is the filtering of the simulated NAT, RFC 4787 Section 5
+type natFiltering int
+
+const (
+	endpointIndependentFiltering natFiltering = iota
+	addressDependentFiltering
+	addressAndPortDependentFiltering
+)
+
+// natBehaviour makes the RFC 5780 test server behave as if the client was behind the given NAT
+type natBehaviour struct {
+	filtering natFiltering
+	// symmetric reports another mapped port to the alternate address
+	symmetric bool
+}
+
+// serverEndpoint is a logical address of the test server. Loopback has no second IP, so the primary and the
+// alternate sockets stand for different IPs and ports, and answers to change requests are sent from the alternate one
+type serverEndpoint struct {
+	alternateIP   bool
+	alternatePort bool
+}
+
+// simulatedNAT remembers the server endpoints every client socket sent to and drops the answers its filtering
+// wouldn't let through
+type simulatedNAT struct {
+	filtering natFiltering
+	mu        sync.Mutex
+	sentTo    map[string]map[serverEndpoint]bool
+}
+
+func (n *simulatedNAT) outbound(client net.Addr, endpoint serverEndpoint) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.sentTo[client.String()] == nil {
+		n.sentTo[client.String()] = map[serverEndpoint]bool{}
+	}
+	n.sentTo[client.String()][endpoint] = true
+}
+
+func (n *simulatedNAT) allowed(client net.Addr, endpoint serverEndpoint) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	switch n.filtering {
+	case addressDependentFiltering:
+		for sent := range n.sentTo[client.String()] {
+			if sent.alternateIP == endpoint.alternateIP {
+				return true
+			}
+		}
+		return false
+	case addressAndPortDependentFiltering:
+		return n.sentTo[client.String()][endpoint]
+	default:
+		return true
+	}
+}
+
+// startRFC5780Server answers binding requests on a primary and an alternate port, advertising the alternate port
+// as OTHER-ADDRESS
+func startRFC5780Server(t *testing.T, behaviour natBehaviour) *stun.URI {
+	t.Helper()
+
+	primary, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = primary.Close() })
+
+	alternate, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = alternate.Close() })
+
+	alternateAddr := alternate.LocalAddr().(*net.UDPAddr)
+	nat := &simulatedNAT{filtering: behaviour.filtering, sentTo: map[string]map[serverEndpoint]bool{}}
+
+	serve := func(conn net.PacketConn, isAlternate bool) {
+		buf := make([]byte, 1500)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			request := &stun.Message{Raw: append([]byte{}, buf[:n]...)}
+			if err := request.Decode(); err != nil {
+				continue
+			}
+
+			received := serverEndpoint{alternateIP: isAlternate, alternatePort: isAlternate}
+			nat.outbound(addr, received)
+
+			var changes uint32
+			if v, err := request.Get(stun.AttrChangeRequest); err == nil && len(v) == 4 {
+				changes = binary.BigEndian.Uint32(v)
+			}
+
+			answering := received
+			if changes&changeIPFlag != 0 {
+				answering.alternateIP = !answering.alternateIP
+			}
+			if changes&changePortFlag != 0 {
+				answering.alternatePort = !answering.alternatePort
+			}
+			if !nat.allowed(addr, answering) {
+				continue
+			}
+
+			udpAddr := addr.(*net.UDPAddr)
+			port := udpAddr.Port
+			if isAlternate && behaviour.symmetric {
+				port++
+			}
+
+			response := stun.MustBuild(
+				stun.NewTransactionIDSetter(request.TransactionID),
+				stun.BindingSuccess,
+				&stun.XORMappedAddress{IP: udpAddr.IP, Port: port},
+				&stun.OtherAddress{IP: alternateAddr.IP, Port: alternateAddr.Port},
+			)
+
+			sender := conn
+			if changes != 0 {
+				sender = alternate
+			}
+			_, _ = sender.WriteTo(response.Raw, addr)
+		}
+	}
+	go serve(primary, false)
+	go serve(alternate, true)
+
+	uri, err := stun.ParseURI(fmt.Sprintf("stun:127.0.0.1:%d", primary.LocalAddr().(*net.UDPAddr).Port))
+	require.NoError(t, err)
+	return uri
+}
+
+func TestDetectNATType(t *testing.T) {
+	tests := []struct {
+		name      string
+		behaviour natBehaviour
+		expected  NATType
+	}{
+		{
+			name:     "full cone",
+			expected: NATTypeFullCone,
+		},
+		{
+			name:      "restricted cone",
+			behaviour: natBehaviour{filtering: addressDependentFiltering},
+			expected:  NATTypeRestrictedCone,
+		},
+		{
+			name:      "port restricted",
+			behaviour: natBehaviour{filtering: addressAndPortDependentFiltering},
+			expected:  NATTypePortRestricted,
+		},
+		{
+			name:      "symmetric",
+			behaviour: natBehaviour{symmetric: true},
+			expected:  NATTypeSymmetric,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			uri := startRFC5780Server(t, tc.behaviour)
+
+			result, err := DetectNATType(context.Background(), []*stun.URI{uri}, 200*time.Millisecond)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, result.NATType)
+			assert.NotEmpty(t, result.MappedAddr)
+			assert.NotEmpty(t, result.OtherAddr)
+			assert.True(t, result.Hairpin, "loopback delivers packets sent to the mapped address")
+		})
+	}
+}
+
+func TestDetectNATTypeWithoutRFC5780(t *testing.T) {
+	uri := startSTUNServer(t)
+
+	result, err := DetectNATType(context.Background(), []*stun.URI{uri}, 200*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, NATTypeUnknown, result.NATType, "a single server without an alternate address can't tell the mapping")
+	assert.Empty(t, result.OtherAddr)
+
+	result, err = DetectNATType(context.Background(), []*stun.URI{uri, startSTUNServer(t)}, 200*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, NATTypeCone, result.NATType)
+	assert.Equal(t, result.MappedAddr, result.SecondMappedAddr)
+
+	unreachable, err := stun.ParseURI("stun:127.0.0.1:1")
+	require.NoError(t, err)
+	_, err = DetectNATType(context.Background(), []*stun.URI{unreachable}, 200*time.Millisecond)
+	assert.Error(t, err)
+}
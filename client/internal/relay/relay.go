@@ -81,25 +81,9 @@ func ProbeTURN(ctx context.Context, uri *stun.URI) (addr string, probeErr error)
 
 	turnServerAddr := fmt.Sprintf("%s:%d", uri.Host, uri.Port)
 
-	var conn net.PacketConn
-	switch uri.Proto {
-	case stun.ProtoTypeUDP:
-		var err error
-		conn, err = net.ListenPacket("udp", "")
-		if err != nil {
-			probeErr = fmt.Errorf("listen: %w", err)
-			return
-		}
-	case stun.ProtoTypeTCP:
-		dialer := net.Dialer{}
-		tcpConn, err := dialer.DialContext(ctx, "tcp", turnServerAddr)
-		if err != nil {
-			probeErr = fmt.Errorf("dial: %w", err)
-			return
-		}
-		conn = turn.NewSTUNConn(tcpConn)
-	default:
-		probeErr = fmt.Errorf("conn: unknown proto: %s", uri.Proto)
+	conn, err := newTURNConn(ctx, uri)
+	if err != nil {
+		probeErr = err
 		return
 	}
 
@@ -144,6 +128,27 @@ func ProbeTURN(ctx context.Context, uri *stun.URI) (addr string, probeErr error)
 	return relayConn.LocalAddr().String(), nil
 }
 
+// newTURNConn opens the connection to the TURN server of the URI over its transport
+func newTURNConn(ctx context.Context, uri *stun.URI) (net.PacketConn, error) {
+	switch uri.Proto {
+	case stun.ProtoTypeUDP:
+		conn, err := net.ListenPacket("udp", "")
+		if err != nil {
+			return nil, fmt.Errorf("listen: %w", err)
+		}
+		return conn, nil
+	case stun.ProtoTypeTCP:
+		dialer := net.Dialer{}
+		tcpConn, err := dialer.DialContext(ctx, "tcp", fmt.Sprintf("%s:%d", uri.Host, uri.Port))
+		if err != nil {
+			return nil, fmt.Errorf("dial: %w", err)
+		}
+		return turn.NewSTUNConn(tcpConn), nil
+	default:
+		return nil, fmt.Errorf("conn: unknown proto: %s", uri.Proto)
+	}
+}
+
 // ProbeAll probes all given servers asynchronously and returns the results
 func ProbeAll(
 	ctx context.Context,
@@ -0,0 +1,132 @@
+package relay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/pion/stun/v2"
+)
+
+// STUNCheckResult holds the result of a STUN binding request sent by CheckSTUN
+type STUNCheckResult struct {
+	URI          *stun.URI
+	ResponseTime time.Duration
+	MappedAddr   string
+	Err          error
+}
+
+// CheckSTUN sends a binding request to every given STUN server from the same local UDP socket,
+// so the mapped addresses can be compared to detect a symmetric NAT
+func CheckSTUN(ctx context.Context, uris []*stun.URI, timeout time.Duration) ([]STUNCheckResult, error) {
+	conn, err := net.ListenPacket("udp4", ":0")
+	if err != nil {
+		return nil, fmt.Errorf("listen: %w", err)
+	}
+	defer conn.Close()
+
+	results := make([]STUNCheckResult, 0, len(uris))
+	for _, uri := range uris {
+		result := STUNCheckResult{URI: uri}
+		result.MappedAddr, result.ResponseTime, result.Err = bindingRequest(ctx, conn, uri, timeout)
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// IsSymmetricNAT reports whether the STUN servers saw different mapped addresses for the same local socket.
+// The second value is false when less than two servers answered and the NAT type can't be determined
+func IsSymmetricNAT(results []STUNCheckResult) (bool, bool) {
+	var mapped []string
+	for _, result := range results {
+		if result.Err == nil {
+			mapped = append(mapped, result.MappedAddr)
+		}
+	}
+
+	if len(mapped) < 2 {
+		return false, false
+	}
+
+	for _, addr := range mapped[1:] {
+		if addr != mapped[0] {
+			return true, true
+		}
+	}
+	return false, true
+}
+
+func bindingRequest(ctx context.Context, conn net.PacketConn, uri *stun.URI, timeout time.Duration) (string, time.Duration, error) {
+	serverAddr, err := resolveSTUN(ctx, uri)
+	if err != nil {
+		return "", 0, err
+	}
+
+	response, responseTime, err := sendBindingRequest(ctx, conn, serverAddr, timeout)
+	if err != nil {
+		return "", 0, err
+	}
+
+	var xorAddr stun.XORMappedAddress
+	if err := xorAddr.GetFrom(response); err != nil {
+		return "", 0, fmt.Errorf("get xor addr: %w", err)
+	}
+	return xorAddr.String(), responseTime, nil
+}
+
+func resolveSTUN(ctx context.Context, uri *stun.URI) (*net.UDPAddr, error) {
+	if uri.Scheme != stun.SchemeTypeSTUN {
+		return nil, fmt.Errorf("unsupported scheme: %s", uri.Scheme)
+	}
+
+	var resolver net.Resolver
+	ips, err := resolver.LookupIP(ctx, "ip4", uri.Host)
+	if err != nil {
+		return nil, fmt.Errorf("resolve: %w", err)
+	}
+	return &net.UDPAddr{IP: ips[0], Port: uri.Port}, nil
+}
+
+// sendBindingRequest sends a binding request with the given extra attributes and waits for the matching response,
+// which may come from another address than the server address when the server is asked to change it
+func sendBindingRequest(ctx context.Context, conn net.PacketConn, serverAddr net.Addr, timeout time.Duration, setters ...stun.Setter) (*stun.Message, time.Duration, error) {
+	request, err := stun.Build(append([]stun.Setter{stun.TransactionID, stun.BindingRequest}, setters...)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("build request: %w", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return nil, 0, fmt.Errorf("set deadline: %w", err)
+	}
+
+	start := time.Now()
+	if _, err := conn.WriteTo(request.Raw, serverAddr); err != nil {
+		return nil, 0, fmt.Errorf("write: %w", err)
+	}
+
+	buf := make([]byte, 1500)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				return nil, 0, fmt.Errorf("no response within %s", timeout)
+			}
+			return nil, 0, fmt.Errorf("read: %w", err)
+		}
+		responseTime := time.Since(start)
+
+		response := &stun.Message{Raw: append([]byte{}, buf[:n]...)}
+		if err := response.Decode(); err != nil || response.TransactionID != request.TransactionID {
+			// late answer to a previous request or unrelated packet
+			continue
+		}
+		return response, responseTime, nil
+	}
+}
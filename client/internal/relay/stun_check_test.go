@@ -0,0 +1,77 @@
+package relay
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pion/stun/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startSTUNServer answers binding requests with the source address of the request
+func startSTUNServer(t *testing.T) *stun.URI {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			request := &stun.Message{Raw: append([]byte{}, buf[:n]...)}
+			if err := request.Decode(); err != nil {
+				continue
+			}
+
+			udpAddr := addr.(*net.UDPAddr)
+			response := stun.MustBuild(
+				stun.NewTransactionIDSetter(request.TransactionID),
+				stun.BindingSuccess,
+				&stun.XORMappedAddress{IP: udpAddr.IP, Port: udpAddr.Port},
+			)
+			_, _ = conn.WriteTo(response.Raw, addr)
+		}
+	}()
+
+	uri, err := stun.ParseURI(fmt.Sprintf("stun:127.0.0.1:%d", conn.LocalAddr().(*net.UDPAddr).Port))
+	require.NoError(t, err)
+	return uri
+}
+
+func TestCheckSTUN(t *testing.T) {
+	unreachable, err := stun.ParseURI("stun:127.0.0.1:1")
+	require.NoError(t, err)
+
+	uris := []*stun.URI{startSTUNServer(t), startSTUNServer(t), unreachable}
+
+	results, err := CheckSTUN(context.Background(), uris, 200*time.Millisecond)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	assert.NoError(t, results[0].Err)
+	assert.NoError(t, results[1].Err)
+	assert.Error(t, results[2].Err)
+	assert.Equal(t, results[0].MappedAddr, results[1].MappedAddr, "both servers should see the same local socket")
+
+	symmetric, ok := IsSymmetricNAT(results)
+	assert.True(t, ok)
+	assert.False(t, symmetric)
+
+	_, ok = IsSymmetricNAT(results[1:])
+	assert.False(t, ok, "a single answer can't tell the NAT type")
+
+	results[1].MappedAddr = "1.2.3.4:5678"
+	symmetric, ok = IsSymmetricNAT(results)
+	assert.True(t, ok)
+	assert.True(t, symmetric)
+}
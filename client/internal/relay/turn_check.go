@@ -0,0 +1,253 @@
+package relay
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/pion/stun/v2"
+	"github.com/pion/turn/v3"
+	log "github.com/sirupsen/logrus"
+)
+
+// TURN check stages, reported as the failed stage of a TURNCheckResult
+const (
+	TURNStageAllocation     = "allocation"
+	TURNStagePeerAllocation = "peer allocation"
+	TURNStagePermission     = "permission"
+	TURNStageChannelBinding = "channel binding"
+	TURNStageData           = "data"
+)
+
+const (
+	turnPingCount  = 5
+	turnPingPrefix = "ping"
+	turnChunkSize  = 1200
+)
+
+// TURNCheckResult holds the result of every stage of CheckTURN. FailedStage and Err are set by the first failing stage
+type TURNCheckResult struct {
+	URI          *stun.URI
+	RelayedAddr  string
+	PeerAddr     string
+	ChannelBound bool
+	RTT          time.Duration
+	BytesSent    int64
+	// BytesReceived are the bytes of the throughput test that reached the peer allocation
+	BytesReceived int64
+	Duration      time.Duration
+	FailedStage   string
+	Err           error
+}
+
+// Throughput returns the bytes per second that reached the peer allocation during the throughput test
+func (r *TURNCheckResult) Throughput() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.BytesReceived) / r.Duration.Seconds()
+}
+
+func (r *TURNCheckResult) fail(stage string, err error) *TURNCheckResult {
+	r.FailedStage = stage
+	r.Err = err
+	return r
+}
+
+// channelBindObserver watches the packets the TURN client receives for the answer to its channel bind request
+type channelBindObserver struct {
+	net.PacketConn
+	bound  atomic.Bool
+	failed atomic.Bool
+}
+
+func (o *channelBindObserver) ReadFrom(p []byte) (int, net.Addr, error) {
+	n, addr, err := o.PacketConn.ReadFrom(p)
+	if err == nil && stun.IsMessage(p[:n]) {
+		msg := &stun.Message{Raw: append([]byte{}, p[:n]...)}
+		if msg.Decode() == nil && msg.Type.Method == stun.MethodChannelBind {
+			switch msg.Type.Class {
+			case stun.ClassSuccessResponse:
+				o.bound.Store(true)
+			case stun.ClassErrorResponse:
+				o.failed.Store(true)
+			}
+		}
+	}
+	return n, addr, err
+}
+
+// CheckTURN allocates a relayed address and a second one acting as the test peer on the same server, waits for the
+// channel binding between them, measures the round-trip time of echoed pings and the throughput to the peer for the
+// given duration
+func CheckTURN(ctx context.Context, uri *stun.URI, duration, timeout time.Duration) *TURNCheckResult {
+	result := &TURNCheckResult{URI: uri}
+
+	conn, err := newTURNConn(ctx, uri)
+	if err != nil {
+		return result.fail(TURNStageAllocation, err)
+	}
+	observer := &channelBindObserver{PacketConn: conn}
+	defer closeLogged(observer, "turn check conn")
+
+	client, relayConn, err := allocate(uri, observer)
+	if err != nil {
+		return result.fail(TURNStageAllocation, err)
+	}
+	defer client.Close()
+	defer closeLogged(relayConn, "turn check relay conn")
+	result.RelayedAddr = relayConn.LocalAddr().String()
+
+	peerConn, err := newTURNConn(ctx, uri)
+	if err != nil {
+		return result.fail(TURNStagePeerAllocation, err)
+	}
+	defer closeLogged(peerConn, "turn check peer conn")
+
+	peerClient, peerRelayConn, err := allocate(uri, peerConn)
+	if err != nil {
+		return result.fail(TURNStagePeerAllocation, err)
+	}
+	defer peerClient.Close()
+	defer closeLogged(peerRelayConn, "turn check peer relay conn")
+	result.PeerAddr = peerRelayConn.LocalAddr().String()
+
+	if err := peerClient.CreatePermission(relayConn.LocalAddr()); err != nil {
+		return result.fail(TURNStagePermission, fmt.Errorf("create permission: %w", err))
+	}
+
+	var received atomic.Int64
+	go echo(peerRelayConn, &received)
+
+	// pion binds the channel in the background after the first packet to the peer, until then data goes as indications
+	bindDeadline := time.Now().Add(timeout)
+	for !observer.bound.Load() {
+		if observer.failed.Load() {
+			return result.fail(TURNStageChannelBinding, errors.New("the server rejected the channel bind request"))
+		}
+		if time.Now().After(bindDeadline) {
+			return result.fail(TURNStageChannelBinding, fmt.Errorf("no channel bind response within %s", timeout))
+		}
+		if _, err := relayConn.WriteTo([]byte(turnPingPrefix), peerRelayConn.LocalAddr()); err != nil {
+			return result.fail(TURNStagePermission, err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	result.ChannelBound = true
+
+	result.RTT, err = pingPeer(relayConn, peerRelayConn.LocalAddr(), timeout)
+	if err != nil {
+		return result.fail(TURNStageData, err)
+	}
+
+	result.BytesSent, result.Duration = sendChunks(relayConn, peerRelayConn.LocalAddr(), duration)
+	// give the last chunks the time of a round trip to arrive
+	time.Sleep(result.RTT)
+	result.BytesReceived = received.Load()
+
+	return result
+}
+
+// allocate creates a TURN client over the connection and allocates a relayed address
+func allocate(uri *stun.URI, conn net.PacketConn) (*turn.Client, net.PacketConn, error) {
+	turnServerAddr := fmt.Sprintf("%s:%d", uri.Host, uri.Port)
+	client, err := turn.NewClient(&turn.ClientConfig{
+		STUNServerAddr: turnServerAddr,
+		TURNServerAddr: turnServerAddr,
+		Conn:           conn,
+		Username:       uri.Username,
+		Password:       uri.Password,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("create client: %w", err)
+	}
+
+	if err := client.Listen(); err != nil {
+		client.Close()
+		return nil, nil, fmt.Errorf("client listen: %w", err)
+	}
+
+	relayConn, err := client.Allocate()
+	if err != nil {
+		client.Close()
+		return nil, nil, fmt.Errorf("allocate: %w", err)
+	}
+
+	return client, relayConn, nil
+}
+
+// echo answers the pings and counts the bytes of every other packet received on the relayed connection
+func echo(conn net.PacketConn, received *atomic.Int64) {
+	buf := make([]byte, 1500)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		if bytes.HasPrefix(buf[:n], []byte(turnPingPrefix)) {
+			_, _ = conn.WriteTo(buf[:n], addr)
+			continue
+		}
+		received.Add(int64(n))
+	}
+}
+
+// pingPeer sends numbered pings one after the other and returns the average round-trip time of the answered ones
+func pingPeer(conn net.PacketConn, peer net.Addr, timeout time.Duration) (time.Duration, error) {
+	var total time.Duration
+	var answered int
+	buf := make([]byte, 1500)
+	for i := 0; i < turnPingCount; i++ {
+		ping := []byte(fmt.Sprintf("%s %d", turnPingPrefix, i))
+		start := time.Now()
+		if _, err := conn.WriteTo(ping, peer); err != nil {
+			return 0, fmt.Errorf("write: %w", err)
+		}
+
+		if err := conn.SetReadDeadline(start.Add(timeout)); err != nil {
+			return 0, fmt.Errorf("set deadline: %w", err)
+		}
+		for {
+			n, _, err := conn.ReadFrom(buf)
+			if err != nil {
+				break
+			}
+			// skip the answers to the pings sent while waiting for the channel binding
+			if bytes.Equal(buf[:n], ping) {
+				total += time.Since(start)
+				answered++
+				break
+			}
+		}
+	}
+
+	if answered == 0 {
+		return 0, fmt.Errorf("none of the %d pings relayed to the peer was answered within %s", turnPingCount, timeout)
+	}
+	return total / time.Duration(answered), nil
+}
+
+// sendChunks sends data to the peer as fast as possible for the given duration
+func sendChunks(conn net.PacketConn, peer net.Addr, duration time.Duration) (int64, time.Duration) {
+	chunk := bytes.Repeat([]byte{'d'}, turnChunkSize)
+	var sent int64
+	start := time.Now()
+	for time.Since(start) < duration {
+		n, err := conn.WriteTo(chunk, peer)
+		if err != nil {
+			break
+		}
+		sent += int64(n)
+	}
+	return sent, time.Since(start)
+}
+
+func closeLogged(conn net.PacketConn, name string) {
+	if err := conn.Close(); err != nil {
+		log.Debugf("failed closing %s: %v", name, err)
+	}
+}
@@ -0,0 +1,82 @@
+package relay
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pion/stun/v2"
+	"github.com/pion/turn/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTURNRealm = "netbird.io"
+
+// startTURNServer runs a TURN server on loopback accepting the user "user" with the password "pass"
+func startTURNServer(t *testing.T) (string, int) {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server, err := turn.NewServer(turn.ServerConfig{
+		Realm: testTURNRealm,
+		AuthHandler: func(username, realm string, _ net.Addr) ([]byte, bool) {
+			if username != "user" {
+				return nil, false
+			}
+			return turn.GenerateAuthKey(username, realm, "pass"), true
+		},
+		PacketConnConfigs: []turn.PacketConnConfig{
+			{
+				PacketConn: conn,
+				RelayAddressGenerator: &turn.RelayAddressGeneratorStatic{
+					RelayAddress: net.ParseIP("127.0.0.1"),
+					Address:      "127.0.0.1",
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = server.Close() })
+
+	return "127.0.0.1", conn.LocalAddr().(*net.UDPAddr).Port
+}
+
+func TestCheckTURN(t *testing.T) {
+	host, port := startTURNServer(t)
+
+	uri, err := stun.ParseURI(fmt.Sprintf("turn:%s:%d?transport=udp", host, port))
+	require.NoError(t, err)
+	uri.Username = "user"
+	uri.Password = "pass"
+
+	result := CheckTURN(context.Background(), uri, 100*time.Millisecond, 2*time.Second)
+	require.NoError(t, result.Err)
+
+	assert.Empty(t, result.FailedStage)
+	assert.NotEmpty(t, result.RelayedAddr)
+	assert.NotEmpty(t, result.PeerAddr)
+	assert.True(t, result.ChannelBound)
+	assert.Greater(t, result.RTT, time.Duration(0))
+	assert.Greater(t, result.BytesSent, int64(0))
+	assert.Greater(t, result.BytesReceived, int64(0))
+	assert.Greater(t, result.Throughput(), float64(0))
+}
+
+func TestCheckTURNWrongCredentials(t *testing.T) {
+	host, port := startTURNServer(t)
+
+	uri, err := stun.ParseURI(fmt.Sprintf("turn:%s:%d?transport=udp", host, port))
+	require.NoError(t, err)
+	uri.Username = "user"
+	uri.Password = "wrong"
+
+	result := CheckTURN(context.Background(), uri, 100*time.Millisecond, 2*time.Second)
+	assert.Error(t, result.Err)
+	assert.Equal(t, TURNStageAllocation, result.FailedStage)
+	assert.Empty(t, result.RelayedAddr)
+}
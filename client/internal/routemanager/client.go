@@ -165,6 +165,7 @@ func (c *clientNetwork) removeRouteFromWireguardPeer(peerKey string) error {
 	if err := c.statusRecorder.UpdatePeerState(state); err != nil {
 		log.Warnf("Failed to update peer state: %v", err)
 	}
+	c.statusRecorder.DeleteRouteMetric(c.network.String())
 
 	if state.ConnStatus != peer.StatusConnected {
 		return nil
@@ -243,6 +244,7 @@ func (c *clientNetwork) recalculateRouteAndUpdatePeerAndSystem() error {
 		if err := c.statusRecorder.UpdatePeerState(state); err != nil {
 			log.Warnf("Failed to update peer state: %v", err)
 		}
+		c.statusRecorder.UpdateRouteMetric(c.network.String(), c.chosenRoute.Metric)
 	}
 
 	err = c.wgInterface.AddAllowedIP(c.chosenRoute.Peer, c.network.String())
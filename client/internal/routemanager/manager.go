@@ -86,6 +86,8 @@ func (m *DefaultManager) UpdateRoutes(updateSerial uint64, newRoutes []*route.Ro
 
 		newServerRoutesMap, newClientRoutesIDMap := m.classifiesRoutes(newRoutes)
 
+		m.updateAdvertisedRoutes(newRoutes)
+
 		m.updateClientNetworks(updateSerial, newClientRoutesIDMap)
 		m.notifier.onNewRoutes(newClientRoutesIDMap)
 
@@ -100,6 +102,19 @@ func (m *DefaultManager) UpdateRoutes(updateSerial uint64, newRoutes []*route.Ro
 	}
 }
 
+// updateAdvertisedRoutes records the networks advertised by each routing peer in the status recorder
+func (m *DefaultManager) updateAdvertisedRoutes(newRoutes []*route.Route) {
+	if m.statusRecorder == nil {
+		return
+	}
+
+	advertisedRoutes := make(map[string][]string)
+	for _, newRoute := range newRoutes {
+		advertisedRoutes[newRoute.Peer] = append(advertisedRoutes[newRoute.Peer], newRoute.Network.String())
+	}
+	m.statusRecorder.UpdateAdvertisedRoutes(advertisedRoutes)
+}
+
 // SetRouteChangeListener set RouteListener for route change notifier
 func (m *DefaultManager) SetRouteChangeListener(listener listener.NetworkChangeListener) {
 	m.notifier.setListener(listener)
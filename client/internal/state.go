@@ -34,6 +34,7 @@ func CtxGetState(ctx context.Context) *contextState {
 type contextState struct {
 	err    error
 	status StatusType
+	engine *Engine
 	mutex  sync.Mutex
 }
 
@@ -64,6 +65,22 @@ func (c *contextState) Wrap(err error) error {
 	return err
 }
 
+// SetEngine records the running engine, nil once it stopped
+func (c *contextState) SetEngine(engine *Engine) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.engine = engine
+}
+
+// Engine returns the running engine, nil when the client isn't connected
+func (c *contextState) Engine() *Engine {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.engine
+}
+
 type stateKey int
 
 var stateCtx stateKey
@@ -83,6 +83,7 @@ func (c *Client) Run(fd int32, interfaceName string) error {
 	}
 	c.recorder.UpdateManagementAddress(cfg.ManagementURL.String())
 	c.recorder.UpdateRosenpass(cfg.RosenpassEnabled, cfg.RosenpassPermissive)
+	c.recorder.UpdateCustomDNSTTL(cfg.CustomDNSTTL)
 
 	var ctx context.Context
 	//nolint
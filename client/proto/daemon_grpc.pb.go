@@ -31,6 +31,28 @@ type DaemonServiceClient interface {
 	Down(ctx context.Context, in *DownRequest, opts ...grpc.CallOption) (*DownResponse, error)
 	// GetConfig of the daemon.
 	GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*GetConfigResponse, error)
+	// RouteTest evaluates the route table of the daemon for a destination
+	RouteTest(ctx context.Context, in *RouteTestRequest, opts ...grpc.CallOption) (*RouteTestResponse, error)
+	// GetDaemonUptime returns the time the daemon was started at
+	GetDaemonUptime(ctx context.Context, in *GetDaemonUptimeRequest, opts ...grpc.CallOption) (*GetDaemonUptimeResponse, error)
+	// GetPeerEvents returns the connection status changes of a peer recorded by the daemon
+	GetPeerEvents(ctx context.Context, in *GetPeerEventsRequest, opts ...grpc.CallOption) (*GetPeerEventsResponse, error)
+	// GetGroupMembers returns the FQDNs of the peers belonging to any of the given management groups
+	GetGroupMembers(ctx context.Context, in *GetGroupMembersRequest, opts ...grpc.CallOption) (*GetGroupMembersResponse, error)
+	// GetProcessStats returns the memory usage of the daemon process, only to root or the daemon user
+	GetProcessStats(ctx context.Context, in *GetProcessStatsRequest, opts ...grpc.CallOption) (*GetProcessStatsResponse, error)
+	// GetProcessInfo returns the process ID of the daemon
+	GetProcessInfo(ctx context.Context, in *GetProcessInfoRequest, opts ...grpc.CallOption) (*GetProcessInfoResponse, error)
+	// VerifyInterface checks the WireGuard interface against the state the daemon expects
+	VerifyInterface(ctx context.Context, in *VerifyInterfaceRequest, opts ...grpc.CallOption) (*VerifyInterfaceResponse, error)
+	// GetInterfaceStats returns the counters of the WireGuard interface from the OS network statistics
+	GetInterfaceStats(ctx context.Context, in *GetInterfaceStatsRequest, opts ...grpc.CallOption) (*GetInterfaceStatsResponse, error)
+	// RekeyPeer drops the WireGuard session keys of a peer and triggers a new handshake
+	RekeyPeer(ctx context.Context, in *RekeyPeerRequest, opts ...grpc.CallOption) (*RekeyPeerResponse, error)
+	// SetCustomDNSTTL changes the TTL of the DNS records pushed to the local resolver without reconnecting
+	SetCustomDNSTTL(ctx context.Context, in *SetCustomDNSTTLRequest, opts ...grpc.CallOption) (*SetCustomDNSTTLResponse, error)
+	// SetLatencyColorThresholds stores the latency color thresholds of the status command in the daemon config
+	SetLatencyColorThresholds(ctx context.Context, in *SetLatencyColorThresholdsRequest, opts ...grpc.CallOption) (*SetLatencyColorThresholdsResponse, error)
 }
 
 type daemonServiceClient struct {
@@ -95,6 +117,105 @@ func (c *daemonServiceClient) GetConfig(ctx context.Context, in *GetConfigReques
 	return out, nil
 }
 
+func (c *daemonServiceClient) RouteTest(ctx context.Context, in *RouteTestRequest, opts ...grpc.CallOption) (*RouteTestResponse, error) {
+	out := new(RouteTestResponse)
+	err := c.cc.Invoke(ctx, "/daemon.DaemonService/RouteTest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetDaemonUptime(ctx context.Context, in *GetDaemonUptimeRequest, opts ...grpc.CallOption) (*GetDaemonUptimeResponse, error) {
+	out := new(GetDaemonUptimeResponse)
+	err := c.cc.Invoke(ctx, "/daemon.DaemonService/GetDaemonUptime", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetPeerEvents(ctx context.Context, in *GetPeerEventsRequest, opts ...grpc.CallOption) (*GetPeerEventsResponse, error) {
+	out := new(GetPeerEventsResponse)
+	err := c.cc.Invoke(ctx, "/daemon.DaemonService/GetPeerEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetGroupMembers(ctx context.Context, in *GetGroupMembersRequest, opts ...grpc.CallOption) (*GetGroupMembersResponse, error) {
+	out := new(GetGroupMembersResponse)
+	err := c.cc.Invoke(ctx, "/daemon.DaemonService/GetGroupMembers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetProcessStats(ctx context.Context, in *GetProcessStatsRequest, opts ...grpc.CallOption) (*GetProcessStatsResponse, error) {
+	out := new(GetProcessStatsResponse)
+	err := c.cc.Invoke(ctx, "/daemon.DaemonService/GetProcessStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetProcessInfo(ctx context.Context, in *GetProcessInfoRequest, opts ...grpc.CallOption) (*GetProcessInfoResponse, error) {
+	out := new(GetProcessInfoResponse)
+	err := c.cc.Invoke(ctx, "/daemon.DaemonService/GetProcessInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) VerifyInterface(ctx context.Context, in *VerifyInterfaceRequest, opts ...grpc.CallOption) (*VerifyInterfaceResponse, error) {
+	out := new(VerifyInterfaceResponse)
+	err := c.cc.Invoke(ctx, "/daemon.DaemonService/VerifyInterface", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) GetInterfaceStats(ctx context.Context, in *GetInterfaceStatsRequest, opts ...grpc.CallOption) (*GetInterfaceStatsResponse, error) {
+	out := new(GetInterfaceStatsResponse)
+	err := c.cc.Invoke(ctx, "/daemon.DaemonService/GetInterfaceStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) RekeyPeer(ctx context.Context, in *RekeyPeerRequest, opts ...grpc.CallOption) (*RekeyPeerResponse, error) {
+	out := new(RekeyPeerResponse)
+	err := c.cc.Invoke(ctx, "/daemon.DaemonService/RekeyPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) SetCustomDNSTTL(ctx context.Context, in *SetCustomDNSTTLRequest, opts ...grpc.CallOption) (*SetCustomDNSTTLResponse, error) {
+	out := new(SetCustomDNSTTLResponse)
+	err := c.cc.Invoke(ctx, "/daemon.DaemonService/SetCustomDNSTTL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *daemonServiceClient) SetLatencyColorThresholds(ctx context.Context, in *SetLatencyColorThresholdsRequest, opts ...grpc.CallOption) (*SetLatencyColorThresholdsResponse, error) {
+	out := new(SetLatencyColorThresholdsResponse)
+	err := c.cc.Invoke(ctx, "/daemon.DaemonService/SetLatencyColorThresholds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DaemonServiceServer is the server API for DaemonService service.
 // All implementations must embed UnimplementedDaemonServiceServer
 // for forward compatibility
@@ -112,6 +233,28 @@ type DaemonServiceServer interface {
 	Down(context.Context, *DownRequest) (*DownResponse, error)
 	// GetConfig of the daemon.
 	GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error)
+	// RouteTest evaluates the route table of the daemon for a destination
+	RouteTest(context.Context, *RouteTestRequest) (*RouteTestResponse, error)
+	// GetDaemonUptime returns the time the daemon was started at
+	GetDaemonUptime(context.Context, *GetDaemonUptimeRequest) (*GetDaemonUptimeResponse, error)
+	// GetPeerEvents returns the connection status changes of a peer recorded by the daemon
+	GetPeerEvents(context.Context, *GetPeerEventsRequest) (*GetPeerEventsResponse, error)
+	// GetGroupMembers returns the FQDNs of the peers belonging to any of the given management groups
+	GetGroupMembers(context.Context, *GetGroupMembersRequest) (*GetGroupMembersResponse, error)
+	// GetProcessStats returns the memory usage of the daemon process, only to root or the daemon user
+	GetProcessStats(context.Context, *GetProcessStatsRequest) (*GetProcessStatsResponse, error)
+	// GetProcessInfo returns the process ID of the daemon
+	GetProcessInfo(context.Context, *GetProcessInfoRequest) (*GetProcessInfoResponse, error)
+	// VerifyInterface checks the WireGuard interface against the state the daemon expects
+	VerifyInterface(context.Context, *VerifyInterfaceRequest) (*VerifyInterfaceResponse, error)
+	// GetInterfaceStats returns the counters of the WireGuard interface from the OS network statistics
+	GetInterfaceStats(context.Context, *GetInterfaceStatsRequest) (*GetInterfaceStatsResponse, error)
+	// RekeyPeer drops the WireGuard session keys of a peer and triggers a new handshake
+	RekeyPeer(context.Context, *RekeyPeerRequest) (*RekeyPeerResponse, error)
+	// SetCustomDNSTTL changes the TTL of the DNS records pushed to the local resolver without reconnecting
+	SetCustomDNSTTL(context.Context, *SetCustomDNSTTLRequest) (*SetCustomDNSTTLResponse, error)
+	// SetLatencyColorThresholds stores the latency color thresholds of the status command in the daemon config
+	SetLatencyColorThresholds(context.Context, *SetLatencyColorThresholdsRequest) (*SetLatencyColorThresholdsResponse, error)
 	mustEmbedUnimplementedDaemonServiceServer()
 }
 
@@ -137,6 +280,39 @@ func (UnimplementedDaemonServiceServer) Down(context.Context, *DownRequest) (*Do
 func (UnimplementedDaemonServiceServer) GetConfig(context.Context, *GetConfigRequest) (*GetConfigResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
 }
+func (UnimplementedDaemonServiceServer) RouteTest(context.Context, *RouteTestRequest) (*RouteTestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RouteTest not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetDaemonUptime(context.Context, *GetDaemonUptimeRequest) (*GetDaemonUptimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDaemonUptime not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetPeerEvents(context.Context, *GetPeerEventsRequest) (*GetPeerEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPeerEvents not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetGroupMembers(context.Context, *GetGroupMembersRequest) (*GetGroupMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroupMembers not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetProcessStats(context.Context, *GetProcessStatsRequest) (*GetProcessStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProcessStats not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetProcessInfo(context.Context, *GetProcessInfoRequest) (*GetProcessInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProcessInfo not implemented")
+}
+func (UnimplementedDaemonServiceServer) VerifyInterface(context.Context, *VerifyInterfaceRequest) (*VerifyInterfaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyInterface not implemented")
+}
+func (UnimplementedDaemonServiceServer) GetInterfaceStats(context.Context, *GetInterfaceStatsRequest) (*GetInterfaceStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInterfaceStats not implemented")
+}
+func (UnimplementedDaemonServiceServer) RekeyPeer(context.Context, *RekeyPeerRequest) (*RekeyPeerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RekeyPeer not implemented")
+}
+func (UnimplementedDaemonServiceServer) SetCustomDNSTTL(context.Context, *SetCustomDNSTTLRequest) (*SetCustomDNSTTLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCustomDNSTTL not implemented")
+}
+func (UnimplementedDaemonServiceServer) SetLatencyColorThresholds(context.Context, *SetLatencyColorThresholdsRequest) (*SetLatencyColorThresholdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLatencyColorThresholds not implemented")
+}
 func (UnimplementedDaemonServiceServer) mustEmbedUnimplementedDaemonServiceServer() {}
 
 // UnsafeDaemonServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -258,6 +434,204 @@ func _DaemonService_GetConfig_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DaemonService_RouteTest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RouteTestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).RouteTest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/daemon.DaemonService/RouteTest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).RouteTest(ctx, req.(*RouteTestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetDaemonUptime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDaemonUptimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetDaemonUptime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/daemon.DaemonService/GetDaemonUptime",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetDaemonUptime(ctx, req.(*GetDaemonUptimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetPeerEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPeerEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetPeerEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/daemon.DaemonService/GetPeerEvents",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetPeerEvents(ctx, req.(*GetPeerEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetGroupMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGroupMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetGroupMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/daemon.DaemonService/GetGroupMembers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetGroupMembers(ctx, req.(*GetGroupMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetProcessStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProcessStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetProcessStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/daemon.DaemonService/GetProcessStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetProcessStats(ctx, req.(*GetProcessStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetProcessInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProcessInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetProcessInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/daemon.DaemonService/GetProcessInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetProcessInfo(ctx, req.(*GetProcessInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_VerifyInterface_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyInterfaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).VerifyInterface(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/daemon.DaemonService/VerifyInterface",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).VerifyInterface(ctx, req.(*VerifyInterfaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_GetInterfaceStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInterfaceStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).GetInterfaceStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/daemon.DaemonService/GetInterfaceStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).GetInterfaceStats(ctx, req.(*GetInterfaceStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_RekeyPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RekeyPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).RekeyPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/daemon.DaemonService/RekeyPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).RekeyPeer(ctx, req.(*RekeyPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_SetCustomDNSTTL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCustomDNSTTLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).SetCustomDNSTTL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/daemon.DaemonService/SetCustomDNSTTL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).SetCustomDNSTTL(ctx, req.(*SetCustomDNSTTLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DaemonService_SetLatencyColorThresholds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLatencyColorThresholdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DaemonServiceServer).SetLatencyColorThresholds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/daemon.DaemonService/SetLatencyColorThresholds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DaemonServiceServer).SetLatencyColorThresholds(ctx, req.(*SetLatencyColorThresholdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DaemonService_ServiceDesc is the grpc.ServiceDesc for DaemonService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -289,6 +663,50 @@ var DaemonService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetConfig",
 			Handler:    _DaemonService_GetConfig_Handler,
 		},
+		{
+			MethodName: "RouteTest",
+			Handler:    _DaemonService_RouteTest_Handler,
+		},
+		{
+			MethodName: "GetDaemonUptime",
+			Handler:    _DaemonService_GetDaemonUptime_Handler,
+		},
+		{
+			MethodName: "GetPeerEvents",
+			Handler:    _DaemonService_GetPeerEvents_Handler,
+		},
+		{
+			MethodName: "GetGroupMembers",
+			Handler:    _DaemonService_GetGroupMembers_Handler,
+		},
+		{
+			MethodName: "GetProcessStats",
+			Handler:    _DaemonService_GetProcessStats_Handler,
+		},
+		{
+			MethodName: "GetProcessInfo",
+			Handler:    _DaemonService_GetProcessInfo_Handler,
+		},
+		{
+			MethodName: "VerifyInterface",
+			Handler:    _DaemonService_VerifyInterface_Handler,
+		},
+		{
+			MethodName: "GetInterfaceStats",
+			Handler:    _DaemonService_GetInterfaceStats_Handler,
+		},
+		{
+			MethodName: "RekeyPeer",
+			Handler:    _DaemonService_RekeyPeer_Handler,
+		},
+		{
+			MethodName: "SetCustomDNSTTL",
+			Handler:    _DaemonService_SetCustomDNSTTL_Handler,
+		},
+		{
+			MethodName: "SetLatencyColorThresholds",
+			Handler:    _DaemonService_SetLatencyColorThresholds_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "daemon.proto",
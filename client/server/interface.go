@@ -0,0 +1,115 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	log "github.com/sirupsen/logrus"
+	"golang.zx2c4.com/wireguard/wgctrl"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+	"google.golang.org/grpc/codes"
+	gstatus "google.golang.org/grpc/status"
+
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+// VerifyInterface checks that the WireGuard interface exists and matches the IP, public key and peers of the daemon state
+func (s *Server) VerifyInterface(_ context.Context, _ *proto.VerifyInterfaceRequest) (*proto.VerifyInterfaceResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.config == nil || s.statusRecorder == nil {
+		return nil, gstatus.Errorf(codes.FailedPrecondition, "service is not up")
+	}
+
+	ifaceName := s.config.WgIface
+	localPeer := s.statusRecorder.GetLocalPeerState()
+
+	device, deviceErr := wgDevice(ifaceName)
+
+	var addrs []net.Addr
+	if deviceErr == nil {
+		addrs, deviceErr = interfaceAddrs(ifaceName)
+	}
+
+	return &proto.VerifyInterfaceResponse{
+		InterfaceName: ifaceName,
+		Checks:        verifyInterface(device, deviceErr, addrs, localPeer.IP, localPeer.PubKey),
+	}, nil
+}
+
+func wgDevice(ifaceName string) (*wgtypes.Device, error) {
+	wg, err := wgctrl.New()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := wg.Close(); err != nil {
+			log.Errorf("got error while closing wgctl: %v", err)
+		}
+	}()
+
+	return wg.Device(ifaceName)
+}
+
+func interfaceAddrs(ifaceName string) ([]net.Addr, error) {
+	iface, err := net.InterfaceByName(ifaceName)
+	if err != nil {
+		return nil, err
+	}
+	return iface.Addrs()
+}
+
+// verifyInterface compares the WireGuard device and the addresses of its interface with the expected IP and public key.
+// The remaining checks fail without running when the device couldn't be read
+func verifyInterface(device *wgtypes.Device, deviceErr error, addrs []net.Addr, expectedIP, expectedPubKey string) []*proto.InterfaceCheck {
+	if deviceErr != nil {
+		return []*proto.InterfaceCheck{
+			{Name: "exists", Message: deviceErr.Error()},
+			{Name: "ip", Message: "interface not found"},
+			{Name: "public-key", Message: "interface not found"},
+			{Name: "peers", Message: "interface not found"},
+		}
+	}
+
+	checks := []*proto.InterfaceCheck{{Name: "exists", Pass: true}}
+
+	ipCheck := &proto.InterfaceCheck{Name: "ip"}
+	switch {
+	case expectedIP == "":
+		ipCheck.Message = "no NetBird IP has been assigned by management"
+	case hasAddr(addrs, expectedIP):
+		ipCheck.Pass = true
+		ipCheck.Message = expectedIP
+	default:
+		ipCheck.Message = fmt.Sprintf("expected %s, found %v", expectedIP, addrs)
+	}
+	checks = append(checks, ipCheck)
+
+	pubKeyCheck := &proto.InterfaceCheck{Name: "public-key", Message: device.PublicKey.String()}
+	if device.PublicKey.String() == expectedPubKey {
+		pubKeyCheck.Pass = true
+	} else {
+		pubKeyCheck.Message = fmt.Sprintf("expected %s, found %s", expectedPubKey, device.PublicKey.String())
+	}
+	checks = append(checks, pubKeyCheck)
+
+	checks = append(checks, &proto.InterfaceCheck{
+		Name:    "peers",
+		Pass:    len(device.Peers) > 0,
+		Message: fmt.Sprintf("%d peers configured", len(device.Peers)),
+	})
+
+	return checks
+}
+
+// hasAddr reports whether any of the addresses equals the expected address in CIDR notation, e.g., 100.64.0.1/16
+func hasAddr(addrs []net.Addr, expected string) bool {
+	for _, addr := range addrs {
+		if addr.String() == expected {
+			return true
+		}
+	}
+	return false
+}
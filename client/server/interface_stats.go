@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	psnet "github.com/shirou/gopsutil/v3/net"
+	"google.golang.org/grpc/codes"
+	gstatus "google.golang.org/grpc/status"
+
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+// GetInterfaceStats returns the counters of the WireGuard interface as the OS network statistics report them,
+// e.g., /proc/net/dev on Linux, so they include the errors and drops WireGuard doesn't count
+func (s *Server) GetInterfaceStats(ctx context.Context, _ *proto.GetInterfaceStatsRequest) (*proto.GetInterfaceStatsResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.config == nil {
+		return nil, gstatus.Errorf(codes.FailedPrecondition, "service is not up")
+	}
+
+	ifaceName := s.config.WgIface
+
+	iface, err := net.InterfaceByName(ifaceName)
+	if err != nil {
+		return nil, gstatus.Errorf(codes.NotFound, "interface %s: %v", ifaceName, err)
+	}
+
+	counters, err := psnet.IOCountersWithContext(ctx, true)
+	if err != nil {
+		return nil, gstatus.Errorf(codes.Internal, "read interface counters: %v", err)
+	}
+
+	resp, err := interfaceStats(ifaceName, iface.Flags&net.FlagUp != 0, counters)
+	if err != nil {
+		return nil, gstatus.Error(codes.NotFound, err.Error())
+	}
+	return resp, nil
+}
+
+// interfaceStats picks the counters of the interface from the counters of every interface
+func interfaceStats(ifaceName string, up bool, counters []psnet.IOCountersStat) (*proto.GetInterfaceStatsResponse, error) {
+	for _, counter := range counters {
+		if counter.Name != ifaceName {
+			continue
+		}
+		return &proto.GetInterfaceStatsResponse{
+			InterfaceName: ifaceName,
+			Up:            up,
+			RxBytes:       counter.BytesRecv,
+			TxBytes:       counter.BytesSent,
+			RxPackets:     counter.PacketsRecv,
+			TxPackets:     counter.PacketsSent,
+			RxErrors:      counter.Errin,
+			TxErrors:      counter.Errout,
+		}, nil
+	}
+	return nil, fmt.Errorf("no statistics for interface %s", ifaceName)
+}
@@ -0,0 +1,30 @@
+package server
+
+import (
+	"testing"
+
+	psnet "github.com/shirou/gopsutil/v3/net"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterfaceStats(t *testing.T) {
+	counters := []psnet.IOCountersStat{
+		{Name: "eth0", BytesRecv: 1, BytesSent: 2},
+		{Name: "wt0", BytesRecv: 1024, BytesSent: 2048, PacketsRecv: 10, PacketsSent: 20, Errin: 1, Errout: 2},
+	}
+
+	stats, err := interfaceStats("wt0", true, counters)
+	require.NoError(t, err)
+	assert.Equal(t, "wt0", stats.GetInterfaceName())
+	assert.True(t, stats.GetUp())
+	assert.Equal(t, uint64(1024), stats.GetRxBytes())
+	assert.Equal(t, uint64(2048), stats.GetTxBytes())
+	assert.Equal(t, uint64(10), stats.GetRxPackets())
+	assert.Equal(t, uint64(20), stats.GetTxPackets())
+	assert.Equal(t, uint64(1), stats.GetRxErrors())
+	assert.Equal(t, uint64(2), stats.GetTxErrors())
+
+	_, err = interfaceStats("wt1", true, counters)
+	assert.Error(t, err)
+}
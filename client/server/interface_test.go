@@ -0,0 +1,72 @@
+package server
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+func TestVerifyInterface(t *testing.T) {
+	key, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+	otherKey, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	addrs := []net.Addr{&net.IPNet{IP: net.ParseIP("100.64.0.1").To4(), Mask: net.CIDRMask(16, 32)}}
+
+	tests := []struct {
+		name           string
+		device         *wgtypes.Device
+		deviceErr      error
+		expectedIP     string
+		expectedPubKey string
+		expectedPass   map[string]bool
+	}{
+		{
+			name:           "interface matches",
+			device:         &wgtypes.Device{PublicKey: key.PublicKey(), Peers: []wgtypes.Peer{{}}},
+			expectedIP:     "100.64.0.1/16",
+			expectedPubKey: key.PublicKey().String(),
+			expectedPass:   map[string]bool{"exists": true, "ip": true, "public-key": true, "peers": true},
+		},
+		{
+			name:           "interface diverged",
+			device:         &wgtypes.Device{PublicKey: otherKey.PublicKey()},
+			expectedIP:     "100.64.0.2/16",
+			expectedPubKey: key.PublicKey().String(),
+			expectedPass:   map[string]bool{"exists": true, "ip": false, "public-key": false, "peers": false},
+		},
+		{
+			name:           "no ip assigned",
+			device:         &wgtypes.Device{PublicKey: key.PublicKey(), Peers: []wgtypes.Peer{{}}},
+			expectedPubKey: key.PublicKey().String(),
+			expectedPass:   map[string]bool{"exists": true, "ip": false, "public-key": true, "peers": true},
+		},
+		{
+			name:           "interface missing",
+			deviceErr:      errors.New("file does not exist"),
+			expectedIP:     "100.64.0.1/16",
+			expectedPubKey: key.PublicKey().String(),
+			expectedPass:   map[string]bool{"exists": false, "ip": false, "public-key": false, "peers": false},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			checks := verifyInterface(tc.device, tc.deviceErr, addrs, tc.expectedIP, tc.expectedPubKey)
+
+			pass := make(map[string]bool)
+			for _, check := range checks {
+				pass[check.GetName()] = check.GetPass()
+				if !check.GetPass() {
+					assert.NotEmpty(t, check.GetMessage(), "failed check %s should explain why", check.GetName())
+				}
+			}
+			assert.Equal(t, tc.expectedPass, pass)
+		})
+	}
+}
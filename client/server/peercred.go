@@ -0,0 +1,65 @@
+package server
+
+import (
+	"context"
+	"net"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/peer"
+)
+
+const peerCredentialsAuthType = "peercred"
+
+// peerCredentials are insecure transport credentials that record the user of the process
+// on the other end of a unix socket connection
+type peerCredentials struct {
+	credentials.TransportCredentials
+}
+
+// peerAuthInfo holds the user ID of the connected process. UIDKnown is false when the platform
+// or the transport doesn't provide it
+type peerAuthInfo struct {
+	credentials.CommonAuthInfo
+	UID      uint32
+	UIDKnown bool
+}
+
+func (peerAuthInfo) AuthType() string {
+	return peerCredentialsAuthType
+}
+
+// NewPeerCredentials returns the transport credentials the daemon gRPC server uses to identify its callers
+func NewPeerCredentials() credentials.TransportCredentials {
+	return &peerCredentials{TransportCredentials: insecure.NewCredentials()}
+}
+
+func (c *peerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	info := peerAuthInfo{CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.NoSecurity}}
+	if unixConn, ok := conn.(*net.UnixConn); ok {
+		info.UID, info.UIDKnown = peerUID(unixConn)
+	}
+	return conn, info, nil
+}
+
+func (c *peerCredentials) Clone() credentials.TransportCredentials {
+	return &peerCredentials{TransportCredentials: c.TransportCredentials.Clone()}
+}
+
+// callerIsOwnerOrRoot reports whether the caller runs as root or as the daemon user.
+// Callers over the local TCP socket used on Windows are allowed, callers over a unix socket whose user
+// can't be determined are not
+func callerIsOwnerOrRoot(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return true
+	}
+
+	info, ok := p.AuthInfo.(peerAuthInfo)
+	if !ok || !info.UIDKnown {
+		return p.Addr == nil || p.Addr.Network() != "unix"
+	}
+
+	return info.UID == 0 || int(info.UID) == os.Getuid()
+}
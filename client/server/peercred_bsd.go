@@ -0,0 +1,29 @@
+//go:build darwin || freebsd
+// +build darwin freebsd
+
+package server
+
+import (
+	"net"
+
+	"golang.org/x/sys/unix"
+)
+
+// peerUID returns the user ID of the process connected to the unix socket
+func peerUID(conn *net.UnixConn) (uint32, bool) {
+	rawConn, err := conn.SyscallConn()
+	if err != nil {
+		return 0, false
+	}
+
+	var cred *unix.Xucred
+	var credErr error
+	err = rawConn.Control(func(fd uintptr) {
+		cred, credErr = unix.GetsockoptXucred(int(fd), unix.SOL_LOCAL, unix.LOCAL_PEERCRED)
+	})
+	if err != nil || credErr != nil {
+		return 0, false
+	}
+
+	return cred.Uid, true
+}
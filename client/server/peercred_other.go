@@ -0,0 +1,11 @@
+//go:build !linux && !darwin && !freebsd
+// +build !linux,!darwin,!freebsd
+
+package server
+
+import "net"
+
+// peerUID is not supported on this platform
+func peerUID(_ *net.UnixConn) (uint32, bool) {
+	return 0, false
+}
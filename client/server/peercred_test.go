@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+func TestPeerCredentialsServerHandshake(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "daemon.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	clientConn, err := net.Dial("unix", socket)
+	require.NoError(t, err)
+	defer clientConn.Close()
+
+	serverConn, err := listener.Accept()
+	require.NoError(t, err)
+	defer serverConn.Close()
+
+	_, authInfo, err := NewPeerCredentials().ServerHandshake(serverConn)
+	require.NoError(t, err)
+
+	info, ok := authInfo.(peerAuthInfo)
+	require.True(t, ok)
+	assert.Equal(t, peerCredentialsAuthType, info.AuthType())
+	switch runtime.GOOS {
+	case "linux", "darwin", "freebsd":
+		assert.True(t, info.UIDKnown)
+		assert.Equal(t, uint32(os.Getuid()), info.UID)
+	}
+}
+
+func TestCallerIsOwnerOrRoot(t *testing.T) {
+	withAuthInfo := func(info credentials.AuthInfo) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: info})
+	}
+	overSocket := func(addr net.Addr, info credentials.AuthInfo) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{Addr: addr, AuthInfo: info})
+	}
+	unixAddr := &net.UnixAddr{Name: "/var/run/netbird.sock", Net: "unix"}
+
+	assert.True(t, callerIsOwnerOrRoot(context.Background()), "callers without peer info should be allowed")
+	assert.True(t, callerIsOwnerOrRoot(withAuthInfo(peerAuthInfo{})), "callers with unknown user should be allowed")
+	assert.True(t, callerIsOwnerOrRoot(overSocket(&net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 41731}, peerAuthInfo{})),
+		"callers over the local TCP socket should be allowed")
+	assert.False(t, callerIsOwnerOrRoot(overSocket(unixAddr, peerAuthInfo{})), "callers over a unix socket with unknown user should be denied")
+	assert.False(t, callerIsOwnerOrRoot(overSocket(unixAddr, nil)), "callers over a unix socket without peer credentials should be denied")
+	assert.True(t, callerIsOwnerOrRoot(overSocket(unixAddr, peerAuthInfo{UID: uint32(os.Getuid()), UIDKnown: true})))
+	assert.True(t, callerIsOwnerOrRoot(withAuthInfo(peerAuthInfo{UID: 0, UIDKnown: true})))
+	assert.True(t, callerIsOwnerOrRoot(withAuthInfo(peerAuthInfo{UID: uint32(os.Getuid()), UIDKnown: true})))
+
+	if os.Getuid() != 12345 {
+		assert.False(t, callerIsOwnerOrRoot(withAuthInfo(peerAuthInfo{UID: 12345, UIDKnown: true})))
+	}
+}
+
+func TestPIDVisible(t *testing.T) {
+	assert.True(t, pidVisible(false, false, false), "the PID of a non-root daemon is always visible")
+	assert.True(t, pidVisible(true, true, false))
+	assert.True(t, pidVisible(true, false, true))
+	assert.False(t, pidVisible(true, false, false))
+}
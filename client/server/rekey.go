@@ -0,0 +1,146 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	gstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/netbirdio/netbird/client/internal"
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/client/internal/ping"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+const (
+	rekeyWaitTimeout  = 30 * time.Second
+	rekeyPollInterval = 200 * time.Millisecond
+)
+
+// RekeyPeer drops the session keys of the peer through the engine, which removes it from the kernel WireGuard
+// interface and adds it back or expires its keypairs in userspace. A ping through the tunnel triggers the handshake
+// of the new session right away
+func (s *Server) RekeyPeer(ctx context.Context, msg *proto.RekeyPeerRequest) (*proto.RekeyPeerResponse, error) {
+	s.mutex.Lock()
+	if s.config == nil || s.statusRecorder == nil {
+		s.mutex.Unlock()
+		return nil, gstatus.Errorf(codes.FailedPrecondition, "service is not up")
+	}
+	peers := s.statusRecorder.GetFullStatus().Peers
+	s.mutex.Unlock()
+
+	peerState, err := findPeerByName(peers, msg.GetPeerFqdn())
+	if err != nil {
+		return nil, err
+	}
+
+	engine := internal.CtxGetState(s.rootCtx).Engine()
+	if engine == nil {
+		return nil, gstatus.Errorf(codes.FailedPrecondition, "service is not connected")
+	}
+
+	rekeyedAt := time.Now()
+	err = engine.RekeyPeer(peerState.PubKey)
+	if errors.Is(err, internal.ErrPeerNotConnected) {
+		return nil, gstatus.Errorf(codes.FailedPrecondition, "peer %s is not connected", peerState.FQDN)
+	}
+	if err != nil {
+		return nil, gstatus.Errorf(codes.Internal, "rekey peer %s: %v", peerState.FQDN, err)
+	}
+	log.Infof("dropped the WireGuard session keys of peer %s (%s) on request", peerState.FQDN, peerState.PubKey)
+
+	triggerHandshake(peerState.IP)
+
+	resp := &proto.RekeyPeerResponse{
+		Fqdn:      peerState.FQDN,
+		PublicKey: peerState.PubKey,
+	}
+	if !msg.GetWait() {
+		return resp, nil
+	}
+
+	handshake, err := waitForHandshake(ctx, engine.LastHandshake, peerState.PubKey, rekeyedAt)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("peer %s completed the handshake of the new WireGuard session", peerState.FQDN)
+
+	resp.LastHandshakeTime = timestamppb.New(handshake)
+	return resp, nil
+}
+
+// findPeerByName matches the FQDN or the hostname of the peer, ignoring the case. A hostname shared by several
+// peers is rejected, their FQDNs tell them apart
+func findPeerByName(peers []peer.State, name string) (peer.State, error) {
+	lowerName := strings.ToLower(name)
+	var matches []peer.State
+	for _, peerState := range peers {
+		fqdn := strings.ToLower(peerState.FQDN)
+		if fqdn == lowerName {
+			return peerState, nil
+		}
+		if strings.Split(fqdn, ".")[0] == lowerName {
+			matches = append(matches, peerState)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return peer.State{}, gstatus.Errorf(codes.NotFound, "unknown peer %s", name)
+	case 1:
+		return matches[0], nil
+	}
+
+	fqdns := make([]string, 0, len(matches))
+	for _, match := range matches {
+		fqdns = append(fqdns, match.FQDN)
+	}
+	return peer.State{}, gstatus.Errorf(codes.InvalidArgument, "peer name %s is ambiguous, use one of the FQDNs: %s", name, strings.Join(fqdns, ", "))
+}
+
+// triggerHandshake sends a packet to the peer through the tunnel, WireGuard starts a handshake for the first packet
+// without a session
+func triggerHandshake(ip string) {
+	addr, err := netip.ParseAddr(strings.Split(ip, "/")[0])
+	if err != nil {
+		log.Debugf("failed parsing the IP %s of the rekeyed peer: %v", ip, err)
+		return
+	}
+
+	go func() {
+		if _, err := ping.Ping(context.Background(), addr); err != nil {
+			log.Debugf("rekeyed peer %s didn't answer the ping: %v", addr, err)
+		}
+	}()
+}
+
+// waitForHandshake polls the last handshake of the peer until it completes a handshake after the given time
+func waitForHandshake(ctx context.Context, lastHandshake func(peerKey string) (time.Time, error), peerKey string, after time.Time) (time.Time, error) {
+	ctx, cancel := context.WithTimeout(ctx, rekeyWaitTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(rekeyPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return time.Time{}, gstatus.Errorf(codes.DeadlineExceeded, "no handshake of the new session within %s", rekeyWaitTimeout)
+		case <-ticker.C:
+		}
+
+		handshake, err := lastHandshake(peerKey)
+		if err != nil {
+			return time.Time{}, gstatus.Errorf(codes.Internal, "last handshake of %s: %v", peerKey, err)
+		}
+		if handshake.After(after) {
+			return handshake, nil
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	gstatus "google.golang.org/grpc/status"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+)
+
+func TestFindPeerByName(t *testing.T) {
+	peers := []peer.State{
+		{FQDN: "peer-1.netbird.cloud", PubKey: "key-1"},
+		{FQDN: "peer-2.netbird.cloud", PubKey: "key-2"},
+		{FQDN: "db.netbird.cloud", PubKey: "key-3"},
+		{FQDN: "db.eu.netbird.cloud", PubKey: "key-4"},
+	}
+
+	found, err := findPeerByName(peers, "PEER-2.netbird.cloud")
+	require.NoError(t, err)
+	assert.Equal(t, "key-2", found.PubKey)
+
+	found, err = findPeerByName(peers, "peer-1")
+	require.NoError(t, err, "the hostname matches as well")
+	assert.Equal(t, "key-1", found.PubKey)
+
+	found, err = findPeerByName(peers, "db.eu.netbird.cloud")
+	require.NoError(t, err, "the FQDN tells apart peers sharing a hostname")
+	assert.Equal(t, "key-4", found.PubKey)
+
+	_, err = findPeerByName(peers, "db")
+	assert.Equal(t, codes.InvalidArgument, gstatus.Code(err), "a hostname shared by several peers is ambiguous")
+
+	_, err = findPeerByName(peers, "peer-3")
+	assert.Equal(t, codes.NotFound, gstatus.Code(err))
+}
+
+func TestWaitForHandshake(t *testing.T) {
+	rekeyedAt := time.Now()
+	polls := 0
+	lastHandshake := func(peerKey string) (time.Time, error) {
+		assert.Equal(t, "key-1", peerKey)
+		polls++
+		if polls < 3 {
+			return rekeyedAt.Add(-time.Minute), nil
+		}
+		return rekeyedAt.Add(time.Second), nil
+	}
+
+	handshake, err := waitForHandshake(context.Background(), lastHandshake, "key-1", rekeyedAt)
+	require.NoError(t, err)
+	assert.Equal(t, rekeyedAt.Add(time.Second), handshake)
+	assert.Equal(t, 3, polls, "handshakes before the rekey are ignored")
+
+	_, err = waitForHandshake(context.Background(), func(string) (time.Time, error) {
+		return time.Time{}, errors.New("interface is down")
+	}, "key-1", rekeyedAt)
+	assert.Equal(t, codes.Internal, gstatus.Code(err))
+}
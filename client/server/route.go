@@ -0,0 +1,126 @@
+package server
+
+import (
+	"context"
+	"net/netip"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	gstatus "google.golang.org/grpc/status"
+
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+// RouteTest evaluates the client routes for the requested destination and returns the route that would serve it.
+// The requested peer is resolved by FQDN or hostname and compared with the peer serving the route
+func (s *Server) RouteTest(_ context.Context, msg *proto.RouteTestRequest) (*proto.RouteTestResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	destination, err := parseDestination(msg.GetDestination())
+	if err != nil {
+		return nil, gstatus.Errorf(codes.InvalidArgument, "invalid destination %s: %v", msg.GetDestination(), err)
+	}
+
+	if s.statusRecorder == nil {
+		return nil, gstatus.Errorf(codes.FailedPrecondition, "service is not up")
+	}
+
+	peers := s.statusRecorder.GetFullStatus().Peers
+
+	var requested peer.State
+	if msg.GetPeerFqdn() != "" {
+		requested, err = findPeerByName(peers, msg.GetPeerFqdn())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &proto.RouteTestResponse{PeerFqdn: requested.FQDN}
+
+	prefix, gateway, found := findRouteForDestination(peers, destination)
+	if !found {
+		return resp, nil
+	}
+
+	metric, _ := s.statusRecorder.GetRouteMetric(prefix.String())
+
+	resp.Found = true
+	resp.RoutePrefix = prefix.String()
+	resp.GatewayFqdn = gateway.FQDN
+	resp.Metric = int64(metric)
+	resp.FullTunnel = prefix.Bits() == 0
+	resp.ServedByPeer = requested.PubKey != "" && gateway.PubKey == requested.PubKey
+	return resp, nil
+}
+
+// parseDestination parses a CIDR or a single IP address into a prefix
+func parseDestination(destination string) (netip.Prefix, error) {
+	if strings.Contains(destination, "/") {
+		prefix, err := netip.ParsePrefix(destination)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(destination)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// findRouteForDestination returns the most specific route covering the destination and the peer chosen to serve it.
+// The NetBird IP of every peer is a candidate too, so a peer is reached directly even with a default route active
+func findRouteForDestination(peers []peer.State, destination netip.Prefix) (netip.Prefix, peer.State, bool) {
+	var (
+		bestPrefix netip.Prefix
+		bestPeer   peer.State
+		found      bool
+	)
+
+	for _, peerState := range peers {
+		peerPrefix, hasPeerPrefix := peerIPPrefix(peerState)
+		candidates := make([]string, 0, len(peerState.Routes)+1)
+		if hasPeerPrefix {
+			candidates = append(candidates, peerPrefix.String())
+		}
+		for network := range peerState.Routes {
+			candidates = append(candidates, network)
+		}
+
+		for _, network := range candidates {
+			prefix, err := netip.ParsePrefix(network)
+			if err != nil {
+				continue
+			}
+
+			if prefix.Bits() > destination.Bits() || !prefix.Contains(destination.Addr()) {
+				continue
+			}
+
+			// the peer own IP wins over a route of another peer of the same length
+			directPath := hasPeerPrefix && prefix == peerPrefix
+			if !found || prefix.Bits() > bestPrefix.Bits() || prefix.Bits() == bestPrefix.Bits() && directPath {
+				bestPrefix = prefix
+				bestPeer = peerState
+				found = true
+			}
+		}
+	}
+
+	return bestPrefix, bestPeer, found
+}
+
+// peerIPPrefix returns the host prefix of the peer NetBird IP. The recorder keeps the bare address for connected
+// peers and the allowed IPs of management for offline ones
+func peerIPPrefix(peerState peer.State) (netip.Prefix, bool) {
+	ip := strings.Split(strings.Split(peerState.IP, ",")[0], "/")[0]
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return netip.Prefix{}, false
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), true
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	gstatus "google.golang.org/grpc/status"
+
+	"github.com/netbirdio/netbird/client/internal"
+	"github.com/netbirdio/netbird/client/internal/peer"
+	"github.com/netbirdio/netbird/client/proto"
+)
+
+func TestFindRouteForDestination(t *testing.T) {
+	peers := []peer.State{
+		{
+			FQDN:   "router-a.netbird.cloud",
+			IP:     "100.64.0.10",
+			Routes: map[string]struct{}{"10.0.0.0/16": {}, "100.64.0.12/32": {}},
+		},
+		{
+			FQDN:   "router-b.netbird.cloud",
+			IP:     "100.64.0.11/32,10.0.1.0/24",
+			Routes: map[string]struct{}{"10.0.1.0/24": {}},
+		},
+		{
+			FQDN:   "exit-node.netbird.cloud",
+			IP:     "100.64.0.12",
+			Routes: map[string]struct{}{"0.0.0.0/0": {}},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		destination     string
+		expectedPrefix  string
+		expectedGateway string
+	}{
+		{
+			name:            "most specific route wins",
+			destination:     "10.0.1.10",
+			expectedPrefix:  "10.0.1.0/24",
+			expectedGateway: "router-b.netbird.cloud",
+		},
+		{
+			name:            "covering route",
+			destination:     "10.0.2.0/24",
+			expectedPrefix:  "10.0.0.0/16",
+			expectedGateway: "router-a.netbird.cloud",
+		},
+		{
+			name:            "default route",
+			destination:     "192.168.1.0/24",
+			expectedPrefix:  "0.0.0.0/0",
+			expectedGateway: "exit-node.netbird.cloud",
+		},
+		{
+			name:            "peer IP is reached directly with a default route",
+			destination:     "100.64.0.10",
+			expectedPrefix:  "100.64.0.10/32",
+			expectedGateway: "router-a.netbird.cloud",
+		},
+		{
+			name:            "offline peer IP is reached directly with a default route",
+			destination:     "100.64.0.11",
+			expectedPrefix:  "100.64.0.11/32",
+			expectedGateway: "router-b.netbird.cloud",
+		},
+		{
+			name:            "peer IP wins over a route of the same length",
+			destination:     "100.64.0.12",
+			expectedPrefix:  "100.64.0.12/32",
+			expectedGateway: "exit-node.netbird.cloud",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			destination, err := parseDestination(tt.destination)
+			require.NoError(t, err)
+
+			prefix, gateway, found := findRouteForDestination(peers, destination)
+			require.True(t, found)
+			assert.Equal(t, tt.expectedPrefix, prefix.String())
+			assert.Equal(t, tt.expectedGateway, gateway.FQDN)
+		})
+	}
+
+	_, _, found := findRouteForDestination(peers[:2], netip.MustParsePrefix("192.168.1.0/24"))
+	assert.False(t, found)
+}
+
+func TestRouteTest(t *testing.T) {
+	ctx := internal.CtxInitState(context.Background())
+	s := New(ctx, t.TempDir()+"/config.json", "debug")
+	s.statusRecorder = peer.NewRecorder("")
+
+	require.NoError(t, s.statusRecorder.AddPeer("key-a", "router-a.netbird.cloud"))
+	require.NoError(t, s.statusRecorder.UpdatePeerState(peer.State{PubKey: "key-a", Routes: map[string]struct{}{"10.0.0.0/16": {}}}))
+	require.NoError(t, s.statusRecorder.AddPeer("key-b", "router-b.netbird.cloud"))
+
+	resp, err := s.RouteTest(ctx, &proto.RouteTestRequest{PeerFqdn: "router-a", Destination: "10.0.1.10"})
+	require.NoError(t, err)
+	assert.True(t, resp.GetFound())
+	assert.Equal(t, "router-a.netbird.cloud", resp.GetPeerFqdn(), "the hostname resolves to the FQDN")
+	assert.True(t, resp.GetServedByPeer())
+
+	resp, err = s.RouteTest(ctx, &proto.RouteTestRequest{PeerFqdn: "router-b.netbird.cloud", Destination: "10.0.1.10"})
+	require.NoError(t, err)
+	assert.Equal(t, "router-a.netbird.cloud", resp.GetGatewayFqdn())
+	assert.False(t, resp.GetServedByPeer())
+
+	require.NoError(t, s.statusRecorder.AddPeer("key-exit", "exit-node.netbird.cloud"))
+	require.NoError(t, s.statusRecorder.UpdatePeerState(peer.State{PubKey: "key-exit", Routes: map[string]struct{}{"0.0.0.0/0": {}}}))
+	require.NoError(t, s.statusRecorder.UpdatePeerState(peer.State{PubKey: "key-b", IP: "100.64.0.11"}))
+
+	resp, err = s.RouteTest(ctx, &proto.RouteTestRequest{PeerFqdn: "router-b", Destination: "100.64.0.11"})
+	require.NoError(t, err)
+	assert.Equal(t, "router-b.netbird.cloud", resp.GetGatewayFqdn())
+	assert.True(t, resp.GetServedByPeer(), "the peer is reached directly rather than through the exit node")
+
+	_, err = s.RouteTest(ctx, &proto.RouteTestRequest{PeerFqdn: "router-c", Destination: "10.0.1.10"})
+	assert.Equal(t, codes.NotFound, gstatus.Code(err))
+}
@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"runtime"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -16,10 +17,12 @@ import (
 	"github.com/netbirdio/netbird/client/internal/auth"
 	"github.com/netbirdio/netbird/client/system"
 
+	"github.com/shirou/gopsutil/v3/process"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	gstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/netbirdio/netbird/client/internal"
@@ -40,6 +43,9 @@ const (
 	defaultRetryMultiplier  = 1.7
 )
 
+// daemonStartedAt is the time the daemon process was initialized
+var daemonStartedAt = time.Now()
+
 // Server for service control.
 type Server struct {
 	rootCtx   context.Context
@@ -133,6 +139,7 @@ func (s *Server) Start() error {
 	}
 	s.statusRecorder.UpdateManagementAddress(config.ManagementURL.String())
 	s.statusRecorder.UpdateRosenpass(config.RosenpassEnabled, config.RosenpassPermissive)
+	s.statusRecorder.UpdateCustomDNSTTL(config.CustomDNSTTL)
 
 	if s.sessionWatcher == nil {
 		s.sessionWatcher = internal.NewSessionWatcher(s.rootCtx, s.statusRecorder)
@@ -343,6 +350,36 @@ func (s *Server) Login(callerCtx context.Context, msg *proto.LoginRequest) (*pro
 		s.latestConfigInput.InterfaceName = msg.InterfaceName
 	}
 
+	if msg.CustomDNSTTL != nil {
+		ttl := time.Duration(*msg.CustomDNSTTL) * time.Second
+		if err := internal.ValidateDNSTTL(ttl); err != nil {
+			s.mutex.Unlock()
+			return nil, gstatus.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		inputConfig.CustomDNSTTL = &ttl
+		s.latestConfigInput.CustomDNSTTL = &ttl
+	}
+
+	if msg.ForceTCP != nil {
+		inputConfig.ForceTCP = msg.ForceTCP
+		s.latestConfigInput.ForceTCP = msg.ForceTCP
+	}
+
+	if msg.AutoTCP != nil {
+		inputConfig.AutoTCP = msg.AutoTCP
+		s.latestConfigInput.AutoTCP = msg.AutoTCP
+	}
+
+	if msg.PeerAllowedIPsOverrides != nil {
+		overrides, err := internal.ParsePeerAllowedIPsOverrides(msg.PeerAllowedIPsOverrides)
+		if err != nil {
+			s.mutex.Unlock()
+			return nil, gstatus.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		inputConfig.PeerAllowedIPsOverrides = overrides
+		s.latestConfigInput.PeerAllowedIPsOverrides = overrides
+	}
+
 	if msg.WireguardPort != nil {
 		port := int(*msg.WireguardPort)
 		inputConfig.WireguardPort = &port
@@ -553,12 +590,65 @@ func (s *Server) Up(callerCtx context.Context, _ *proto.UpRequest) (*proto.UpRes
 	}
 	s.statusRecorder.UpdateManagementAddress(s.config.ManagementURL.String())
 	s.statusRecorder.UpdateRosenpass(s.config.RosenpassEnabled, s.config.RosenpassPermissive)
+	s.statusRecorder.UpdateCustomDNSTTL(s.config.CustomDNSTTL)
 
 	go s.connectWithRetryRuns(ctx, s.config, s.statusRecorder, s.mgmProbe, s.signalProbe, s.relayProbe, s.wgProbe)
 
 	return &proto.UpResponse{}, nil
 }
 
+// SetCustomDNSTTL stores the TTL of the DNS records pushed to the local resolver. A running engine applies it on the
+// next DNS update, no reconnection needed
+func (s *Server) SetCustomDNSTTL(_ context.Context, msg *proto.SetCustomDNSTTLRequest) (*proto.SetCustomDNSTTLResponse, error) {
+	ttl := time.Duration(msg.GetCustomDNSTTL()) * time.Second
+	if err := internal.ValidateDNSTTL(ttl); err != nil {
+		return nil, gstatus.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	inputConfig := s.latestConfigInput
+	inputConfig.CustomDNSTTL = &ttl
+	config, err := internal.UpdateOrCreateConfig(inputConfig)
+	if err != nil {
+		return nil, err
+	}
+	s.latestConfigInput.CustomDNSTTL = &ttl
+	s.config = config
+
+	if s.statusRecorder != nil {
+		s.statusRecorder.UpdateCustomDNSTTL(ttl)
+	}
+
+	return &proto.SetCustomDNSTTLResponse{}, nil
+}
+
+// SetLatencyColorThresholds stores the latency color thresholds of the status command, which loads them as its default
+func (s *Server) SetLatencyColorThresholds(_ context.Context, msg *proto.SetLatencyColorThresholdsRequest) (*proto.SetLatencyColorThresholdsResponse, error) {
+	thresholds := make([]int, 0, len(msg.GetThresholds()))
+	for _, threshold := range msg.GetThresholds() {
+		thresholds = append(thresholds, int(threshold))
+	}
+	if err := internal.ValidateLatencyColorThresholds(thresholds); err != nil {
+		return nil, gstatus.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	inputConfig := s.latestConfigInput
+	inputConfig.LatencyColorThresholds = thresholds
+	config, err := internal.UpdateOrCreateConfig(inputConfig)
+	if err != nil {
+		return nil, err
+	}
+	s.latestConfigInput.LatencyColorThresholds = thresholds
+	s.config = config
+
+	return &proto.SetLatencyColorThresholdsResponse{}, nil
+}
+
 // Down engine work in the daemon.
 func (s *Server) Down(_ context.Context, _ *proto.DownRequest) (*proto.DownResponse, error) {
 	s.mutex.Lock()
@@ -594,6 +684,7 @@ func (s *Server) Status(
 	}
 	s.statusRecorder.UpdateManagementAddress(s.config.ManagementURL.String())
 	s.statusRecorder.UpdateRosenpass(s.config.RosenpassEnabled, s.config.RosenpassPermissive)
+	s.statusRecorder.UpdateCustomDNSTTL(s.config.CustomDNSTTL)
 
 	if msg.GetFullPeerStatus {
 		s.runProbes()
@@ -601,11 +692,131 @@ func (s *Server) Status(
 		fullStatus := s.statusRecorder.GetFullStatus()
 		pbFullStatus := toProtoFullStatus(fullStatus)
 		statusResponse.FullStatus = pbFullStatus
+
+		if msg.GetIncludeOSInfo() {
+			addHostInfo(pbFullStatus.LocalPeerState)
+		}
 	}
 
 	return &statusResponse, nil
 }
 
+// addHostInfo fills the local peer state with the OS and hardware details of the daemon host
+func addHostInfo(localPeerState *proto.LocalPeerState) {
+	hostInfo, err := system.GetHostInfo()
+	if err != nil {
+		log.Warnf("failed to get host info: %v", err)
+		return
+	}
+
+	localPeerState.Os = hostInfo.OS
+	localPeerState.Kernel = hostInfo.Kernel
+	localPeerState.Arch = hostInfo.Arch
+	localPeerState.TotalRAMMB = hostInfo.TotalRAMMB
+}
+
+// GetDaemonUptime returns the time the daemon was started at
+func (s *Server) GetDaemonUptime(_ context.Context, _ *proto.GetDaemonUptimeRequest) (*proto.GetDaemonUptimeResponse, error) {
+	return &proto.GetDaemonUptimeResponse{StartedAt: timestamppb.New(daemonStartedAt)}, nil
+}
+
+// GetPeerEvents returns the connection status changes recorded for the peer with the requested FQDN
+func (s *Server) GetPeerEvents(_ context.Context, msg *proto.GetPeerEventsRequest) (*proto.GetPeerEventsResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.statusRecorder == nil {
+		return nil, gstatus.Errorf(codes.FailedPrecondition, "service is not up")
+	}
+
+	for _, peerState := range s.statusRecorder.GetFullStatus().Peers {
+		if !strings.EqualFold(strings.TrimSuffix(peerState.FQDN, "."), strings.TrimSuffix(msg.GetFqdn(), ".")) {
+			continue
+		}
+
+		resp := &proto.GetPeerEventsResponse{}
+		for _, event := range s.statusRecorder.GetPeerEvents(peerState.PubKey) {
+			resp.Events = append(resp.Events, &proto.PeerEvent{
+				ConnStatus: event.ConnStatus.String(),
+				Timestamp:  timestamppb.New(event.Timestamp),
+			})
+		}
+		return resp, nil
+	}
+
+	return nil, gstatus.Errorf(codes.NotFound, "peer %s not found", msg.GetFqdn())
+}
+
+// GetGroupMembers returns the FQDNs of the peers belonging to any of the requested management groups, group names are case-insensitive
+func (s *Server) GetGroupMembers(_ context.Context, msg *proto.GetGroupMembersRequest) (*proto.GetGroupMembersResponse, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.statusRecorder == nil {
+		return nil, gstatus.Errorf(codes.FailedPrecondition, "service is not up")
+	}
+
+	resp := &proto.GetGroupMembersResponse{}
+	for _, peerState := range s.statusRecorder.GetFullStatus().Peers {
+		if peerInGroups(peerState.Groups, msg.GetGroups()) {
+			resp.Fqdns = append(resp.Fqdns, peerState.FQDN)
+		}
+	}
+	return resp, nil
+}
+
+// GetProcessStats returns the resident set size, the heap in use and the number of goroutines of the daemon process
+func (s *Server) GetProcessStats(ctx context.Context, _ *proto.GetProcessStatsRequest) (*proto.GetProcessStatsResponse, error) {
+	if !callerIsOwnerOrRoot(ctx) {
+		return nil, gstatus.Errorf(codes.PermissionDenied, "daemon process stats are only available to root or the daemon user")
+	}
+
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	resp := &proto.GetProcessStatsResponse{
+		HeapInUseBytes: memStats.HeapInuse,
+		Goroutines:     uint32(runtime.NumGoroutine()),
+	}
+
+	proc, err := process.NewProcess(int32(os.Getpid()))
+	if err != nil {
+		return nil, gstatus.Errorf(codes.Internal, "failed to get the daemon process: %v", err)
+	}
+	memInfo, err := proc.MemoryInfoWithContext(ctx)
+	if err != nil {
+		return nil, gstatus.Errorf(codes.Internal, "failed to get the daemon memory info: %v", err)
+	}
+	resp.RssBytes = memInfo.RSS
+
+	return resp, nil
+}
+
+// GetProcessInfo returns the PID of the daemon. The PID of a daemon running as root is only shown to root, the daemon
+// user or callers asking for sensitive information
+func (s *Server) GetProcessInfo(ctx context.Context, msg *proto.GetProcessInfoRequest) (*proto.GetProcessInfoResponse, error) {
+	if !pidVisible(os.Geteuid() == 0, callerIsOwnerOrRoot(ctx), msg.GetShowSensitive()) {
+		return nil, gstatus.Errorf(codes.PermissionDenied, "the PID of a daemon running as root is only shown to other users with --show-sensitive")
+	}
+
+	return &proto.GetProcessInfoResponse{Pid: int32(os.Getpid())}, nil
+}
+
+func pidVisible(daemonIsRoot, callerIsOwnerOrRoot, showSensitive bool) bool {
+	return !daemonIsRoot || callerIsOwnerOrRoot || showSensitive
+}
+
+func peerInGroups(peerGroups, groups []string) bool {
+	for _, peerGroup := range peerGroups {
+		for _, group := range groups {
+			if strings.EqualFold(peerGroup, group) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (s *Server) runProbes() {
 	if time.Since(s.lastProbe) > probeThreshold {
 		managementHealthy := s.mgmProbe.Probe()
@@ -628,6 +839,7 @@ func (s *Server) GetConfig(_ context.Context, _ *proto.GetConfigRequest) (*proto
 	managementURL := s.latestConfigInput.ManagementURL
 	adminURL := s.latestConfigInput.AdminURL
 	preSharedKey := ""
+	var latencyColorThresholds []int32
 
 	if s.config != nil {
 		if managementURL == "" && s.config.ManagementURL != nil {
@@ -643,14 +855,18 @@ func (s *Server) GetConfig(_ context.Context, _ *proto.GetConfigRequest) (*proto
 			preSharedKey = "**********"
 		}
 
+		for _, threshold := range s.config.LatencyColorThresholds {
+			latencyColorThresholds = append(latencyColorThresholds, int32(threshold))
+		}
 	}
 
 	return &proto.GetConfigResponse{
-		ManagementUrl: managementURL,
-		AdminURL:      adminURL,
-		ConfigFile:    s.latestConfigInput.ConfigPath,
-		LogFile:       s.logFile,
-		PreSharedKey:  preSharedKey,
+		ManagementUrl:          managementURL,
+		AdminURL:               adminURL,
+		ConfigFile:             s.latestConfigInput.ConfigPath,
+		LogFile:                s.logFile,
+		PreSharedKey:           preSharedKey,
+		LatencyColorThresholds: latencyColorThresholds,
 	}, nil
 }
 
@@ -671,6 +887,7 @@ func toProtoFullStatus(fullStatus peer.FullStatus) *proto.FullStatus {
 		SignalState:     &proto.SignalState{},
 		LocalPeerState:  &proto.LocalPeerState{},
 		Peers:           []*proto.PeerState{},
+		Version:         fullStatus.Version,
 	}
 
 	pbFullStatus.ManagementState.URL = fullStatus.ManagementState.URL
@@ -692,6 +909,7 @@ func toProtoFullStatus(fullStatus peer.FullStatus) *proto.FullStatus {
 	pbFullStatus.LocalPeerState.RosenpassPermissive = fullStatus.RosenpassState.Permissive
 	pbFullStatus.LocalPeerState.RosenpassEnabled = fullStatus.RosenpassState.Enabled
 	pbFullStatus.LocalPeerState.Routes = maps.Keys(fullStatus.LocalPeerState.Routes)
+	pbFullStatus.LocalPeerState.DnsListenAddress = fullStatus.DNSListenAddress
 
 	for _, peerState := range fullStatus.Peers {
 		pbPeerState := &proto.PeerState{
@@ -711,6 +929,25 @@ func toProtoFullStatus(fullStatus peer.FullStatus) *proto.FullStatus {
 			BytesTx:                    peerState.BytesTx,
 			RosenpassEnabled:           peerState.RosenpassEnabled,
 			Routes:                     maps.Keys(peerState.Routes),
+			Latency:                    durationpb.New(peerState.Latency),
+			AdvertisedRoutes:           peerState.AdvertisedRoutes,
+			Version:                    peerState.Version,
+			AllowedIPs:                 peerState.AllowedIPs,
+			AllowedIPsOverride:         peerState.AllowedIPsOverride,
+			OsName:                     peerState.OSName,
+			OsVersion:                  peerState.OSVersion,
+			KernelVersion:              peerState.KernelVersion,
+			CipherSuite:                peerState.CipherSuite,
+		}
+		if stats := peerState.TransportStats; stats.Protocol != "" {
+			pbPeerState.TransportStats = &proto.TransportStats{
+				Protocol:        stats.Protocol,
+				LocalPort:       int64(stats.LocalPort),
+				RemoteAddress:   stats.RemoteAddress,
+				PacketsSent:     stats.PacketsSent,
+				PacketsReceived: stats.PacketsReceived,
+				Retransmits:     stats.Retransmits,
+			}
 		}
 		pbFullStatus.Peers = append(pbFullStatus.Peers, pbPeerState)
 	}
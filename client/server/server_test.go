@@ -3,15 +3,19 @@ package server
 import (
 	"context"
 	"net"
+	"reflect"
 	"testing"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/keepalive"
+	gstatus "google.golang.org/grpc/status"
 
 	"github.com/netbirdio/netbird/client/internal"
 	"github.com/netbirdio/netbird/client/internal/peer"
+	daemonProto "github.com/netbirdio/netbird/client/proto"
 	mgmtProto "github.com/netbirdio/netbird/management/proto"
 	"github.com/netbirdio/netbird/management/server"
 	"github.com/netbirdio/netbird/management/server/activity"
@@ -74,6 +78,66 @@ func TestConnectWithRetryRuns(t *testing.T) {
 	}
 }
 
+func TestSetCustomDNSTTL(t *testing.T) {
+	ctx := internal.CtxInitState(context.Background())
+	s := New(ctx, t.TempDir()+"/config.json", "debug")
+	s.statusRecorder = peer.NewRecorder("")
+
+	if _, err := s.SetCustomDNSTTL(ctx, &daemonProto.SetCustomDNSTTLRequest{CustomDNSTTL: 0}); gstatus.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a zero TTL, got %v", err)
+	}
+	if _, err := s.SetCustomDNSTTL(ctx, &daemonProto.SetCustomDNSTTLRequest{CustomDNSTTL: 25 * 3600}); gstatus.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a TTL above 24h, got %v", err)
+	}
+
+	if _, err := s.SetCustomDNSTTL(ctx, &daemonProto.SetCustomDNSTTLRequest{CustomDNSTTL: 30}); err != nil {
+		t.Fatalf("failed setting the TTL: %v", err)
+	}
+	if ttl := s.statusRecorder.GetCustomDNSTTL(); ttl != 30*time.Second {
+		t.Fatalf("expected the running engine TTL to be 30s, got %s", ttl)
+	}
+
+	config, err := internal.ReadConfig(s.latestConfigInput.ConfigPath)
+	if err != nil {
+		t.Fatalf("failed reading the config: %v", err)
+	}
+	if config.CustomDNSTTL != 30*time.Second {
+		t.Fatalf("expected the stored TTL to be 30s, got %s", config.CustomDNSTTL)
+	}
+}
+
+func TestSetLatencyColorThresholds(t *testing.T) {
+	ctx := internal.CtxInitState(context.Background())
+	s := New(ctx, t.TempDir()+"/config.json", "debug")
+
+	for _, invalid := range [][]int32{{10, 100}, {100, 10, 500}, {-1, 10, 500}} {
+		_, err := s.SetLatencyColorThresholds(ctx, &daemonProto.SetLatencyColorThresholdsRequest{Thresholds: invalid})
+		if gstatus.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument for the thresholds %v, got %v", invalid, err)
+		}
+	}
+
+	if _, err := s.SetLatencyColorThresholds(ctx, &daemonProto.SetLatencyColorThresholdsRequest{Thresholds: []int32{0, 200, 800}}); err != nil {
+		t.Fatalf("failed setting the thresholds: %v", err)
+	}
+
+	config, err := internal.ReadConfig(s.latestConfigInput.ConfigPath)
+	if err != nil {
+		t.Fatalf("failed reading the config: %v", err)
+	}
+	if !reflect.DeepEqual(config.LatencyColorThresholds, []int{0, 200, 800}) {
+		t.Fatalf("expected the stored thresholds to be [0 200 800], got %v", config.LatencyColorThresholds)
+	}
+
+	resp, err := s.GetConfig(ctx, &daemonProto.GetConfigRequest{})
+	if err != nil {
+		t.Fatalf("failed getting the config: %v", err)
+	}
+	if !reflect.DeepEqual(resp.GetLatencyColorThresholds(), []int32{0, 200, 800}) {
+		t.Fatalf("expected the config to return the thresholds [0 200 800], got %v", resp.GetLatencyColorThresholds())
+	}
+}
+
 type mockServer struct {
 	mgmtProto.ManagementServiceServer
 	counter *int
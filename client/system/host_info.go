@@ -0,0 +1,37 @@
+package system
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shirou/gopsutil/v3/host"
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+// HostInfo holds the operating system and hardware details of the host running the daemon
+type HostInfo struct {
+	OS         string
+	Kernel     string
+	Arch       string
+	TotalRAMMB int64
+}
+
+// GetHostInfo collects the operating system, kernel, architecture and memory details of the current host
+func GetHostInfo() (*HostInfo, error) {
+	hi, err := host.Info()
+	if err != nil {
+		return nil, fmt.Errorf("get host info: %w", err)
+	}
+
+	vm, err := mem.VirtualMemory()
+	if err != nil {
+		return nil, fmt.Errorf("get memory info: %w", err)
+	}
+
+	return &HostInfo{
+		OS:         strings.TrimSpace(hi.Platform + " " + hi.PlatformVersion),
+		Kernel:     hi.KernelVersion,
+		Arch:       hi.KernelArch,
+		TotalRAMMB: int64(vm.Total / 1024 / 1024),
+	}, nil
+}
@@ -31,6 +31,7 @@ type WGStats struct {
 	LastHandshake time.Time
 	TxBytes       int64
 	RxBytes       int64
+	AllowedIPs    []string
 }
 
 // IsUserspaceBind indicates whether this interfaces is userspace with bind.ICEBind
@@ -89,6 +90,15 @@ func (w *WGIface) RemovePeer(peerKey string) error {
 	return w.configurer.removePeer(peerKey)
 }
 
+// RekeyPeer drops the session keys of a Wireguard Peer, the next packet to it starts a new handshake
+func (w *WGIface) RekeyPeer(peerKey string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	log.Debugf("rekeying peer %s on interface %s", peerKey, w.tun.DeviceName())
+	return w.configurer.rekeyPeer(peerKey)
+}
+
 // AddAllowedIP adds a prefix to the allowed IPs list of peer
 func (w *WGIface) AddAllowedIP(peerKey string, allowedIP string) error {
 	w.mu.Lock()
@@ -271,6 +271,61 @@ func Test_UpdatePeer(t *testing.T) {
 	}
 }
 
+func Test_UpdatePeerWithAddedAllowedIP(t *testing.T) {
+	ifaceName := fmt.Sprintf("utun%d", WgIntNumber+5)
+	wgIP := "10.99.99.17/30"
+	newNet, err := stdnet.NewNet()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	iface, err := NewWGIFace(ifaceName, wgIP, 33100, key, DefaultMTU, newNet, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = iface.Create()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		err = iface.Close()
+		if err != nil {
+			t.Error(err)
+		}
+	}()
+
+	_, err = iface.Up()
+	if err != nil {
+		t.Fatal(err)
+	}
+	peerIP := "10.99.99.18/32"
+	overrideIP := "10.100.0.0/16"
+	endpoint, err := net.ResolveUDPAddr("udp", "127.0.0.1:9900")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = iface.UpdatePeer(peerPubKey, peerIP, 15*time.Second, endpoint, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = iface.AddAllowedIP(peerPubKey, overrideIP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	peer, err := getPeer(ifaceName, peerPubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var allowedIPs []string
+	for _, aip := range peer.AllowedIPs {
+		allowedIPs = append(allowedIPs, aip.String())
+	}
+	if len(allowedIPs) != 2 || allowedIPs[0] != peerIP || allowedIPs[1] != overrideIP {
+		t.Fatalf("expected peer Allowed IPs %s and %s, got %v", peerIP, overrideIP, allowedIPs)
+	}
+}
+
 func Test_RemovePeer(t *testing.T) {
 	ifaceName := fmt.Sprintf("utun%d", WgIntNumber+4)
 	wgIP := "10.99.99.13/30"
@@ -15,4 +15,5 @@ type wgConfigurer interface {
 	removeAllowedIP(peerKey string, allowedIP string) error
 	close()
 	getStats(peerKey string) (WGStats, error)
+	rekeyPeer(peerKey string) error
 }
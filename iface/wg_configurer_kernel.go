@@ -164,6 +164,44 @@ func (c *wgKernelConfigurer) removeAllowedIP(peerKey string, allowedIP string) e
 	return nil
 }
 
+// rekeyPeer removes the peer and adds it back with the same configuration, which drops its session keys
+func (c *wgKernelConfigurer) rekeyPeer(peerKey string) error {
+	peer, err := c.getPeer(c.deviceName, peerKey)
+	if err != nil {
+		return fmt.Errorf("get peer %s: %w", peerKey, err)
+	}
+
+	err = c.configure(wgtypes.Config{Peers: []wgtypes.PeerConfig{{PublicKey: peer.PublicKey, Remove: true}}})
+	if err != nil {
+		return fmt.Errorf(`received error "%w" while removing peer %s from interface %s`, err, peerKey, c.deviceName)
+	}
+
+	err = c.configure(wgtypes.Config{Peers: []wgtypes.PeerConfig{readdPeerConfig(peer)}})
+	if err != nil {
+		return fmt.Errorf(`received error "%w" while adding peer %s back to interface %s`, err, peerKey, c.deviceName)
+	}
+	return nil
+}
+
+// readdPeerConfig configures the peer as it was before its removal, without its session
+func readdPeerConfig(peer wgtypes.Peer) wgtypes.PeerConfig {
+	config := wgtypes.PeerConfig{
+		PublicKey:         peer.PublicKey,
+		Endpoint:          peer.Endpoint,
+		ReplaceAllowedIPs: true,
+		AllowedIPs:        peer.AllowedIPs,
+	}
+	if peer.PersistentKeepaliveInterval > 0 {
+		keepalive := peer.PersistentKeepaliveInterval
+		config.PersistentKeepaliveInterval = &keepalive
+	}
+	if peer.PresharedKey != (wgtypes.Key{}) {
+		presharedKey := peer.PresharedKey
+		config.PresharedKey = &presharedKey
+	}
+	return config
+}
+
 func (c *wgKernelConfigurer) getPeer(ifaceName, peerPubKey string) (wgtypes.Peer, error) {
 	wg, err := wgctrl.New()
 	if err != nil {
@@ -213,9 +251,14 @@ func (c *wgKernelConfigurer) getStats(peerKey string) (WGStats, error) {
 	if err != nil {
 		return WGStats{}, fmt.Errorf("get wireguard stats: %w", err)
 	}
+	allowedIPs := make([]string, 0, len(peer.AllowedIPs))
+	for _, allowedIP := range peer.AllowedIPs {
+		allowedIPs = append(allowedIPs, allowedIP.String())
+	}
 	return WGStats{
 		LastHandshake: peer.LastHandshakeTime,
 		TxBytes:       peer.TransmitBytes,
 		RxBytes:       peer.ReceiveBytes,
+		AllowedIPs:    allowedIPs,
 	}, nil
 }
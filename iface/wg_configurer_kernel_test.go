@@ -0,0 +1,44 @@
+//go:build linux && !android
+
+package iface
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
+)
+
+func TestReaddPeerConfig(t *testing.T) {
+	key, err := wgtypes.GeneratePrivateKey()
+	require.NoError(t, err)
+	presharedKey, err := wgtypes.GenerateKey()
+	require.NoError(t, err)
+
+	peer := wgtypes.Peer{
+		PublicKey:                   key.PublicKey(),
+		PresharedKey:                presharedKey,
+		Endpoint:                    &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 51820},
+		PersistentKeepaliveInterval: 25 * time.Second,
+		LastHandshakeTime:           time.Now(),
+		AllowedIPs:                  []net.IPNet{{IP: net.ParseIP("100.64.0.1").To4(), Mask: net.CIDRMask(32, 32)}},
+	}
+
+	config := readdPeerConfig(peer)
+	assert.Equal(t, peer.PublicKey, config.PublicKey)
+	assert.Equal(t, peer.Endpoint, config.Endpoint)
+	assert.Equal(t, peer.AllowedIPs, config.AllowedIPs)
+	assert.True(t, config.ReplaceAllowedIPs)
+	assert.False(t, config.Remove)
+	require.NotNil(t, config.PersistentKeepaliveInterval)
+	assert.Equal(t, 25*time.Second, *config.PersistentKeepaliveInterval)
+	require.NotNil(t, config.PresharedKey)
+	assert.Equal(t, presharedKey, *config.PresharedKey)
+
+	config = readdPeerConfig(wgtypes.Peer{PublicKey: key.PublicKey()})
+	assert.Nil(t, config.PersistentKeepaliveInterval, "a peer without keepalive keeps none")
+	assert.Nil(t, config.PresharedKey, "a peer without preshared key keeps none")
+}
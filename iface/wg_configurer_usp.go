@@ -92,6 +92,21 @@ func (c *wgUSPConfigurer) removePeer(peerKey string) error {
 	return c.device.IpcSet(toWgUserspaceString(config))
 }
 
+// rekeyPeer expires the session keys of the peer, the next packet to it starts the handshake of a new session
+func (c *wgUSPConfigurer) rekeyPeer(peerKey string) error {
+	peerKeyParsed, err := wgtypes.ParseKey(peerKey)
+	if err != nil {
+		return err
+	}
+
+	peer := c.device.LookupPeer(device.NoisePublicKey(peerKeyParsed))
+	if peer == nil {
+		return fmt.Errorf("peer not found: %s", peerKey)
+	}
+	peer.ExpireCurrentKeypairs()
+	return nil
+}
+
 func (c *wgUSPConfigurer) addAllowedIP(peerKey string, allowedIP string) error {
 	_, ipNet, err := net.ParseCIDR(allowedIP)
 	if err != nil {
@@ -245,9 +260,37 @@ func (t *wgUSPConfigurer) getStats(peerKey string) (WGStats, error) {
 		LastHandshake: time.Unix(sec, nsec),
 		TxBytes:       txBytes,
 		RxBytes:       rxBytes,
+		AllowedIPs:    findPeerAllowedIPs(ipc, peerKey),
 	}, nil
 }
 
+// findPeerAllowedIPs returns every allowed_ip of the peer, findPeerInfo keeps only the last value of repeated keys
+func findPeerAllowedIPs(ipcInput string, peerKey string) []string {
+	peerKeyParsed, err := wgtypes.ParseKey(peerKey)
+	if err != nil {
+		return nil
+	}
+	peerLine := fmt.Sprintf("public_key=%s", hex.EncodeToString(peerKeyParsed[:]))
+
+	allowedIPs := []string{}
+	foundPeer := false
+	for _, line := range strings.Split(ipcInput, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "public_key=") {
+			if foundPeer {
+				break
+			}
+			foundPeer = line == peerLine
+			continue
+		}
+
+		if allowedIP, ok := strings.CutPrefix(line, "allowed_ip="); ok && foundPeer {
+			allowedIPs = append(allowedIPs, allowedIP)
+		}
+	}
+	return allowedIPs
+}
+
 func findPeerInfo(ipcInput string, peerKey string, searchConfigKeys []string) (map[string]string, error) {
 	peerKeyParsed, err := wgtypes.ParseKey(peerKey)
 	if err != nil {
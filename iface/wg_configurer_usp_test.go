@@ -102,3 +102,38 @@ func Test_findPeerInfo(t *testing.T) {
 		})
 	}
 }
+
+func Test_findPeerAllowedIPs(t *testing.T) {
+	tests := []struct {
+		name    string
+		peerKey string
+		want    []string
+	}{
+		{
+			name:    "single",
+			peerKey: "58402e695ba1772b1cc9309755f043251ea77fdcf10fbe63989ceb7e19321376",
+			want:    []string{"192.168.4.6/32"},
+		},
+		{
+			name:    "multiple",
+			peerKey: "662e14fd594556f522604703340351258903b64f35553763f19426ab2a515c58",
+			want:    []string{"192.168.4.10/32", "192.168.4.11/32"},
+		},
+		{
+			name:    "peer not found",
+			peerKey: "1111111111111111111111111111111111111111111111111111111111111111",
+			want:    []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := hex.DecodeString(tt.peerKey)
+			require.NoError(t, err)
+
+			key, err := wgtypes.NewKey(res)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, findPeerAllowedIPs(ipcFixture, key.String()))
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"net/netip"
 	"reflect"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -423,9 +424,33 @@ func (a *Account) GetPeerNetworkMap(peerID, dnsDomain string) *NetworkMap {
 		DNSConfig:     dnsUpdate,
 		OfflinePeers:  expiredPeers,
 		FirewallRules: firewallRules,
+		PeersGroups:   a.getPeersGroupNames(peersToConnect, expiredPeers),
 	}
 }
 
+// getPeersGroupNames returns the names of the groups each of the given peers belongs to, sorted by name
+func (a *Account) getPeersGroupNames(peerLists ...[]*nbpeer.Peer) map[string][]string {
+	peersGroups := make(map[string][]string)
+	for _, peers := range peerLists {
+		for _, p := range peers {
+			peersGroups[p.ID] = nil
+		}
+	}
+
+	for _, group := range a.Groups {
+		for _, peerID := range group.Peers {
+			if _, ok := peersGroups[peerID]; ok {
+				peersGroups[peerID] = append(peersGroups[peerID], group.Name)
+			}
+		}
+	}
+
+	for _, groups := range peersGroups {
+		sort.Strings(groups)
+	}
+	return peersGroups
+}
+
 // GetExpiredPeers returns peers that have been expired
 func (a *Account) GetExpiredPeers() []*nbpeer.Peer {
 	var peers []*nbpeer.Peer
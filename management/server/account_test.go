@@ -1473,6 +1473,22 @@ func TestAccount_GetRoutesToSync(t *testing.T) {
 	assert.Len(t, emptyRoutes, 0)
 }
 
+func TestAccount_GetPeersGroupNames(t *testing.T) {
+	account := &Account{
+		Groups: map[string]*Group{
+			"group1": {ID: "group1", Name: "web", Peers: []string{"peer-1", "peer-2"}},
+			"group2": {ID: "group2", Name: "All", Peers: []string{"peer-1", "peer-2", "peer-3"}},
+		},
+	}
+
+	peersGroups := account.getPeersGroupNames([]*nbpeer.Peer{{ID: "peer-1"}}, []*nbpeer.Peer{{ID: "peer-3"}})
+
+	assert.Equal(t, map[string][]string{
+		"peer-1": {"All", "web"},
+		"peer-3": {"All"},
+	}, peersGroups)
+}
+
 func TestAccount_Copy(t *testing.T) {
 	account := &Account{
 		Id:                     "account1",
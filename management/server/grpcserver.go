@@ -459,15 +459,19 @@ func toPeerConfig(peer *nbpeer.Peer, network *Network, dnsName string) *proto.Pe
 	}
 }
 
-func toRemotePeerConfig(peers []*nbpeer.Peer, dnsName string) []*proto.RemotePeerConfig {
+func toRemotePeerConfig(peers []*nbpeer.Peer, peersGroups map[string][]string, dnsName string) []*proto.RemotePeerConfig {
 	remotePeers := []*proto.RemotePeerConfig{}
 	for _, rPeer := range peers {
 		fqdn := rPeer.FQDN(dnsName)
 		remotePeers = append(remotePeers, &proto.RemotePeerConfig{
-			WgPubKey:   rPeer.Key,
-			AllowedIps: []string{fmt.Sprintf(AllowedIPsFormat, rPeer.IP)},
-			SshConfig:  &proto.SSHConfig{SshPubKey: []byte(rPeer.SSHKey)},
-			Fqdn:       fqdn,
+			WgPubKey:      rPeer.Key,
+			AllowedIps:    []string{fmt.Sprintf(AllowedIPsFormat, rPeer.IP)},
+			SshConfig:     &proto.SSHConfig{SshPubKey: []byte(rPeer.SSHKey)},
+			Fqdn:          fqdn,
+			Groups:        peersGroups[rPeer.ID],
+			OsName:        rPeer.Meta.OS,
+			OsVersion:     rPeer.Meta.OSVersion,
+			KernelVersion: strings.TrimSpace(rPeer.Meta.Kernel + " " + rPeer.Meta.KernelVersion),
 		})
 	}
 	return remotePeers
@@ -478,13 +482,13 @@ func toSyncResponse(config *Config, peer *nbpeer.Peer, turnCredentials *TURNCred
 
 	pConfig := toPeerConfig(peer, networkMap.Network, dnsName)
 
-	remotePeers := toRemotePeerConfig(networkMap.Peers, dnsName)
+	remotePeers := toRemotePeerConfig(networkMap.Peers, networkMap.PeersGroups, dnsName)
 
 	routesUpdate := toProtocolRoutes(networkMap.Routes)
 
 	dnsUpdate := toProtocolDNSConfig(networkMap.DNSConfig)
 
-	offlinePeers := toRemotePeerConfig(networkMap.OfflinePeers, dnsName)
+	offlinePeers := toRemotePeerConfig(networkMap.OfflinePeers, networkMap.PeersGroups, dnsName)
 
 	firewallRules := toProtocolFirewallRules(networkMap.FirewallRules)
 
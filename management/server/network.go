@@ -32,6 +32,8 @@ type NetworkMap struct {
 	DNSConfig     nbdns.Config
 	OfflinePeers  []*nbpeer.Peer
 	FirewallRules []*FirewallRule
+	// PeersGroups maps the ID of every peer of the network map to the names of the groups it belongs to
+	PeersGroups map[string][]string
 }
 
 type Network struct {